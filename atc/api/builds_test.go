@@ -654,18 +654,22 @@ var _ = Describe("Builds API", func() {
 								Version:         atc.Version{"version": "value1"},
 								ResourceID:      1,
 								FirstOccurrence: true,
+								Enabled:         true,
 							},
 							{
 								Name:            "input2",
 								Version:         atc.Version{"version": "value2"},
 								ResourceID:      2,
 								FirstOccurrence: false,
+								Enabled:         false,
+								Pinned:          true,
 							},
 						},
 							[]db.BuildOutput{
 								{
 									Name:    "myresource3",
 									Version: atc.Version{"version": "value3"},
+									Enabled: true,
 								},
 								{
 									Name:    "myresource4",
@@ -688,23 +692,31 @@ var _ = Describe("Builds API", func() {
 									"name": "input1",
 									"version": {"version": "value1"},
 									"pipeline_id": 42,
-									"first_occurrence": true
+									"first_occurrence": true,
+									"enabled": true,
+									"pinned": false
 								},
 								{
 									"name": "input2",
 									"version": {"version": "value2"},
 									"pipeline_id": 42,
-									"first_occurrence": false
+									"first_occurrence": false,
+									"enabled": false,
+									"pinned": true
 								}
 							],
 							"outputs": [
 								{
 									"name": "myresource3",
-									"version": {"version": "value3"}
+									"version": {"version": "value3"},
+									"enabled": true,
+									"pinned": false
 								},
 								{
 									"name": "myresource4",
-									"version": {"version": "value4"}
+									"version": {"version": "value4"},
+									"enabled": false,
+									"pinned": false
 								}
 							]
 						}`))
@@ -1019,6 +1031,19 @@ var _ = Describe("Builds API", func() {
 						})
 					})
 
+					Context("when the build has already finished", func() {
+						BeforeEach(func() {
+							build.MarkAsAbortedReturns(db.ErrInvalidBuildTransition{
+								From: db.BuildStatusSucceeded,
+								To:   db.BuildStatusAborted,
+							})
+						})
+
+						It("returns 204", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						})
+					})
+
 					Context("when aborting succeeds", func() {
 						BeforeEach(func() {
 							build.MarkAsAbortedReturns(nil)
@@ -14,6 +14,13 @@ func (s *Server) AbortBuild(build db.Build) http.Handler {
 		})
 
 		err := build.MarkAsAborted()
+		if _, ok := err.(db.ErrInvalidBuildTransition); ok {
+			// aborting is idempotent; there is nothing left to abort
+			aLog.Info("build-already-finished")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
 		if err != nil {
 			aLog.Error("failed-to-abort-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -23,6 +23,12 @@ func (s *Server) CreateBuild(team db.Team) http.Handler {
 		}
 
 		build, err := team.CreateStartedBuild(plan)
+		if _, ok := err.(db.ErrQuotaExceeded); ok {
+			hLog.Info("quota-exceeded", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
 		if err != nil {
 			hLog.Error("failed-to-create-one-off-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -7,9 +7,11 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/vito/go-sse/sse"
 )
 
@@ -17,6 +19,20 @@ const ProtocolVersionHeader = "X-ATC-Stream-Version"
 const CurrentProtocolVersion = "2.0"
 
 func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
+	return newEventHandler(logger, build, 0)
+}
+
+// NewEventHandlerFactory returns an EventHandlerFactory whose streams send a
+// heartbeat event whenever a build has been quiet for the given interval, so
+// that proxies don't time out the connection. A zero interval disables
+// heartbeats.
+func NewEventHandlerFactory(heartbeatInterval time.Duration) EventHandlerFactory {
+	return func(logger lager.Logger, build db.Build) http.Handler {
+		return newEventHandler(logger, build, heartbeatInterval)
+	}
+}
+
+func newEventHandler(logger lager.Logger, build db.Build, heartbeatInterval time.Duration) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		clientNotifier := w.(http.CloseNotifier)
 
@@ -55,7 +71,12 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 			writer.writeFlusher = gz
 		}
 
-		events, err := build.Events(eventID)
+		var opts []db.EventsOption
+		if heartbeatInterval > 0 {
+			opts = append(opts, db.WithHeartbeat(heartbeatInterval))
+		}
+
+		events, err := build.Events(eventID, opts...)
 		if err != nil {
 			logger.Error("failed-to-get-build-events", err, lager.Data{"build-id": build.ID(), "start": eventID})
 			w.WriteHeader(http.StatusInternalServerError)
@@ -85,6 +106,16 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 				return
 			}
 
+			if ev.Event == event.EventTypeHeartbeat {
+				err = writer.WriteHeartbeat(eventID, ev)
+				if err != nil {
+					logger.Info("failed-to-write-heartbeat", lager.Data{"error": err.Error()})
+					return
+				}
+
+				continue
+			}
+
 			err = writer.WriteEvent(eventID, ev)
 			if err != nil {
 				logger.Info("failed-to-write-event", lager.Data{"error": err.Error()})
@@ -124,6 +155,32 @@ func (writer eventWriter) WriteEvent(id uint, envelope interface{}) error {
 	return writer.flush()
 }
 
+// WriteHeartbeat writes a heartbeat with the id of the last event written
+// before it, as clients remember the id of every event they receive and
+// would otherwise resume from the wrong place after reconnecting.
+func (writer eventWriter) WriteHeartbeat(next uint, envelope interface{}) error {
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	id := ""
+	if next > 0 {
+		id = fmt.Sprintf("%d", next-1)
+	}
+
+	err = sse.Event{
+		ID:   id,
+		Name: "heartbeat",
+		Data: payload,
+	}.Write(writer.responseWriter)
+	if err != nil {
+		return err
+	}
+
+	return writer.flush()
+}
+
 func (writer eventWriter) WriteEnd(id uint) error {
 	err := sse.Event{ID: fmt.Sprintf("%d", id), Name: "end"}.Write(writer.responseWriter)
 	if err != nil {
@@ -67,7 +67,7 @@ var _ = Describe("Handler", func() {
 
 				fakeEventSource = new(dbfakes.FakeEventSource)
 
-				build.EventsStub = func(from uint) (db.EventSource, error) {
+				build.EventsStub = func(from uint, opts ...db.EventsOption) (db.EventSource, error) {
 					fakeEventSource.NextStub = func() (event.Envelope, error) {
 						defer GinkgoRecover()
 
@@ -103,7 +103,7 @@ var _ = Describe("Handler", func() {
 			It("gets the events from the right build, starting at 0", func() {
 				_ = response.Body.Close()
 				Eventually(build.EventsCallCount).Should(Equal(1))
-				actualFrom := build.EventsArgsForCall(0)
+				actualFrom, _ := build.EventsArgsForCall(0)
 				Expect(actualFrom).To(BeZero())
 			})
 
@@ -162,10 +162,55 @@ var _ = Describe("Handler", func() {
 				It("starts subscribing from after the id", func() {
 					_ = response.Body.Close()
 					Eventually(build.EventsCallCount).Should(Equal(1))
-					actualFrom := build.EventsArgsForCall(0)
+					actualFrom, _ := build.EventsArgsForCall(0)
 					Expect(actualFrom).To(Equal(uint(2)))
 				})
 			})
+
+			Context("when the event source returns a heartbeat", func() {
+				BeforeEach(func() {
+					heartbeat := json.RawMessage(`{"time":1}`)
+
+					returnedEvents = []event.Envelope{
+						fakeEvent(`{"event":1}`),
+						{
+							Data:    &heartbeat,
+							Event:   event.EventTypeHeartbeat,
+							Version: "1.0",
+						},
+						fakeEvent(`{"event":2}`),
+					}
+				})
+
+				It("emits it as a heartbeat with the id of the previous event", func() {
+					defer db.Close(response.Body)
+					reader := sse.NewReadCloser(response.Body)
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "0",
+						Name: "event",
+						Data: []byte(`{"data":{"event":1},"event":"fake","version":"42.0"}`),
+					}))
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "0",
+						Name: "heartbeat",
+						Data: []byte(`{"data":{"time":1},"event":"heartbeat","version":"1.0"}`),
+					}))
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "1",
+						Name: "event",
+						Data: []byte(`{"data":{"event":2},"event":"fake","version":"42.0"}`),
+					}))
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "2",
+						Name: "end",
+						Data: []byte{},
+					}))
+				})
+			})
 		})
 
 		Context("when the eventsource returns an error", func() {
@@ -164,153 +164,40 @@ var _ = Describe("Config API", func() {
 						fakePipeline = new(dbfakes.FakePipeline)
 						fakePipeline.NameReturns("something-else")
 						fakePipeline.ConfigVersionReturns(1)
-						fakePipeline.GroupsReturns(atc.GroupConfigs{
-							{
-								Name:      "some-group",
-								Jobs:      []string{"some-job"},
-								Resources: []string{"some-resource"},
-							},
-						})
 						fakeTeam.PipelineReturns(fakePipeline, true, nil)
 					})
 
-					Context("when the jobs are found", func() {
-						var fakeJob *dbfakes.FakeJob
+					Context("when getting the config succeeds", func() {
 						BeforeEach(func() {
-							fakeJob = new(dbfakes.FakeJob)
-							fakeJob.ConfigReturns(atc.JobConfig{
-								Name:   "some-job",
-								Public: true,
-								Serial: true,
-								Plan: atc.PlanSequence{
-									{
-										Get:      "some-input",
-										Resource: "some-resource",
-										Params: atc.Params{
-											"some-param": "some-value",
-											"nested": map[string]interface{}{
-												"key": "value",
-												"array": []interface{}{
-													map[string]interface{}{
-														"key": "value",
-													},
-												},
-											},
-										},
-									},
-									{
-										Task:       "some-task",
-										Privileged: true,
-										TaskConfig: &atc.TaskConfig{
-											Platform:  "linux",
-											RootfsURI: "some-image",
-											Run: atc.TaskRunConfig{
-												Path: "/path/to/run",
-											},
-										},
-									},
-									{
-										Put:      "some-output",
-										Resource: "some-resource",
-										Params: atc.Params{
-											"some-param": "some-value",
-											"nested": map[string]interface{}{
-												"key": "value",
-												"array": []interface{}{
-													map[string]interface{}{
-														"key": "value",
-													},
-												},
-											},
-										},
-									},
-								},
-							})
-
-							fakePipeline.JobsReturns(db.Jobs{fakeJob}, nil)
+							fakePipeline.ConfigReturns(pipelineConfig, nil)
 						})
 
-						Context("when the resources are found", func() {
-							var fakeResource *dbfakes.FakeResource
-							BeforeEach(func() {
-								fakeResource = new(dbfakes.FakeResource)
-								fakeResource.NameReturns("some-resource")
-								fakeResource.TypeReturns("some-type")
-								fakeResource.SourceReturns(atc.Source{
-									"source-config": "some-value",
-									"nested": map[string]interface{}{
-										"key": "value",
-										"array": []interface{}{
-											map[string]interface{}{
-												"key": "value",
-											},
-										},
-									},
-								})
-
-								fakePipeline.ResourcesReturns(db.Resources{fakeResource}, nil)
-							})
-
-							Context("when the resource types are found", func() {
-								var fakeResourceType *dbfakes.FakeResourceType
-								BeforeEach(func() {
-									fakeResourceType = new(dbfakes.FakeResourceType)
-									fakeResourceType.NameReturns("custom-resource")
-									fakeResourceType.TypeReturns("custom-type")
-									fakeResourceType.SourceReturns(atc.Source{"custom": "source"})
-									fakeResourceType.TagsReturns(atc.Tags{"some-tag"})
-
-									fakePipeline.ResourceTypesReturns(db.ResourceTypes{fakeResourceType}, nil)
-								})
-
-								It("returns 200", func() {
-									Expect(response.StatusCode).To(Equal(http.StatusOK))
-								})
-
-								It("returns Content-Type 'application/json'", func() {
-									Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
-								})
-
-								It("returns the config version as X-Concourse-Config-Version", func() {
-									Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("1"))
-								})
-
-								It("returns the config", func() {
-									var actualConfigResponse atc.ConfigResponse
-									err := json.NewDecoder(response.Body).Decode(&actualConfigResponse)
-									Expect(err).NotTo(HaveOccurred())
-
-									Expect(actualConfigResponse).To(Equal(atc.ConfigResponse{
-										Config: pipelineConfig,
-									}))
-								})
-							})
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
 
-							Context("when finding the resource types fails", func() {
-								BeforeEach(func() {
-									fakePipeline.ResourceTypesReturns(nil, errors.New("failed"))
-								})
+						It("returns Content-Type 'application/json'", func() {
+							Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+						})
 
-								It("returns 500", func() {
-									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
-								})
-							})
+						It("returns the config version as X-Concourse-Config-Version", func() {
+							Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("1"))
 						})
 
-						Context("when finding the resources fails", func() {
-							BeforeEach(func() {
-								fakePipeline.ResourcesReturns(nil, errors.New("failed"))
-							})
+						It("returns the config", func() {
+							var actualConfigResponse atc.ConfigResponse
+							err := json.NewDecoder(response.Body).Decode(&actualConfigResponse)
+							Expect(err).NotTo(HaveOccurred())
 
-							It("returns 500", func() {
-								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
-							})
+							Expect(actualConfigResponse).To(Equal(atc.ConfigResponse{
+								Config: pipelineConfig,
+							}))
 						})
 					})
 
-					Context("when finding the jobs fails", func() {
+					Context("when getting the config fails", func() {
 						BeforeEach(func() {
-							fakePipeline.JobsReturns(nil, errors.New("failed"))
+							fakePipeline.ConfigReturns(atc.Config{}, errors.New("failed"))
 						})
 
 						It("returns 500", func() {
@@ -505,6 +392,20 @@ var _ = Describe("Config API", func() {
 							})
 						})
 
+						Context("and the team has reached its pipeline quota", func() {
+							BeforeEach(func() {
+								dbTeam.SavePipelineReturns(nil, false, db.ErrQuotaExceeded{Quota: "pipelines", Limit: 2})
+							})
+
+							It("returns 403", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							})
+
+							It("returns the error in the response body", func() {
+								Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("failed to save config: team quota exceeded: limited to 2 pipelines")))
+							})
+						})
+
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
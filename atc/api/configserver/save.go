@@ -143,6 +143,13 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	}
 
 	_, created, err := team.SavePipeline(pipelineName, config, version, pausedState)
+	if _, ok := err.(db.ErrQuotaExceeded); ok {
+		session.Info("quota-exceeded", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprintf(w, "failed to save config: %s", err)
+		return
+	}
+
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -1482,6 +1482,16 @@ var _ = Describe("Jobs API", func() {
 						})
 					})
 
+					Context("when the team has reached its running builds quota", func() {
+						BeforeEach(func() {
+							fakeJob.CreateBuildReturns(nil, db.ErrQuotaExceeded{Quota: "running builds", Limit: 1})
+						})
+
+						It("returns a 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						})
+					})
+
 					Context("when triggering the build succeeds", func() {
 						BeforeEach(func() {
 							build := new(dbfakes.FakeBuild)
@@ -1497,8 +1507,13 @@ var _ = Describe("Jobs API", func() {
 							fakeJob.CreateBuildReturns(build, nil)
 						})
 
-						It("triggers the build", func() {
+						It("triggers a manual build of the job", func() {
 							Expect(fakeJob.CreateBuildCallCount()).To(Equal(1))
+							Expect(fakeJob.CreateBuildArgsForCall(0)).To(Equal(db.BuildTriggerManual))
+						})
+
+						It("does not override the job being paused", func() {
+							Expect(fakeJob.CreateBuildOverridingPauseCallCount()).To(Equal(0))
 						})
 
 						Context("when finding the pipeline resources fails", func() {
@@ -1551,6 +1566,37 @@ var _ = Describe("Jobs API", func() {
 							})
 						})
 					})
+
+					Context("when asked to override the job being paused", func() {
+						BeforeEach(func() {
+							var err error
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds?override_pause=true", nil)
+							Expect(err).NotTo(HaveOccurred())
+
+							build := new(dbfakes.FakeBuild)
+							build.IDReturns(42)
+							fakeJob.CreateBuildOverridingPauseReturns(build, nil)
+						})
+
+						It("triggers a build overriding the pause", func() {
+							Expect(fakeJob.CreateBuildOverridingPauseCallCount()).To(Equal(1))
+							Expect(fakeJob.CreateBuildCallCount()).To(Equal(0))
+						})
+
+						It("returns 200 OK", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+
+						Context("when triggering the build fails", func() {
+							BeforeEach(func() {
+								fakeJob.CreateBuildOverridingPauseReturns(nil, errors.New("nopers"))
+							})
+
+							It("returns a 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
 				})
 			})
 		})
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -32,7 +33,19 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		build, err := job.CreateBuild()
+		var build db.Build
+		if r.FormValue("override_pause") == "true" {
+			build, err = job.CreateBuildOverridingPause()
+		} else {
+			build, err = job.CreateBuild(db.BuildTriggerManual)
+		}
+
+		if _, ok := err.(db.ErrQuotaExceeded); ok {
+			logger.Info("quota-exceeded", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
 		if err != nil {
 			logger.Error("failed-to-create-job-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
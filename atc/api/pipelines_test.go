@@ -804,6 +804,25 @@ var _ = Describe("Pipelines API", func() {
 					Expect(dbPipeline.DestroyCallCount()).To(Equal(1))
 				})
 
+				It("aborts the pipeline's running builds first", func() {
+					Expect(dbPipeline.AbortAllRunningBuildsCallCount()).To(Equal(1))
+					Expect(dbPipeline.AbortAllRunningBuildsArgsForCall(0)).To(Equal("pipeline destroyed"))
+				})
+
+				Context("when aborting the pipeline's builds fails", func() {
+					BeforeEach(func() {
+						dbPipeline.AbortAllRunningBuildsReturns(nil, errors.New("disaster!"))
+					})
+
+					It("returns a 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+
+					It("does not delete the pipeline", func() {
+						Expect(dbPipeline.DestroyCallCount()).To(BeZero())
+					})
+				})
+
 				Context("when an error occurs destroying the pipeline", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
@@ -875,6 +894,7 @@ var _ = Describe("Pipelines API", func() {
 
 				Context("when pausing the pipeline succeeds", func() {
 					BeforeEach(func() {
+						fakeaccess.UserNameReturns("some-user")
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
 						dbPipeline.PauseReturns(nil)
 					})
@@ -882,6 +902,11 @@ var _ = Describe("Pipelines API", func() {
 					It("returns 200", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusOK))
 					})
+
+					It("records the requester as the actor", func() {
+						Expect(dbPipeline.PauseCallCount()).To(Equal(1))
+						Expect(dbPipeline.PauseArgsForCall(0)).To(Equal("some-user"))
+					})
 				})
 
 				Context("when pausing the pipeline fails", func() {
@@ -955,6 +980,7 @@ var _ = Describe("Pipelines API", func() {
 
 				Context("when unpausing the pipeline succeeds", func() {
 					BeforeEach(func() {
+						fakeaccess.UserNameReturns("some-user")
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
 						dbPipeline.UnpauseReturns(nil)
 					})
@@ -962,6 +988,11 @@ var _ = Describe("Pipelines API", func() {
 					It("returns 200", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusOK))
 					})
+
+					It("records the requester as the actor", func() {
+						Expect(dbPipeline.UnpauseCallCount()).To(Equal(1))
+						Expect(dbPipeline.UnpauseArgsForCall(0)).To(Equal("some-user"))
+					})
 				})
 
 				Context("when unpausing the pipeline fails", func() {
@@ -1288,7 +1319,7 @@ var _ = Describe("Pipelines API", func() {
 				fakeTeam.PipelineReturns(dbPipeline, true, nil)
 				//construct Version db
 
-				dbPipeline.LoadVersionsDBReturns(
+				dbPipeline.LoadVersionsDBContextReturns(
 					&algorithm.VersionsDB{
 						ResourceVersions: []algorithm.ResourceVersion{
 							{
@@ -1440,6 +1471,17 @@ var _ = Describe("Pipelines API", func() {
 					Expect(dbPipeline.RenameArgsForCall(0)).To(Equal("some-new-name"))
 				})
 
+				Context("when the new name is already taken", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineReturns(dbPipeline, true, nil)
+						dbPipeline.RenameReturns(db.ErrPipelineNameTaken{Name: "some-new-name"})
+					})
+
+					It("returns a 409 conflict", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
 				Context("when an error occurs on update", func() {
 					BeforeEach(func() {
 						fakeTeam.PipelineReturns(dbPipeline, true, nil)
@@ -22,6 +22,12 @@ func (s *Server) CreateBuild(pipeline db.Pipeline) http.Handler {
 		}
 
 		build, err := pipeline.CreateStartedBuild(plan)
+		if _, ok := err.(db.ErrQuotaExceeded); ok {
+			logger.Info("quota-exceeded", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
 		if err != nil {
 			logger.Error("failed-to-create-one-off-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -15,7 +15,19 @@ func (s *Server) DeletePipeline(pipelineDB db.Pipeline) http.Handler {
 
 		logger.Info("start")
 
-		err := pipelineDB.Destroy()
+		abortedIDs, err := pipelineDB.AbortAllRunningBuilds("pipeline destroyed")
+		if err != nil {
+			logger.Error("failed-to-abort-builds", err)
+
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if len(abortedIDs) > 0 {
+			logger.Info("aborted-builds", lager.Data{"builds": abortedIDs})
+		}
+
+		err = pipelineDB.Destroy()
 		if err != nil {
 			logger.Error("failed", err)
 
@@ -3,13 +3,16 @@ package pipelineserver
 import (
 	"net/http"
 
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/db"
 )
 
 func (s *Server) PausePipeline(pipelineDB db.Pipeline) http.Handler {
 	logger := s.logger.Session("pause-pipeline")
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		err := pipelineDB.Pause()
+		acc := accessor.GetAccessor(r)
+
+		err := pipelineDB.Pause(acc.UserName())
 		if err != nil {
 			logger.Error("failed-to-pause-pipeline", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -29,6 +30,12 @@ func (s *Server) RenamePipeline(pipeline db.Pipeline) http.Handler {
 		}
 
 		err = pipeline.Rename(rename.NewName)
+		if _, ok := err.(db.ErrPipelineNameTaken); ok {
+			logger.Info("pipeline-name-taken", lager.Data{"name": rename.NewName})
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
 		if err != nil {
 			logger.Error("failed-to-update-name", err)
 			w.WriteHeader(http.StatusInternalServerError)
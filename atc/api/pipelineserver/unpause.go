@@ -3,13 +3,16 @@ package pipelineserver
 import (
 	"net/http"
 
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/db"
 )
 
 func (s *Server) UnpausePipeline(pipelineDB db.Pipeline) http.Handler {
 	logger := s.logger.Session("unpause-pipeline")
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		err := pipelineDB.Unpause()
+		acc := accessor.GetAccessor(r)
+
+		err := pipelineDB.Unpause(acc.UserName())
 		if err != nil {
 			logger.Error("failed-to-unpause-pipeline", err)
 			w.WriteHeader(http.StatusInternalServerError)
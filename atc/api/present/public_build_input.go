@@ -11,6 +11,8 @@ func PublicBuildInput(input db.BuildInput, pipelineID int) atc.PublicBuildInput
 		Version:         atc.Version(input.Version),
 		PipelineID:      pipelineID,
 		FirstOccurrence: input.FirstOccurrence,
+		Enabled:         input.Enabled,
+		Pinned:          input.Pinned,
 	}
 }
 
@@ -18,5 +20,7 @@ func PublicBuildOutput(output db.BuildOutput) atc.PublicBuildOutput {
 	return atc.PublicBuildOutput{
 		Name:    output.Name,
 		Version: atc.Version(output.Version),
+		Enabled: output.Enabled,
+		Pinned:  output.Pinned,
 	}
 }
@@ -31,6 +31,12 @@ func (s *Server) PinResourceVersion(pipeline db.Pipeline) http.Handler {
 		}
 
 		err = resource.PinVersion(resourceConfigVersionID)
+		if err == db.ErrVersionNotFound {
+			logger.Debug("resource-version-not-found", lager.Data{"resource": resourceName, "version": resourceConfigVersionID})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
 		if err != nil {
 			logger.Error("failed-to-pin-resource-version", err)
 			w.WriteHeader(http.StatusInternalServerError)
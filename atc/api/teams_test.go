@@ -473,9 +473,19 @@ var _ = Describe("Teams API", func() {
 					})
 				})
 
+				Context("when the team is the default team", func() {
+					BeforeEach(func() {
+						fakeTeam.DeleteReturns(db.TeamDeletion{}, db.ErrCannotDeleteDefaultTeam)
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
 				Context("when there's a problem deleting the team", func() {
 					BeforeEach(func() {
-						fakeTeam.DeleteReturns(errors.New("disaster"))
+						fakeTeam.DeleteReturns(db.TeamDeletion{}, errors.New("disaster"))
 					})
 
 					It("returns 500 Internal Server Error", func() {
@@ -564,6 +574,26 @@ var _ = Describe("Teams API", func() {
 				It("returns 204 no content", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
 				})
+
+				Context("when the new name is taken", func() {
+					BeforeEach(func() {
+						fakeTeam.RenameReturns(db.ErrTeamNameTaken{Name: "some-new-name"})
+					})
+
+					It("returns 409 Conflict", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
+				Context("when the team is the default team", func() {
+					BeforeEach(func() {
+						fakeTeam.RenameReturns(db.ErrCannotRenameDefaultTeam)
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
 			})
 
 			Context("when requester belongs to the team", func() {
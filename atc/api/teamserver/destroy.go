@@ -3,7 +3,9 @@ package teamserver
 import (
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
 )
 
 func (s *Server) DestroyTeam(w http.ResponseWriter, r *http.Request) {
@@ -54,12 +56,24 @@ func (s *Server) DestroyTeam(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	err = team.Delete()
+	deletion, err := team.Delete()
 	if err != nil {
+		if err == db.ErrCannotDeleteDefaultTeam {
+			hLog.Info("team-is-default-team")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
 		hLog.Error("failed-to-delete-team", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	hLog.Info("deleted-team", lager.Data{
+		"team":      teamName,
+		"pipelines": deletion.Pipelines,
+		"builds":    deletion.Builds,
+	})
+
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -5,8 +5,10 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
 )
 
 // RenameTeam allows an authenticated user with authority or admin to rename a team
@@ -49,6 +51,18 @@ func (s *Server) RenameTeam(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err = team.Rename(rename.NewName)
+	if _, ok := err.(db.ErrTeamNameTaken); ok {
+		logger.Info("team-name-taken", lager.Data{"name": rename.NewName})
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	if err == db.ErrCannotRenameDefaultTeam {
+		logger.Info("team-is-default-team")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	if err != nil {
 		logger.Error("failed-to-update-team-name", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -654,6 +654,16 @@ var _ = Describe("Versions API", func() {
 						})
 					})
 
+					Context("when the version does not belong to the resource", func() {
+						BeforeEach(func() {
+							fakeResource.PinVersionReturns(db.ErrVersionNotFound)
+						})
+
+						It("returns 404", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+
 					Context("when pinning the resource fails", func() {
 						BeforeEach(func() {
 							fakeResource.PinVersionReturns(errors.New("welp"))
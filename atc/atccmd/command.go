@@ -99,6 +99,8 @@ type RunCommand struct {
 
 	Postgres flag.PostgresConfig `group:"PostgreSQL Configuration" namespace:"postgres"`
 
+	PostgresReadReplicaHost string `long:"postgres-read-replica-host" description:"Host of a read replica to serve build listings and resource version history from in the API. Connects with the same settings as the primary."`
+
 	CredentialManagement creds.CredentialManagementConfig `group:"Credential Management"`
 	CredentialManagers   creds.Managers
 
@@ -110,6 +112,8 @@ type RunCommand struct {
 
 	InterceptIdleTimeout time.Duration `long:"intercept-idle-timeout" default:"0m" description:"Length of time for a intercepted session to be idle before terminating."`
 
+	BuildEventHeartbeatInterval time.Duration `long:"build-event-heartbeat-interval" default:"0s" description:"Send a heartbeat on build event streams that have been idle for this long, to keep proxies from closing them. Disabled by default, as older fly clients reject heartbeats."`
+
 	EnableGlobalResources bool `long:"enable-global-resources" description:"Enable equivalent resources across pipelines and teams to share a single version history."`
 
 	GlobalResourceCheckTimeout   time.Duration `long:"global-resource-check-timeout" default:"1h" description:"Time limit on checking for new versions of resources."`
@@ -144,14 +148,21 @@ type RunCommand struct {
 
 	LogDBQueries bool `long:"log-db-queries" description:"Log database queries."`
 
+	LogSlowDBQueries time.Duration `long:"log-slow-db-queries" description:"Log database queries that take longer than this to return. Disabled when zero."`
+
+	CompressBuildEvents bool `long:"compress-build-events" description:"Compress build event payloads before storing them in the database."`
+
 	GC struct {
 		Interval time.Duration `long:"interval" default:"30s" description:"Interval on which to perform garbage collection."`
 
 		OneOffBuildGracePeriod time.Duration `long:"one-off-grace-period" default:"5m" description:"Period after which one-off build containers will be garbage-collected."`
 		MissingGracePeriod     time.Duration `long:"missing-grace-period" default:"5m" description:"Period after which to reap containers and volumes that were created but went missing from the worker."`
+
+		PipelineConfigVersionsToRetain int `long:"pipeline-config-versions-to-retain" default:"50" description:"Number of historical configs to keep per pipeline, 0 means all."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
+	StaleBuildTimeout    time.Duration `long:"stale-build-timeout" default:"10m" description:"Mark builds which could not be resumed as errored once no ATC has tracked them for this long. Disabled when zero."`
 
 	TelemetryOptIn bool `long:"telemetry-opt-in" hidden:"true" description:"Enable anonymous concourse version reporting."`
 
@@ -401,12 +412,12 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 
 	lockFactory := lock.NewLockFactory(lockConn, metric.LogLockAcquired, metric.LogLockReleased)
 
-	apiConn, err := cmd.constructDBConn(retryingDriverName, logger, 32, "api", lockFactory)
+	apiConn, err := cmd.constructDBConn(retryingDriverName, logger, 32, "api", lockFactory, true)
 	if err != nil {
 		return nil, err
 	}
 
-	backendConn, err := cmd.constructDBConn(retryingDriverName, logger, 32, "backend", lockFactory)
+	backendConn, err := cmd.constructDBConn(retryingDriverName, logger, 32, "backend", lockFactory, false)
 	if err != nil {
 		return nil, err
 	}
@@ -668,6 +679,10 @@ func (cmd *RunCommand) constructAPIMembers(
 			cmd.nonTLSBindAddr(),
 			httpHandler,
 		)},
+		{Name: "build-event-streams", Runner: builds.EventStreamCloser{
+			BuildFactory: dbBuildFactory,
+			Logger:       logger.Session("build-event-streams"),
+		}},
 	}
 
 	if httpsHandler != nil {
@@ -829,6 +844,10 @@ func (cmd *RunCommand) constructBackendMembers(
 				gc.NewResourceConfigCheckSessionCollector(
 					resourceConfigCheckSessionLifecycle,
 				),
+				gc.NewPipelineConfigCollector(
+					dbPipelineFactory,
+					cmd.GC.PipelineConfigVersionsToRetain,
+				),
 			),
 			"collector",
 			lockFactory,
@@ -1150,12 +1169,31 @@ func (cmd *RunCommand) constructDBConn(
 	maxConn int,
 	connectionName string,
 	lockFactory lock.LockFactory,
+	useReadReplica bool,
 ) (db.Conn, error) {
 	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), cmd.newKey(), cmd.oldKey(), connectionName, lockFactory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %s", err)
 	}
 
+	if useReadReplica && cmd.PostgresReadReplicaHost != "" {
+		replicaConfig := cmd.Postgres
+		replicaConfig.Host = cmd.PostgresReadReplicaHost
+
+		replica, err := sql.Open(driverName, replicaConfig.ConnectionString())
+		if err != nil {
+			return nil, err
+		}
+
+		replica.SetMaxOpenConns(maxConn)
+
+		dbConn = db.WithReadReplica(dbConn, replica)
+	}
+
+	if cmd.CompressBuildEvents {
+		dbConn = db.WithCompressedBuildEvents(dbConn)
+	}
+
 	// Instrument with Metrics
 	dbConn = metric.CountQueries(dbConn)
 	metric.Databases = append(metric.Databases, dbConn)
@@ -1165,6 +1203,10 @@ func (cmd *RunCommand) constructDBConn(
 		dbConn = db.Log(logger.Session("log-conn"), dbConn)
 	}
 
+	if cmd.LogSlowDBQueries > 0 {
+		dbConn = db.LogSlowQueries(logger.Session("slow-query-conn"), dbConn, cmd.LogSlowDBQueries)
+	}
+
 	// Prepare
 	dbConn.SetMaxOpenConns(maxConn)
 
@@ -1255,7 +1297,7 @@ func (cmd *RunCommand) constructEngine(
 		cmd.ExternalURL.String(),
 	)
 
-	return engine.NewEngine(stepBuilder)
+	return engine.NewEngine(stepBuilder, cmd.StaleBuildTimeout)
 }
 
 func (cmd *RunCommand) constructHTTPHandler(
@@ -1355,7 +1397,7 @@ func (cmd *RunCommand) constructAPIHandler(
 		dbBuildFactory,
 		resourceConfigFactory,
 
-		buildserver.NewEventHandler,
+		buildserver.NewEventHandlerFactory(cmd.BuildEventHeartbeatInterval),
 
 		workerClient,
 		radarScannerFactory,
@@ -1427,10 +1469,11 @@ func (cmd *RunCommand) constructPipelineSyncer(
 							"team":     pipeline.TeamName(),
 							"pipeline": pipeline.Name(),
 						}),
-						Pipeline:  pipeline,
-						Scheduler: radarSchedulerFactory.BuildScheduler(pipeline),
-						Noop:      cmd.Developer.Noop,
-						Interval:  10 * time.Second,
+						Pipeline:      pipeline,
+						Scheduler:     radarSchedulerFactory.BuildScheduler(pipeline),
+						Notifications: bus,
+						Noop:          cmd.Developer.Noop,
+						Interval:      10 * time.Second,
 					},
 				},
 			})
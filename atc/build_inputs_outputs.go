@@ -10,11 +10,15 @@ type PublicBuildInput struct {
 	Version         Version `json:"version"`
 	PipelineID      int     `json:"pipeline_id"`
 	FirstOccurrence bool    `json:"first_occurrence"`
+	Enabled         bool    `json:"enabled"`
+	Pinned          bool    `json:"pinned"`
 }
 
 type PublicBuildOutput struct {
 	Name    string  `json:"name"`
 	Version Version `json:"version"`
+	Enabled bool    `json:"enabled"`
+	Pinned  bool    `json:"pinned"`
 }
 
 type ResourceVersion struct {
@@ -0,0 +1,27 @@
+package builds
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// EventStreamCloser ends every build event stream still open as soon as the
+// ATC is told to shut down. The web server waits for its connections to
+// finish, so otherwise it would wait on every client following a running
+// build.
+type EventStreamCloser struct {
+	BuildFactory db.BuildFactory
+	Logger       lager.Logger
+}
+
+func (closer EventStreamCloser) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	<-signals
+
+	closer.Logger.Info("closing-build-event-streams")
+
+	return closer.BuildFactory.Close()
+}
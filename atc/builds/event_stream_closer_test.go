@@ -0,0 +1,62 @@
+package builds_test
+
+import (
+	"errors"
+	"os"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/concourse/concourse/atc/builds"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+)
+
+var _ = Describe("EventStreamCloser", func() {
+	var fakeBuildFactory *dbfakes.FakeBuildFactory
+	var process ifrit.Process
+
+	BeforeEach(func() {
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+	})
+
+	JustBeforeEach(func() {
+		process = ifrit.Invoke(EventStreamCloser{
+			BuildFactory: fakeBuildFactory,
+			Logger:       lagertest.NewTestLogger("test"),
+		})
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		<-process.Wait()
+	})
+
+	It("leaves the event streams open until it is signalled", func() {
+		Consistently(fakeBuildFactory.CloseCallCount).Should(BeZero())
+	})
+
+	Context("when it is signalled", func() {
+		JustBeforeEach(func() {
+			process.Signal(os.Interrupt)
+		})
+
+		It("closes the event streams", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(fakeBuildFactory.CloseCallCount()).To(Equal(1))
+		})
+
+		Context("when closing the event streams fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuildFactory.CloseReturns(disaster)
+			})
+
+			It("exits with the error", func() {
+				Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+			})
+		})
+	})
+})
@@ -31,6 +31,16 @@ func (bt *Tracker) Track() {
 
 	tLog.Debug("start")
 	defer tLog.Debug("done")
+
+	abortedIDs, err := bt.buildFactory.AbortTimedOutBuilds()
+	if err != nil {
+		tLog.Error("failed-to-abort-timed-out-builds", err)
+	}
+
+	if len(abortedIDs) > 0 {
+		tLog.Info("aborted-timed-out-builds", lager.Data{"builds": abortedIDs})
+	}
+
 	builds, err := bt.buildFactory.GetAllStartedBuilds()
 	if err != nil {
 		tLog.Error("failed-to-lookup-started-builds", err)
@@ -68,6 +68,12 @@ var _ = Describe("Tracker", func() {
 			Eventually(engineBuilds[1].ResumeCallCount).Should(Equal(1))
 			Eventually(engineBuilds[2].ResumeCallCount).Should(Equal(1))
 		})
+
+		It("aborts builds that are past their deadline", func() {
+			tracker.Track()
+
+			Expect(fakeBuildFactory.AbortTimedOutBuildsCallCount()).To(Equal(1))
+		})
 	})
 
 	Describe("Release", func() {
@@ -1,10 +1,12 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
 	"time"
 
@@ -26,15 +28,48 @@ type BuildInput struct {
 	ResourceID int
 
 	FirstOccurrence bool
+
+	// Enabled and Pinned reflect the state of the version at the time of the
+	// query, not at the time the build ran.
+	Enabled bool
+	Pinned  bool
+
+	Metadata ResourceConfigMetadataFields
 }
 
 type BuildOutput struct {
 	Name    string
 	Version atc.Version
+
+	// See BuildInput.
+	Enabled bool
+	Pinned  bool
+
+	Metadata ResourceConfigMetadataFields
 }
 
 type BuildStatus string
 
+// ErrorCategory distinguishes errored builds caused by the infrastructure from
+// those caused by the pipeline itself.
+type ErrorCategory string
+
+const (
+	ErrorCategoryUnknown ErrorCategory = "unknown"
+	ErrorCategoryWorker  ErrorCategory = "worker"
+	ErrorCategoryTimeout ErrorCategory = "timeout"
+	ErrorCategoryConfig  ErrorCategory = "config"
+)
+
+// BuildTrigger records what caused a build to be created.
+type BuildTrigger string
+
+const (
+	BuildTriggerManual   BuildTrigger = "manual"
+	BuildTriggerResource BuildTrigger = "resource"
+	BuildTriggerRerun    BuildTrigger = "rerun"
+)
+
 const (
 	BuildStatusPending   BuildStatus = "pending"
 	BuildStatusStarted   BuildStatus = "started"
@@ -44,7 +79,24 @@ const (
 	BuildStatusErrored   BuildStatus = "errored"
 )
 
-var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.schema, b.private_plan, b.public_plan, b.create_time, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.drained, b.aborted, b.completed").
+// buildTransitions lists the statuses each status may move to. Statuses
+// missing from the map are terminal.
+var buildTransitions = map[BuildStatus][]BuildStatus{
+	BuildStatusPending: {BuildStatusStarted, BuildStatusAborted, BuildStatusSucceeded, BuildStatusFailed, BuildStatusErrored},
+	BuildStatusStarted: {BuildStatusAborted, BuildStatusSucceeded, BuildStatusFailed, BuildStatusErrored},
+}
+
+func (status BuildStatus) CanTransitionTo(to BuildStatus) bool {
+	for _, allowed := range buildTransitions[status] {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.schema, b.private_plan, b.public_plan, b.create_time, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.drained, b.aborted, b.completed, b.abort_reason, b.rerun_of, b.labels, b.error_category, b.trigger_source, b.overrides_pause").
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
 	JoinClause("LEFT OUTER JOIN pipelines p ON b.pipeline_id = p.id").
@@ -53,6 +105,14 @@ var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.ma
 var minMaxIdQuery = psql.Select("COALESCE(MAX(b.id), 0)", "COALESCE(MIN(b.id), 0)").
 	From("builds as b")
 
+// BuildComment is a note left on a build, e.g. by an operator investigating
+// why it failed.
+type BuildComment struct {
+	Author string
+	Text   string
+	Time   time.Time
+}
+
 //go:generate counterfeiter . Build
 
 type Build interface {
@@ -72,8 +132,15 @@ type Build interface {
 	CreateTime() time.Time
 	EndTime() time.Time
 	ReapTime() time.Time
+	Duration() time.Duration
 	IsManuallyTriggered() bool
+	TriggerSource() BuildTrigger
+	OverridesPause() bool
 	IsScheduled() bool
+	RerunOf() int
+	Labels() map[string]string
+	AddComment(author string, text string) error
+	GetComments() ([]BuildComment, error)
 	IsRunning() bool
 	IsCompleted() bool
 
@@ -85,27 +152,50 @@ type Build interface {
 	Preparation() (BuildPreparation, bool, error)
 
 	Start(atc.Plan) (bool, error)
+	StartWithTimeout(atc.Plan, time.Duration) (bool, error)
 	Finish(BuildStatus) error
+	MarkAsErrored(cause error, category ErrorCategory) error
+	SaveEventAndFinish(ev atc.Event, status BuildStatus) error
 
 	SetInterceptible(bool) error
-
-	Events(uint) (EventSource, error)
+	Heartbeat() error
+	IsStale(timeout time.Duration) (bool, error)
+
+	Events(uint, ...EventsOption) (EventSource, error)
+	EventsPage(from uint, limit uint) ([]event.Envelope, uint, error)
+	TailEvents(count uint) ([]event.Envelope, error)
+	WriteEventsJSON(w io.Writer, from uint, live bool) error
+	WriteSSE(ctx context.Context, w io.Writer, from uint) error
+	Snapshot() (BuildReport, error)
+	EventCount() (uint, error)
+	LastEventTime() (time.Time, bool, error)
+	DeleteEvents() error
 	SaveEvent(event atc.Event) error
+	SaveEvents(events []atc.Event) error
+	SaveMetric(name string, value float64) error
 
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
 
 	SaveOutput(lager.Logger, string, atc.Source, creds.VersionedResourceTypes, atc.Version, ResourceConfigMetadataFields, string, string) error
 	UseInputs(inputs []BuildInput) error
+	SaveInput(input BuildInput, reused bool) error
 
 	Resources() ([]BuildInput, []BuildOutput, error)
+	GetResourceMetadata() (map[string]map[string]string, error)
+	GetInputs() ([]BuildInput, error)
+	GetOutputs() ([]BuildOutput, error)
+	GetInputResourceConfigVersionIDs() ([]int, error)
 	SaveImageResourceVersion(UsedResourceCache) error
 
 	Pipeline() (Pipeline, bool, error)
 
 	Delete() (bool, error)
 	MarkAsAborted() error
+	AbortWithReason(reason string) error
 	IsAborted() bool
+	AbortReason() string
+	ErrorCategory() ErrorCategory
 	AbortNotifier() (Notifier, error)
 	Schedule() (bool, error)
 
@@ -128,6 +218,10 @@ type build struct {
 	jobName      string
 
 	isManuallyTriggered bool
+	triggerSource       BuildTrigger
+	overridesPause      bool
+	rerunOf             int
+	labels              map[string]string
 
 	schema      string
 	privatePlan atc.Plan
@@ -143,11 +237,17 @@ type build struct {
 	drained     bool
 	aborted     bool
 	completed   bool
+
+	abortReason   string
+	errorCategory ErrorCategory
 }
 
 var ErrBuildDisappeared = errors.New("build disappeared from db")
 var ErrBuildHasNoPipeline = errors.New("build has no pipeline")
 var ErrBuildArtifactNotFound = errors.New("build artifact not found")
+var ErrBuildNotCompleted = errors.New("build has not completed")
+var ErrBuildNotFound = errors.New("build not found")
+var ErrBuildHasNoInputs = errors.New("build has no recorded inputs")
 
 type ResourceNotFoundInPipeline struct {
 	Resource string
@@ -158,6 +258,15 @@ func (r ResourceNotFoundInPipeline) Error() string {
 	return fmt.Sprintf("resource %s not found in pipeline %s", r.Resource, r.Pipeline)
 }
 
+type ErrInvalidBuildTransition struct {
+	From BuildStatus
+	To   BuildStatus
+}
+
+func (e ErrInvalidBuildTransition) Error() string {
+	return fmt.Sprintf("cannot transition build from %s to %s", e.From, e.To)
+}
+
 func (b *build) ID() int                      { return b.id }
 func (b *build) Name() string                 { return b.name }
 func (b *build) JobID() int                   { return b.jobID }
@@ -167,6 +276,8 @@ func (b *build) PipelineName() string         { return b.pipelineName }
 func (b *build) TeamID() int                  { return b.teamID }
 func (b *build) TeamName() string             { return b.teamName }
 func (b *build) IsManuallyTriggered() bool    { return b.isManuallyTriggered }
+func (b *build) TriggerSource() BuildTrigger  { return b.triggerSource }
+func (b *build) OverridesPause() bool         { return b.overridesPause }
 func (b *build) Schema() string               { return b.schema }
 func (b *build) PrivatePlan() atc.Plan        { return b.privatePlan }
 func (b *build) PublicPlan() *json.RawMessage { return b.publicPlan }
@@ -176,11 +287,30 @@ func (b *build) EndTime() time.Time           { return b.endTime }
 func (b *build) ReapTime() time.Time          { return b.reapTime }
 func (b *build) Status() BuildStatus          { return b.status }
 func (b *build) IsScheduled() bool            { return b.scheduled }
+func (b *build) RerunOf() int                 { return b.rerunOf }
+func (b *build) Labels() map[string]string    { return b.labels }
 func (b *build) IsDrained() bool              { return b.drained }
 func (b *build) IsRunning() bool              { return !b.completed }
 func (b *build) IsAborted() bool              { return b.aborted }
+func (b *build) AbortReason() string          { return b.abortReason }
+func (b *build) ErrorCategory() ErrorCategory { return b.errorCategory }
 func (b *build) IsCompleted() bool            { return b.completed }
 
+// Duration returns how long the build ran for. Builds which never started
+// have no duration, and builds which are still running are measured up to
+// now.
+func (b *build) Duration() time.Duration {
+	if b.startTime.IsZero() {
+		return 0
+	}
+
+	if b.endTime.IsZero() {
+		return time.Since(b.startTime)
+	}
+
+	return b.endTime.Sub(b.startTime)
+}
+
 func (b *build) Reload() (bool, error) {
 	row := buildsQuery.Where(sq.Eq{"b.id": b.id}).
 		RunWith(b.conn).
@@ -239,7 +369,50 @@ func (b *build) SetInterceptible(i bool) error {
 	return nil
 }
 
+// Heartbeat records that the build is still being tracked by an engine, so
+// that it is not considered stale by IsStale. It only touches the build's row
+// and does nothing once the build has finished.
+func (b *build) Heartbeat() error {
+	_, err := psql.Update("builds").
+		Set("last_tracked", sq.Expr("now()")).
+		Where(sq.Eq{
+			"id":     b.id,
+			"status": BuildStatusStarted,
+		}).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+// IsStale reports whether the build has gone without a heartbeat for longer
+// than the timeout. Builds started before heartbeats were recorded are never
+// considered stale.
+func (b *build) IsStale(timeout time.Duration) (bool, error) {
+	var stale bool
+	err := psql.Select(fmt.Sprintf("COALESCE(last_tracked < now() - '%d seconds'::interval, false)", int(timeout.Seconds()))).
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&stale)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, ErrBuildDisappeared
+		}
+		return false, err
+	}
+
+	return stale, nil
+}
+
 func (b *build) Start(plan atc.Plan) (bool, error) {
+	return b.StartWithTimeout(plan, 0)
+}
+
+// StartWithTimeout starts the build like Start, additionally recording a
+// deadline after which the build will be aborted by AbortTimedOutBuilds. A
+// zero timeout means the build has no deadline.
+func (b *build) StartWithTimeout(plan atc.Plan, timeout time.Duration) (bool, error) {
 	tx, err := b.conn.Begin()
 	if err != nil {
 		return false, err
@@ -259,13 +432,20 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 
 	var startTime time.Time
 
-	err = psql.Update("builds").
+	update := psql.Update("builds").
 		Set("status", BuildStatusStarted).
 		Set("start_time", sq.Expr("now()")).
+		Set("last_tracked", sq.Expr("now()")).
 		Set("schema", schema).
 		Set("private_plan", encryptedPlan).
 		Set("public_plan", plan.Public()).
-		Set("nonce", nonce).
+		Set("nonce", nonce)
+
+	if timeout > 0 {
+		update = update.Set("deadline", sq.Expr(fmt.Sprintf("now() + '%d seconds'::interval", int(timeout.Seconds()))))
+	}
+
+	err = update.
 		Where(sq.Eq{
 			"id":      b.id,
 			"status":  "pending",
@@ -282,7 +462,7 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 		return false, err
 	}
 
-	err = b.saveEvent(tx, event.Status{
+	eventID, err := b.saveEvent(tx, event.Status{
 		Status: atc.StatusStarted,
 		Time:   startTime.Unix(),
 	})
@@ -302,7 +482,7 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 		return false, err
 	}
 
-	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
+	err = b.notifyEvents(eventID)
 	if err != nil {
 		return false, err
 	}
@@ -311,6 +491,33 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 }
 
 func (b *build) Finish(status BuildStatus) error {
+	return b.finish(status, nil, "", false)
+}
+
+// MarkAsErrored finishes the build as errored, always recording the given
+// category on the build. An error event with the cause is saved unless a step
+// has already reported an error, so that it doesn't show up twice in the
+// build's log.
+func (b *build) MarkAsErrored(cause error, category ErrorCategory) error {
+	return b.finish(BuildStatusErrored, event.Error{
+		Message:  cause.Error(),
+		Category: string(category),
+		Time:     time.Now().Unix(),
+	}, category, true)
+}
+
+// SaveEventAndFinish saves the event and finishes the build in the same
+// transaction, so that subscribers always see the event before the build's
+// status event.
+func (b *build) SaveEventAndFinish(ev atc.Event, status BuildStatus) error {
+	return b.finish(status, ev, "", false)
+}
+
+// finish completes the build with the given status, first saving last as its
+// final event if it is not nil. A non-empty category is recorded as the
+// build's error category. With skipRepeatedError, an error event given as last
+// is not saved when the build already has one.
+func (b *build) finish(status BuildStatus, last atc.Event, category ErrorCategory, skipRepeatedError bool) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
 		return err
@@ -318,14 +525,48 @@ func (b *build) Finish(status BuildStatus) error {
 
 	defer Rollback(tx)
 
-	var endTime time.Time
+	err = b.checkTransition(tx, status)
+	if err != nil {
+		return err
+	}
 
-	err = psql.Update("builds").
+	update := psql.Update("builds").
 		Set("status", status).
 		Set("end_time", sq.Expr("now()")).
 		Set("completed", true).
 		Set("private_plan", nil).
-		Set("nonce", nil).
+		Set("nonce", nil)
+
+	_, isError := last.(event.Error)
+
+	save := last != nil
+	if isError && skipRepeatedError {
+		err = tx.QueryRow(fmt.Sprintf(`
+			SELECT NOT EXISTS (
+				SELECT 1
+				FROM %s
+				WHERE build_id = $1
+				AND type = $2
+			)
+		`, b.eventsTable()), b.id, string(event.EventTypeError)).Scan(&save)
+		if err != nil {
+			return err
+		}
+	}
+
+	if save {
+		_, err = b.saveEvent(tx, last)
+		if err != nil {
+			return err
+		}
+	}
+
+	if category != "" {
+		update = update.Set("error_category", category)
+	}
+
+	var endTime time.Time
+	err = update.
 		Where(sq.Eq{"id": b.id}).
 		Suffix("RETURNING end_time").
 		RunWith(tx).
@@ -335,7 +576,11 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
-	err = b.saveEvent(tx, event.Status{
+	if category != "" {
+		b.errorCategory = category
+	}
+
+	eventID, err := b.saveEvent(tx, event.Status{
 		Status: atc.BuildStatus(status),
 		Time:   endTime.Unix(),
 	})
@@ -389,7 +634,7 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
-	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
+	err = b.notifyEvents(eventID)
 	if err != nil {
 		return err
 	}
@@ -440,13 +685,73 @@ func (b *build) Delete() (bool, error) {
 // Setting status as aborted will also make Start() return false in case where
 // build was aborted before it was started.
 func (b *build) MarkAsAborted() error {
+	return b.AbortWithReason("")
+}
+
+// AbortWithReason marks the build as aborted like MarkAsAborted, recording why
+// it was aborted. A non-empty reason is also written to the build's event
+// stream.
+func (b *build) AbortWithReason(reason string) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	err = b.checkTransition(tx, BuildStatusAborted)
+	if err != nil {
+		return err
+	}
+
+	eventID, err := b.abort(tx, reason)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return b.notifyAborted(reason, eventID)
+}
+
+// abort marks the build as aborted within the transaction, saving the reason
+// as an error event if there is one. It returns the id of the saved event, or
+// -1 if there is no reason.
+func (b *build) abort(tx Tx, reason string) (int, error) {
 	_, err := psql.Update("builds").
 		Set("aborted", true).
+		Set("abort_reason", sq.Expr("COALESCE(NULLIF(?, ''), abort_reason)", reason)).
 		Where(sq.Eq{"id": b.id}).
-		RunWith(b.conn).
+		RunWith(tx).
 		Exec()
 	if err != nil {
-		return err
+		return -1, err
+	}
+
+	if reason != "" {
+		return b.saveEvent(tx, event.Error{
+			Message: "aborted: " + reason,
+		})
+	}
+
+	return -1, nil
+}
+
+// notifyAborted tells the build's event subscribers and whoever is running it
+// that it was aborted, once the transaction that aborted it has committed.
+func (b *build) notifyAborted(reason string, eventID int) error {
+	b.aborted = true
+
+	if reason != "" {
+		b.abortReason = reason
+
+		err := b.notifyEvents(eventID)
+		if err != nil {
+			return err
+		}
 	}
 
 	return b.conn.Bus().Notify(buildAbortChannel(b.id))
@@ -714,7 +1019,12 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 	return buildPreparation, true, nil
 }
 
-func (b *build) Events(from uint) (EventSource, error) {
+func (b *build) Events(from uint, opts ...EventsOption) (EventSource, error) {
+	var options eventsOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	notifier, err := newConditionNotifier(b.conn.Bus(), buildEventsChannel(b.id), func() (bool, error) {
 		return true, nil
 	})
@@ -722,20 +1032,80 @@ func (b *build) Events(from uint) (EventSource, error) {
 		return nil, err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
-	}
-
 	return newBuildEventSource(
 		b.id,
-		table,
+		b.eventsTable(),
 		b.conn,
 		notifier,
+		buildEventsChannel(b.id),
 		from,
+		options,
 	), nil
 }
 
+// EventsPage returns at most limit persisted events starting at the given
+// event id, along with the id to continue from. Event ids start at 0, so the
+// first page starts from 0. Unlike Events it does not subscribe to the
+// notifications bus, so it never blocks waiting for a running build to emit
+// more events.
+func (b *build) EventsPage(from uint, limit uint) ([]event.Envelope, uint, error) {
+	rows, err := psql.Select("type", "version", "payload", "COALESCE(compressed, false)", "event_id").
+		From(b.eventsTable()).
+		Where(sq.Eq{"build_id": b.id}).
+		Where(sq.GtOrEq{"event_id": from}).
+		OrderBy("event_id ASC").
+		Limit(uint64(limit)).
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer Close(rows)
+
+	next := from
+	events := []event.Envelope{}
+	for rows.Next() {
+		var eventID uint
+		ev, err := scanEventEnvelope(withTrailingColumns{rows, []interface{}{&eventID}})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		events = append(events, ev)
+		next = eventID + 1
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, next, nil
+}
+
+// TailEvents returns the last count persisted events of the build in
+// chronological order. If the build has fewer than count events, all of them
+// are returned.
+func (b *build) TailEvents(count uint) ([]event.Envelope, error) {
+	rows, err := b.conn.Query(`
+		SELECT sub.type, sub.version, sub.payload, sub.compressed
+		FROM (
+			SELECT event_id, type, version, payload, COALESCE(compressed, false) AS compressed
+			FROM `+b.eventsTable()+`
+			WHERE build_id = $1
+			ORDER BY event_id DESC
+			LIMIT $2
+		) sub
+		ORDER BY sub.event_id ASC
+	`, b.id, count)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanEventEnvelopes(rows)
+}
+
 func (b *build) SaveEvent(event atc.Event) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -744,7 +1114,128 @@ func (b *build) SaveEvent(event atc.Event) error {
 
 	defer Rollback(tx)
 
-	err = b.saveEvent(tx, event)
+	eventID, err := b.saveEvent(tx, event)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return b.notifyEvents(eventID)
+}
+
+// SaveMetric saves a metric event with the given name and value, timestamped
+// now.
+func (b *build) SaveMetric(name string, value float64) error {
+	return b.SaveEvent(event.Metric{
+		Name:  name,
+		Value: value,
+		Time:  time.Now().Unix(),
+	})
+}
+
+func (b *build) EventCount() (uint, error) {
+	var count uint
+	err := psql.Select("COUNT(*)").
+		From(b.eventsTable()).
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// LastEventTime returns the time the build's newest event was saved. It
+// returns false if the build has no events, or if its newest event was saved
+// before event times were recorded.
+func (b *build) LastEventTime() (time.Time, bool, error) {
+	var createdAt pq.NullTime
+	err := psql.Select("created_at").
+		From(b.eventsTable()).
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("event_id DESC").
+		Limit(1).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&createdAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, false, nil
+		}
+		return time.Time{}, false, err
+	}
+
+	if !createdAt.Valid {
+		return time.Time{}, false, nil
+	}
+
+	return createdAt.Time, true, nil
+}
+
+// DeleteEvents removes the persisted events of a completed build and marks it
+// as reaped. The build itself and its inputs and outputs are left intact.
+func (b *build) DeleteEvents() error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Update("builds").
+		Set("reap_time", sq.Expr("now()")).
+		Where(sq.Eq{
+			"id":        b.id,
+			"completed": true,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrBuildNotCompleted
+	}
+
+	_, err = psql.Delete(b.eventsTable()).
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SaveEvents persists the given events in order using a single insert and
+// notifies subscribers once they have all been committed.
+func (b *build) SaveEvents(events []atc.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	eventID, err := b.saveEvents(tx, events)
 	if err != nil {
 		return err
 	}
@@ -754,7 +1245,7 @@ func (b *build) SaveEvent(event atc.Event) error {
 		return err
 	}
 
-	return b.conn.Bus().Notify(buildEventsChannel(b.id))
+	return b.notifyEvents(eventID)
 }
 
 func (b *build) Artifact(artifactID int) (WorkerArtifact, error) {
@@ -928,11 +1419,9 @@ func (b *build) UseInputs(inputs []BuildInput) error {
 		return err
 	}
 
-	for _, input := range inputs {
-		err = b.saveInputTx(tx, b.id, input)
-		if err != nil {
-			return err
-		}
+	err = b.saveInputsTx(tx, b.id, inputs)
+	if err != nil {
+		return err
 	}
 
 	if b.pipelineID != 0 {
@@ -945,11 +1434,51 @@ func (b *build) UseInputs(inputs []BuildInput) error {
 	return tx.Commit()
 }
 
-func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
-	inputs := []BuildInput{}
-	outputs := []BuildOutput{}
+// SaveInput records whether one of the inputs the build uses was reused from
+// a cache or newly fetched. Inputs are saved by UseInputs without saying
+// either, until their get step runs.
+func (b *build) SaveInput(input BuildInput, reused bool) error {
+	versionJSON, err := json.Marshal(input.Version)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("build_resource_config_version_inputs").
+		Set("reused", reused).
+		Where(sq.Eq{
+			"build_id":    b.id,
+			"resource_id": input.ResourceID,
+			"name":        input.Name,
+		}).
+		Where(sq.Expr("version_md5 = md5(?)", versionJSON)).
+		RunWith(b.conn).
+		Exec()
+
+	return err
+}
+
+// versionEnabled, versionPinned and inputFirstOccurrence are selected
+// alongside a build's inputs and outputs, and expect the version and its
+// resource to be aliased as "versions" and "resources", and the build as
+// "builds".
+const (
+	versionEnabled = `
+		NOT EXISTS (
+			SELECT 1
+			FROM resource_disabled_versions d
+			WHERE d.resource_id = resources.id
+			AND d.version_md5 = versions.version_md5
+		)`
+
+	versionPinned = `
+		EXISTS (
+			SELECT 1
+			FROM resource_pins p
+			WHERE p.resource_id = resources.id
+			AND p.version = versions.version
+		)`
 
-	firstOccurrence := `
+	inputFirstOccurrence = `
 		NOT EXISTS (
 			SELECT 1
 			FROM build_resource_config_version_inputs i, builds b
@@ -960,8 +1489,13 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 			AND i.build_id = b.id
 			AND i.build_id < builds.id
 		)`
+)
+
+func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
+	inputs := []BuildInput{}
+	outputs := []BuildOutput{}
 
-	rows, err := psql.Select("inputs.name", "resources.id", "versions.version", firstOccurrence).
+	rows, err := psql.Select("inputs.name", "resources.id", "versions.version", "COALESCE(versions.metadata, '[]')", inputFirstOccurrence, versionEnabled, versionPinned).
 		From("resource_config_versions versions, build_resource_config_version_inputs inputs, builds, resources").
 		Where(sq.Eq{"builds.id": b.id}).
 		Where(sq.NotEq{"versions.check_order": 0}).
@@ -986,33 +1520,16 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 	defer Close(rows)
 
 	for rows.Next() {
-		var (
-			inputName       string
-			firstOccurrence bool
-			versionBlob     string
-			version         atc.Version
-			resourceID      int
-		)
-
-		err = rows.Scan(&inputName, &resourceID, &versionBlob, &firstOccurrence)
+		var input BuildInput
+		err = scanResourceVersion(rows, &input.Name, &input.ResourceID, &input.Version, &input.Metadata, &input.FirstOccurrence, &input.Enabled, &input.Pinned)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		err = json.Unmarshal([]byte(versionBlob), &version)
-		if err != nil {
-			return nil, nil, err
-		}
-
-		inputs = append(inputs, BuildInput{
-			Name:            inputName,
-			Version:         version,
-			ResourceID:      resourceID,
-			FirstOccurrence: firstOccurrence,
-		})
+		inputs = append(inputs, input)
 	}
 
-	rows, err = psql.Select("outputs.name", "versions.version").
+	rows, err = psql.Select("outputs.name", "resources.id", "versions.version", "COALESCE(versions.metadata, '[]')", versionEnabled, versionPinned).
 		From("resource_config_versions versions, build_resource_config_version_outputs outputs, builds, resources").
 		Where(sq.Eq{"builds.id": b.id}).
 		Where(sq.NotEq{"versions.check_order": 0}).
@@ -1031,39 +1548,262 @@ func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 
 	for rows.Next() {
 		var (
-			outputName  string
-			versionBlob string
-			version     atc.Version
+			output     BuildOutput
+			resourceID int
 		)
 
-		err := rows.Scan(&outputName, &versionBlob)
+		err = scanResourceVersion(rows, &output.Name, &resourceID, &output.Version, &output.Metadata, &output.Enabled, &output.Pinned)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		err = json.Unmarshal([]byte(versionBlob), &version)
+		outputs = append(outputs, output)
+	}
+
+	return inputs, outputs, nil
+}
+
+// GetInputs returns every version the build used as an input along with its
+// metadata, ordered by input name. Unlike Resources, inputs which the build
+// also produced as outputs are included.
+func (b *build) GetInputs() ([]BuildInput, error) {
+	rows, err := b.queryResourceVersions("build_resource_config_version_inputs", inputFirstOccurrence, versionEnabled, versionPinned)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	inputs := []BuildInput{}
+	for rows.Next() {
+		var input BuildInput
+		err = scanResourceVersion(rows, &input.Name, &input.ResourceID, &input.Version, &input.Metadata, &input.FirstOccurrence, &input.Enabled, &input.Pinned)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
-		outputs = append(outputs, BuildOutput{
-			Name:    outputName,
-			Version: version,
-		})
+		inputs = append(inputs, input)
 	}
 
-	return inputs, outputs, nil
+	return inputs, rows.Err()
 }
 
-func (p *build) saveInputTx(tx Tx, buildID int, input BuildInput) error {
-	versionJSON, err := json.Marshal(input.Version)
+// GetOutputs returns every version the build produced as an output along
+// with its metadata, ordered by output name.
+func (b *build) GetOutputs() ([]BuildOutput, error) {
+	rows, err := b.queryResourceVersions("build_resource_config_version_outputs", versionEnabled, versionPinned)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	outputs := []BuildOutput{}
+	for rows.Next() {
+		var (
+			output     BuildOutput
+			resourceID int
+		)
+
+		err = scanResourceVersion(rows, &output.Name, &resourceID, &output.Version, &output.Metadata, &output.Enabled, &output.Pinned)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, output)
+	}
+
+	return outputs, rows.Err()
+}
+
+// queryResourceVersions selects the name, resource id, version and metadata
+// of the build's rows in the given inputs or outputs table, followed by the
+// given columns.
+func (b *build) queryResourceVersions(table string, columns ...string) (*sql.Rows, error) {
+	return psql.Select("io.name", "resources.id", "versions.version", "COALESCE(versions.metadata, '[]')").
+		Columns(columns...).
+		From(table + " io").
+		Join("builds ON builds.id = io.build_id").
+		Join("resources ON resources.id = io.resource_id").
+		Join("resource_config_versions versions ON versions.version_md5 = io.version_md5 AND versions.resource_config_scope_id = resources.resource_config_scope_id").
+		Where(sq.Eq{"io.build_id": b.id}).
+		OrderBy("io.name ASC").
+		RunWith(b.conn).
+		Query()
+}
+
+// scanResourceVersion scans a row selected like queryResourceVersions does,
+// scanning any further columns into dest.
+func scanResourceVersion(rows scannable, name *string, resourceID *int, version *atc.Version, metadata *ResourceConfigMetadataFields, dest ...interface{}) error {
+	var versionBlob, metadataBlob string
+	err := rows.Scan(append([]interface{}{name, resourceID, &versionBlob, &metadataBlob}, dest...)...)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal([]byte(versionBlob), version)
 	if err != nil {
 		return err
 	}
 
-	_, err = psql.Insert("build_resource_config_version_inputs").
-		Columns("build_id", "resource_id", "version_md5", "name").
-		Values(buildID, input.ResourceID, sq.Expr("md5(?)", versionJSON), input.Name).
+	return json.Unmarshal([]byte(metadataBlob), metadata)
+}
+
+// GetResourceMetadata returns the metadata of the versions the build used and
+// produced as flat maps, keyed by resource name. When a key occurs more than
+// once for a resource the last one wins: outputs take precedence over inputs,
+// then inputs and outputs are ordered by name and version id, and later fields
+// take precedence over earlier ones within a version's metadata.
+func (b *build) GetResourceMetadata() (map[string]map[string]string, error) {
+	rows, err := b.conn.Query(`
+		SELECT r.name, COALESCE(v.metadata, '[]')
+		FROM (
+			SELECT resource_id, version_md5, name, 0 AS kind
+			FROM build_resource_config_version_inputs
+			WHERE build_id = $1
+			UNION ALL
+			SELECT resource_id, version_md5, name, 1 AS kind
+			FROM build_resource_config_version_outputs
+			WHERE build_id = $1
+		) io
+		JOIN resources r ON r.id = io.resource_id
+		JOIN resource_config_versions v
+			ON v.version_md5 = io.version_md5
+			AND v.resource_config_scope_id = r.resource_config_scope_id
+		ORDER BY io.kind, r.name, io.name, v.id
+	`, b.id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	metadata := map[string]map[string]string{}
+	for rows.Next() {
+		var (
+			resourceName string
+			metadataBlob string
+		)
+
+		err = rows.Scan(&resourceName, &metadataBlob)
+		if err != nil {
+			return nil, err
+		}
+
+		var fields ResourceConfigMetadataFields
+		err = json.Unmarshal([]byte(metadataBlob), &fields)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, found := metadata[resourceName]; !found {
+			metadata[resourceName] = map[string]string{}
+		}
+
+		for _, field := range fields {
+			metadata[resourceName][field.Name] = field.Value
+		}
+	}
+
+	return metadata, nil
+}
+
+// GetInputResourceConfigVersionIDs returns the ids of the resource config
+// versions the build used as inputs, ordered by input name. Unlike Resources
+// it does not load the versions themselves, so it is cheap enough for working
+// out cache keys.
+func (b *build) GetInputResourceConfigVersionIDs() ([]int, error) {
+	rows, err := psql.Select("v.id").
+		From("build_resource_config_version_inputs i").
+		Join("resources r ON r.id = i.resource_id").
+		Join("resource_config_versions v ON v.version_md5 = i.version_md5 AND v.resource_config_scope_id = r.resource_config_scope_id").
+		Where(sq.Eq{"i.build_id": b.id}).
+		OrderBy("i.name ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// AddComment leaves a note on the build.
+func (b *build) AddComment(author string, text string) error {
+	_, err := psql.Insert("build_comments").
+		Columns("build_id", "author", "text").
+		Values(b.id, author, text).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+// GetComments returns the notes left on the build, oldest first.
+func (b *build) GetComments() ([]BuildComment, error) {
+	rows, err := psql.Select("author", "text", "time").
+		From("build_comments").
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("time ASC", "id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	comments := []BuildComment{}
+	for rows.Next() {
+		var comment BuildComment
+		err = rows.Scan(&comment.Author, &comment.Text, &comment.Time)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, comment)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// saveInputsTx inserts all of the inputs with a single statement, since fan-in
+// jobs can have many of them.
+func (b *build) saveInputsTx(tx Tx, buildID int, inputs []BuildInput) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	insert := psql.Insert("build_resource_config_version_inputs").
+		Columns("build_id", "resource_id", "version_md5", "name")
+
+	for _, input := range inputs {
+		versionJSON, err := json.Marshal(input.Version)
+		if err != nil {
+			return err
+		}
+
+		insert = insert.Values(buildID, input.ResourceID, sq.Expr("md5(?)", versionJSON), input.Name)
+	}
+
+	_, err := insert.
 		Suffix("ON CONFLICT DO NOTHING").
 		RunWith(tx).
 		Exec()
@@ -1082,17 +1822,46 @@ func buildEventSeq(buildid int) string {
 	return fmt.Sprintf("build_event_id_seq_%d", buildid)
 }
 
+// checkTransition locks the build's row for the rest of the transaction and
+// returns ErrInvalidBuildTransition if its current status may not move to the
+// given status.
+func (b *build) checkTransition(tx Tx, to BuildStatus) error {
+	var from string
+	err := psql.Select("status").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&from)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrBuildDisappeared
+		}
+		return err
+	}
+
+	if !BuildStatus(from).CanTransitionTo(to) {
+		return ErrInvalidBuildTransition{From: BuildStatus(from), To: to}
+	}
+
+	return nil
+}
+
 func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy) error {
 	var (
-		jobID, pipelineID                                      sql.NullInt64
+		jobID, pipelineID, rerunOf                             sql.NullInt64
 		schema, privatePlan, jobName, pipelineName, publicPlan sql.NullString
+		labels                                                 sql.NullString
 		createTime, startTime, endTime, reapTime               pq.NullTime
 		nonce                                                  sql.NullString
 		drained, aborted, completed                            bool
 		status                                                 string
+		abortReason                                            sql.NullString
+		errorCategory, triggerSource                           sql.NullString
 	)
 
-	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &schema, &privatePlan, &publicPlan, &createTime, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &drained, &aborted, &completed)
+	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &schema, &privatePlan, &publicPlan, &createTime, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &drained, &aborted, &completed, &abortReason, &rerunOf, &labels, &errorCategory, &triggerSource, &b.overridesPause)
 	if err != nil {
 		return err
 	}
@@ -1110,6 +1879,10 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.drained = drained
 	b.aborted = aborted
 	b.completed = completed
+	b.abortReason = abortReason.String
+	b.rerunOf = int(rerunOf.Int64)
+	b.errorCategory = ErrorCategory(errorCategory.String)
+	b.triggerSource = BuildTrigger(triggerSource.String)
 
 	var (
 		noncense      *string
@@ -1140,28 +1913,139 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		}
 	}
 
+	if labels.Valid {
+		err = json.Unmarshal([]byte(labels.String), &b.labels)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (b *build) saveEvent(tx Tx, event atc.Event) error {
-	payload, err := json.Marshal(event)
+func (b *build) saveEvent(tx Tx, event atc.Event) (int, error) {
+	return b.saveEvents(tx, []atc.Event{event})
+}
+
+// saveEvents inserts the events and returns the id of the last one, to be
+// passed to notifyEvents once the transaction has committed. The id is
+// returned rather than kept on the build as the same build is shared by all
+// of its steps, which may save events concurrently.
+func (b *build) saveEvents(tx Tx, events []atc.Event) (int, error) {
+	insert := psql.Insert(b.eventsTable()).
+		Columns("event_id", "build_id", "type", "version", "payload", "compressed")
+
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return -1, err
+		}
+
+		stored, compressed, err := encodeEventPayload(payload, b.conn.CompressBuildEvents())
+		if err != nil {
+			return -1, err
+		}
+
+		insert = insert.Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), stored, compressed)
+	}
+
+	rows, err := insert.
+		Suffix("RETURNING event_id").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return -1, err
+	}
+
+	defer Close(rows)
+
+	lastEventID := -1
+	for rows.Next() {
+		var eventID int
+		err = rows.Scan(&eventID)
+		if err != nil {
+			return -1, err
+		}
+
+		if eventID > lastEventID {
+			lastEventID = eventID
+		}
+	}
+
+	return lastEventID, rows.Err()
+}
+
+// notifyEvents wakes up event subscribers, telling them the id of the latest
+// saved event so that they can skip re-reading events they already have.
+func (b *build) notifyEvents(lastEventID int) error {
+	if lastEventID < 0 {
+		return b.conn.Bus().Notify(buildEventsChannel(b.id))
+	}
+
+	return b.conn.Bus().NotifyWithPayload(buildEventsChannel(b.id), strconv.Itoa(lastEventID))
+}
+
+func scanEventEnvelopes(rows *sql.Rows) ([]event.Envelope, error) {
+	defer Close(rows)
+
+	events := []event.Envelope{}
+	for rows.Next() {
+		ev, err := scanEventEnvelope(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, ev)
+	}
+
+	err := rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// scanEventEnvelope scans an event's type, version, payload and whether the
+// payload is compressed, migrating the event to its latest version.
+func scanEventEnvelope(row scannable) (event.Envelope, error) {
+	var t, v, p string
+	var compressed bool
+	err := row.Scan(&t, &v, &p, &compressed)
 	if err != nil {
-		return err
+		return event.Envelope{}, err
+	}
+
+	payload, err := decodeEventPayload(p, compressed)
+	if err != nil {
+		return event.Envelope{}, err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
+	data := json.RawMessage(payload)
+
+	return event.Migrate(event.Envelope{
+		Data:    &data,
+		Event:   atc.EventType(t),
+		Version: atc.EventVersion(v),
+	})
+}
+
+func (b *build) eventsTable() string {
 	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+		return fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
 	}
-	_, err = psql.Insert(table).
-		Columns("event_id", "build_id", "type", "version", "payload").
-		Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), payload).
-		RunWith(tx).
-		Exec()
-	return err
+
+	return fmt.Sprintf("team_build_events_%d", b.teamID)
 }
 
 func createBuild(tx Tx, build *build, vals map[string]interface{}) error {
+	if teamID, ok := vals["team_id"].(int); ok {
+		err := checkRunningBuildsQuota(tx, teamID)
+		if err != nil {
+			return err
+		}
+	}
+
 	var buildID int
 	err := psql.Insert("builds").
 		SetMap(vals).
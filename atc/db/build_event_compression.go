@@ -0,0 +1,92 @@
+package db
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io/ioutil"
+)
+
+// minCompressedEventSize is the size below which event payloads are stored
+// as-is even when compression is enabled, as the gzip header and base64
+// encoding would make most of them larger, e.g. short log lines.
+const minCompressedEventSize = 512
+
+// WithCompressedBuildEvents returns a connection through which the payloads
+// of saved build events are compressed, whichever way their build was looked
+// up.
+func WithCompressedBuildEvents(conn Conn) Conn {
+	return &compressingConn{
+		Conn: conn,
+	}
+}
+
+type compressingConn struct {
+	Conn
+}
+
+func (c *compressingConn) CompressBuildEvents() bool {
+	return true
+}
+
+// encodeEventPayload returns the payload to store for an event, and whether
+// it was compressed. Payloads are only compressed if compress is true, they
+// are large enough, and compressing them actually makes them smaller.
+func encodeEventPayload(payload []byte, compress bool) (string, bool, error) {
+	if !compress || len(payload) < minCompressedEventSize {
+		return string(payload), false, nil
+	}
+
+	compressed, err := compressEventPayload(payload)
+	if err != nil {
+		return "", false, err
+	}
+
+	if len(compressed) >= len(payload) {
+		return string(payload), false, nil
+	}
+
+	return compressed, true, nil
+}
+
+// compressEventPayload gzips the payload and base64-encodes the result so
+// that it can be stored in the text payload column.
+func compressEventPayload(payload []byte) (string, error) {
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+
+	_, err := zw.Write(payload)
+	if err != nil {
+		return "", err
+	}
+
+	err = zw.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// decodeEventPayload returns the raw JSON for a stored event payload,
+// decompressing it if the row was marked as compressed.
+func decodeEventPayload(payload string, compressed bool) ([]byte, error) {
+	if !compressed {
+		return []byte(payload), nil
+	}
+
+	gz, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+
+	defer zr.Close()
+
+	return ioutil.ReadAll(zr)
+}
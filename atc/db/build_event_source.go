@@ -1,12 +1,16 @@
 package db
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/event"
+	"github.com/lib/pq"
 )
 
 var ErrEndOfBuildEventStream = errors.New("end of build event stream")
@@ -16,28 +20,100 @@ var ErrBuildEventStreamClosed = errors.New("build event stream closed")
 
 type EventSource interface {
 	Next() (event.Envelope, error)
+	NextContext(context.Context) (event.Envelope, error)
 	Close() error
 }
 
+// DefaultEventsBufferSize is how many events an event stream reads ahead of
+// its consumer unless configured otherwise with WithBufferSize.
+const DefaultEventsBufferSize = 2000
+
+type EventsOption func(*eventsOptions)
+
+type eventsOptions struct {
+	types      []string
+	heartbeat  time.Duration
+	bufferSize int
+	storedOnly bool
+}
+
+// WithTypes limits an event stream to events of the given types. The filter
+// is applied in the query, so offsets passed to Events count only the
+// matching events.
+func WithTypes(types ...atc.EventType) EventsOption {
+	return func(opts *eventsOptions) {
+		for _, t := range types {
+			opts.types = append(opts.types, string(t))
+		}
+	}
+}
+
+// WithHeartbeat makes Next return an event.Heartbeat whenever no event has
+// arrived for the given interval. Heartbeats are not saved and do not count
+// towards the offsets passed to Events.
+func WithHeartbeat(interval time.Duration) EventsOption {
+	return func(opts *eventsOptions) {
+		opts.heartbeat = interval
+	}
+}
+
+// WithBufferSize sets how many events an event stream reads ahead of its
+// consumer, which is also how many it reads from the database at a time.
+// Once the buffer is full the stream waits for Next to be called before
+// reading any more, so a slow consumer holds up reading rather than events
+// being dropped. Sizes below one use DefaultEventsBufferSize.
+func WithBufferSize(size int) EventsOption {
+	return func(opts *eventsOptions) {
+		opts.bufferSize = size
+	}
+}
+
+// WithoutFollowing ends an event stream with ErrEndOfBuildEventStream once the
+// events saved so far have been read, rather than waiting for more events
+// while the build is still running.
+func WithoutFollowing() EventsOption {
+	return func(opts *eventsOptions) {
+		opts.storedOnly = true
+	}
+}
+
 func newBuildEventSource(
 	buildID int,
 	table string,
 	conn Conn,
 	notifier Notifier,
+	channel string,
 	from uint,
+	opts eventsOptions,
 ) *buildEventSource {
 	wg := new(sync.WaitGroup)
 
+	bufferSize := opts.bufferSize
+	if bufferSize < 1 {
+		bufferSize = DefaultEventsBufferSize
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
 	source := &buildEventSource{
-		buildID: buildID,
-		table:   table,
+		buildID:    buildID,
+		table:      table,
+		types:      opts.types,
+		heartbeat:  opts.heartbeat,
+		storedOnly: opts.storedOnly,
 
 		conn: conn,
 
 		notifier: notifier,
+		channel:  channel,
+		busDone:  conn.Bus().Done(),
 
-		events: make(chan event.Envelope, 2000),
+		lastEventID: -1,
+
+		events: make(chan event.Envelope, bufferSize),
 		stop:   make(chan struct{}),
+		ctx:    ctx,
+		cancel: cancel,
 		wg:     wg,
 	}
 
@@ -48,25 +124,75 @@ func newBuildEventSource(
 }
 
 type buildEventSource struct {
-	buildID int
-	table   string
+	buildID    int
+	table      string
+	types      []string
+	heartbeat  time.Duration
+	storedOnly bool
 
 	conn     Conn
 	notifier Notifier
+	channel  string
+	busDone  <-chan struct{}
+
+	// lastEventID is the id of the last event read, or -1 if none have been read
+	lastEventID int
 
 	events chan event.Envelope
 	stop   chan struct{}
 	err    error
 	wg     *sync.WaitGroup
+
+	// ctx is cancelled on Close so that a slow query doesn't hold it up
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 func (source *buildEventSource) Next() (event.Envelope, error) {
-	e, ok := <-source.events
-	if !ok {
-		return event.Envelope{}, source.err
+	return source.NextContext(context.Background())
+}
+
+// NextContext behaves like Next, but gives up waiting for the next event once
+// the context is done, returning the context's error. The stream is left open
+// so that the caller decides whether to Close it.
+func (source *buildEventSource) NextContext(ctx context.Context) (event.Envelope, error) {
+	var heartbeat <-chan time.Time
+	if source.heartbeat > 0 {
+		timer := time.NewTimer(source.heartbeat)
+		defer timer.Stop()
+
+		heartbeat = timer.C
+	}
+
+	select {
+	case e, ok := <-source.events:
+		if !ok {
+			return event.Envelope{}, source.err
+		}
+
+		return e, nil
+	case <-heartbeat:
+		return heartbeatEnvelope()
+	case <-ctx.Done():
+		return event.Envelope{}, ctx.Err()
+	}
+}
+
+func heartbeatEnvelope() (event.Envelope, error) {
+	hb := event.Heartbeat{Time: time.Now().Unix()}
+
+	payload, err := json.Marshal(hb)
+	if err != nil {
+		return event.Envelope{}, err
 	}
 
-	return e, nil
+	data := json.RawMessage(payload)
+
+	return event.Envelope{
+		Data:    &data,
+		Event:   hb.EventType(),
+		Version: hb.Version(),
+	}, nil
 }
 
 func (source *buildEventSource) Close() error {
@@ -77,6 +203,7 @@ func (source *buildEventSource) Close() error {
 		close(source.stop)
 	}
 
+	source.cancel()
 	source.wg.Wait()
 
 	return source.notifier.Close()
@@ -98,27 +225,35 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 
 		completed := false
 
-		err := source.conn.QueryRow(`
+		err := source.conn.QueryRowContext(source.ctx, `
 			SELECT builds.completed
 			FROM builds
 			WHERE builds.id = $1
 		`, source.buildID).Scan(&completed)
 		if err != nil {
-			source.err = err
+			source.err = source.queryErr(err)
 			close(source.events)
 			return
 		}
 
-		rows, err := source.conn.Query(`
-			SELECT type, version, payload
+		typeFilter := ""
+		args := []interface{}{source.buildID, cursor, batchSize}
+		if len(source.types) > 0 {
+			typeFilter = "AND type = ANY($4)"
+			args = append(args, pq.Array(source.types))
+		}
+
+		rows, err := source.conn.QueryContext(source.ctx, `
+			SELECT event_id, type, version, payload, COALESCE(compressed, false)
 			FROM `+source.table+`
 			WHERE build_id = $1
+			`+typeFilter+`
 			ORDER BY event_id ASC
 			OFFSET $2
 			LIMIT $3
-		`, source.buildID, cursor, batchSize)
+		`, args...)
 		if err != nil {
-			source.err = err
+			source.err = source.queryErr(err)
 			close(source.events)
 			return
 		}
@@ -130,8 +265,19 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 
 			cursor++
 
+			var eventID int
 			var t, v, p string
-			err := rows.Scan(&t, &v, &p)
+			var compressed bool
+			err := rows.Scan(&eventID, &t, &v, &p, &compressed)
+			if err != nil {
+				_ = rows.Close()
+
+				source.err = err
+				close(source.events)
+				return
+			}
+
+			payload, err := decodeEventPayload(p, compressed)
 			if err != nil {
 				_ = rows.Close()
 
@@ -140,12 +286,23 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 				return
 			}
 
-			data := json.RawMessage(p)
+			if eventID > source.lastEventID {
+				source.lastEventID = eventID
+			}
+
+			data := json.RawMessage(payload)
 
-			ev := event.Envelope{
+			ev, err := event.Migrate(event.Envelope{
 				Data:    &data,
 				Event:   atc.EventType(t),
 				Version: atc.EventVersion(v),
+			})
+			if err != nil {
+				_ = rows.Close()
+
+				source.err = err
+				close(source.events)
+				return
 			}
 
 			select {
@@ -164,18 +321,56 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 			continue
 		}
 
-		if completed {
+		if completed || source.storedOnly {
 			source.err = ErrEndOfBuildEventStream
 			close(source.events)
 			return
 		}
 
-		select {
-		case <-source.notifier.Notify():
-		case <-source.stop:
-			source.err = ErrBuildEventStreamClosed
-			close(source.events)
-			return
+		for {
+			select {
+			case <-source.notifier.Notify():
+			case <-source.stop:
+				source.err = ErrBuildEventStreamClosed
+				close(source.events)
+				return
+			case <-source.busDone:
+				// nothing will wake us up anymore
+				source.err = ErrBuildEventStreamClosed
+				close(source.events)
+				return
+			}
+
+			if !source.hasLatestEvent() {
+				break
+			}
 		}
 	}
 }
+
+// queryErr returns ErrBuildEventStreamClosed for queries that were cancelled
+// by Close.
+func (source *buildEventSource) queryErr(err error) error {
+	if source.ctx.Err() != nil {
+		return ErrBuildEventStreamClosed
+	}
+
+	return err
+}
+
+// hasLatestEvent returns true if the payload of the latest notification names
+// an event that has already been read. Anything else, including a missing or
+// malformed payload, requires reading from the database again.
+func (source *buildEventSource) hasLatestEvent() bool {
+	payload, found := source.conn.Bus().LastPayload(source.channel)
+	if !found {
+		return false
+	}
+
+	eventID, err := strconv.Atoi(payload)
+	if err != nil {
+		return false
+	}
+
+	return eventID <= source.lastEventID
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc/event"
+	"github.com/vito/go-sse/sse"
+)
+
+// eventsFlushInterval is how long the event writers wait for another event
+// before flushing what they have buffered so far.
+const eventsFlushInterval = time.Second
+
+type flusher interface {
+	Flush()
+}
+
+// WriteEventsJSON writes the build's events starting at the given offset to
+// w as newline-delimited JSON envelopes, one per line. Persisted events are
+// written as they are read and, if live is set and the build is still
+// running, live events follow until the build finishes. Otherwise it returns
+// once the persisted events have been written. Output is buffered, and
+// flushed whenever the stream has been idle for a second so that live events
+// are not held back.
+func (b *build) WriteEventsJSON(w io.Writer, from uint, live bool) error {
+	opts := []EventsOption{WithHeartbeat(eventsFlushInterval)}
+	if !live {
+		opts = append(opts, WithoutFollowing())
+	}
+
+	events, err := b.Events(from, opts...)
+	if err != nil {
+		return err
+	}
+
+	defer Close(events)
+
+	buf := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buf)
+
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err == ErrEndOfBuildEventStream {
+				return flushEvents(buf, w)
+			}
+
+			return err
+		}
+
+		if ev.Event == event.EventTypeHeartbeat {
+			err = flushEvents(buf, w)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		err = encoder.Encode(ev)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// WriteSSE writes the build's events starting at the given offset to w as
+// server-sent events, flushing after each one. Every event carries its offset
+// as its id, so a client reconnecting with a Last-Event-ID header should pass
+// that id plus one as from. Once the build has finished an "end" event is
+// written. It stops early with the context's error when the context is done,
+// e.g. because the client went away.
+func (b *build) WriteSSE(ctx context.Context, w io.Writer, from uint) error {
+	events, err := b.Events(from)
+	if err != nil {
+		return err
+	}
+
+	defer Close(events)
+
+	id := from
+	for {
+		ev, err := events.NextContext(ctx)
+		if err != nil {
+			if err == ErrEndOfBuildEventStream {
+				return writeSSE(w, sse.Event{
+					ID:   strconv.FormatUint(uint64(id), 10),
+					Name: "end",
+				})
+			}
+
+			return err
+		}
+
+		payload, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+
+		err = writeSSE(w, sse.Event{
+			ID:   strconv.FormatUint(uint64(id), 10),
+			Name: "event",
+			Data: payload,
+		})
+		if err != nil {
+			return err
+		}
+
+		id++
+	}
+}
+
+func writeSSE(w io.Writer, ev sse.Event) error {
+	err := ev.Write(w)
+	if err != nil {
+		return err
+	}
+
+	if f, ok := w.(flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+func flushEvents(buf *bufio.Writer, w io.Writer) error {
+	err := buf.Flush()
+	if err != nil {
+		return err
+	}
+
+	if f, ok := w.(flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
@@ -8,19 +8,25 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/lib/pq"
 )
 
 //go:generate counterfeiter . BuildFactory
 
 type BuildFactory interface {
 	Build(int) (Build, bool, error)
+	BuildByName(teamName, pipelineName, jobName, buildName string) (Build, bool, error)
 	VisibleBuilds([]string, Page) ([]Build, Pagination, error)
 	VisibleBuildsWithTime([]string, Page) ([]Build, Pagination, error)
 	PublicBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
 	GetDrainableBuilds() ([]Build, error)
+	AbortTimedOutBuilds() ([]int, error)
+	PruneEventsOlderThan(time.Duration) (int, error)
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
 	MarkNonInterceptibleBuilds() error
+
+	Close() error
 }
 
 type buildFactory struct {
@@ -59,6 +65,35 @@ func (f *buildFactory) Build(buildID int) (Build, bool, error) {
 	return build, true, nil
 }
 
+// BuildByName looks up a job build by the names found in its URL. It returns
+// false if the team, pipeline, job or build does not exist.
+func (f *buildFactory) BuildByName(teamName, pipelineName, jobName, buildName string) (Build, bool, error) {
+	build := &build{
+		conn:        f.conn,
+		lockFactory: f.lockFactory,
+	}
+
+	row := buildsQuery.
+		Where(sq.Eq{
+			"t.name": teamName,
+			"p.name": pipelineName,
+			"j.name": jobName,
+			"b.name": buildName,
+		}).
+		RunWith(f.conn).
+		QueryRow()
+
+	err := scanBuild(build, row, f.conn.EncryptionStrategy())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return build, true, nil
+}
+
 func (f *buildFactory) VisibleBuildsWithTime(teamNames []string, page Page) ([]Build, Pagination, error) {
 	newBuildsQuery := buildsQuery.
 		Where(sq.Or{
@@ -114,14 +149,114 @@ func (f *buildFactory) GetDrainableBuilds() ([]Build, error) {
 	return getBuilds(query, f.conn, f.lockFactory)
 }
 
+// GetAllStartedBuilds returns every started build across all teams and
+// pipelines, including one-off builds, in order of id.
 func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	query := buildsQuery.Where(sq.Eq{
 		"b.status": BuildStatusStarted,
-	})
+	}).OrderBy("b.id ASC")
 
 	return getBuilds(query, f.conn, f.lockFactory)
 }
 
+// AbortTimedOutBuilds marks every started build whose deadline has passed as
+// aborted and returns the ids of the builds it aborted.
+func (f *buildFactory) AbortTimedOutBuilds() ([]int, error) {
+	query := buildsQuery.
+		Where(sq.Eq{
+			"b.status":  BuildStatusStarted,
+			"b.aborted": false,
+		}).
+		Where(sq.Expr("b.deadline < now()"))
+
+	builds, err := getBuilds(query, f.conn, f.lockFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	abortedIDs := []int{}
+	for _, build := range builds {
+		err = build.AbortWithReason("build timed out")
+		if err != nil {
+			if _, ok := err.(ErrInvalidBuildTransition); ok {
+				// finished since we looked it up
+				continue
+			}
+
+			return abortedIDs, err
+		}
+
+		abortedIDs = append(abortedIDs, build.ID())
+	}
+
+	return abortedIDs, nil
+}
+
+// PruneEventsOlderThan deletes the events of builds which completed more than
+// the given retention period ago, leaving the builds themselves intact. It
+// returns the number of builds whose events were pruned.
+func (f *buildFactory) PruneEventsOlderThan(retention time.Duration) (int, error) {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := psql.Update("builds").
+		Set("reap_time", sq.Expr("now()")).
+		Where(sq.Eq{
+			"completed": true,
+			"reap_time": nil,
+		}).
+		Where(sq.Expr(fmt.Sprintf("now() - end_time > '%d seconds'::interval", int(retention.Seconds())))).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Close(rows)
+
+	var buildIDs []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return 0, err
+		}
+
+		buildIDs = append(buildIDs, id)
+	}
+
+	if len(buildIDs) == 0 {
+		return 0, nil
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM build_events
+		WHERE build_id = ANY($1)
+	`, pq.Array(buildIDs))
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(buildIDs), nil
+}
+
+// Close closes the notifications bus, so that every build event stream still
+// waiting for new events ends with ErrBuildEventStreamClosed. It is meant to
+// be called when shutting down, and closing more than once is harmless.
+func (f *buildFactory) Close() error {
+	return f.conn.Bus().Close()
+}
+
 func getBuilds(buildsQuery sq.SelectBuilder, conn Conn, lockFactory lock.LockFactory) ([]Build, error) {
 	rows, err := buildsQuery.RunWith(conn).Query()
 	if err != nil {
@@ -153,7 +288,7 @@ func getBuildsWithDates(buildsQuery, minMaxIdQuery sq.SelectBuilder, page Page,
 			Where(sq.Expr("b.start_time >= to_timestamp(" + strconv.Itoa(page.Since) + ")")).
 			OrderBy("b.id ASC").
 			Limit(1).
-			RunWith(conn).
+			RunWith(conn.ReadReplica()).
 			Query()
 
 		if err != nil {
@@ -189,7 +324,7 @@ func getBuildsWithDates(buildsQuery, minMaxIdQuery sq.SelectBuilder, page Page,
 			Where(sq.Expr("b.start_time <= to_timestamp(" + strconv.Itoa(page.Until) + ")")).
 			OrderBy("b.id DESC").
 			Limit(1).
-			RunWith(conn).
+			RunWith(conn.ReadReplica()).
 			Query()
 		if err != nil {
 			// The user has no builds since that given time
@@ -219,6 +354,8 @@ func getBuildsWithDates(buildsQuery, minMaxIdQuery sq.SelectBuilder, page Page,
 	return getBuildsWithPagination(buildsQuery, minMaxIdQuery, newPage, conn, lockFactory)
 }
 
+// Builds are listed from the read replica, if there is one, but the builds
+// returned still use the primary connection.
 func getBuildsWithPagination(buildsQuery, minMaxIdQuery sq.SelectBuilder, page Page, conn Conn, lockFactory lock.LockFactory) ([]Build, Pagination, error) {
 	var (
 		rows    *sql.Rows
@@ -253,7 +390,7 @@ func getBuildsWithPagination(buildsQuery, minMaxIdQuery sq.SelectBuilder, page P
 			OrderBy("b.id ASC")
 	}
 
-	rows, err = buildsQuery.RunWith(conn).Query()
+	rows, err = buildsQuery.RunWith(conn.ReadReplica()).Query()
 	if err != nil {
 		return nil, Pagination{}, err
 	}
@@ -283,7 +420,7 @@ func getBuildsWithPagination(buildsQuery, minMaxIdQuery sq.SelectBuilder, page P
 
 	var minID, maxID int
 	err = minMaxIdQuery.
-		RunWith(conn).
+		RunWith(conn.ReadReplica()).
 		QueryRow().
 		Scan(&maxID, &minID)
 	if err != nil {
@@ -1,8 +1,12 @@
 package db_test
 
 import (
+	"strings"
+	"time"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -40,6 +44,35 @@ var _ = Describe("BuildFactory", func() {
 		})
 	})
 
+	Describe("BuildByName", func() {
+		var createdBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			createdBuild, err = defaultJob.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the build of the job with the given names", func() {
+			foundBuild, found, err := buildFactory.BuildByName("default-team", "default-pipeline", "some-job", createdBuild.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundBuild.ID()).To(Equal(createdBuild.ID()))
+		})
+
+		DescribeTable("when any of the names do not exist",
+			func(teamName, pipelineName, jobName string, buildName func() string) {
+				_, found, err := buildFactory.BuildByName(teamName, pipelineName, jobName, buildName())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			},
+			Entry("team", "bogus-team", "default-pipeline", "some-job", func() string { return createdBuild.Name() }),
+			Entry("pipeline", "default-team", "bogus-pipeline", "some-job", func() string { return createdBuild.Name() }),
+			Entry("job", "default-team", "default-pipeline", "bogus-job", func() string { return createdBuild.Name() }),
+			Entry("build", "default-team", "default-pipeline", "some-job", func() string { return "bogus-build" }),
+		)
+	})
+
 	Describe("MarkNonInterceptibleBuilds", func() {
 		Context("one-off builds", func() {
 			DescribeTable("completed and within grace period",
@@ -103,10 +136,10 @@ var _ = Describe("BuildFactory", func() {
 		Context("pipeline builds", func() {
 
 			It("[#139963615] marks builds that aren't the latest as non-interceptible, ", func() {
-				build1, err := defaultJob.CreateBuild()
+				build1, err := defaultJob.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
-				build2, err := defaultJob.CreateBuild()
+				build2, err := defaultJob.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
 				err = build1.Finish(db.BuildStatusErrored)
@@ -127,10 +160,10 @@ var _ = Describe("BuildFactory", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				pb1, err := j.CreateBuild()
+				pb1, err := j.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
-				pb2, err := j.CreateBuild()
+				pb2, err := j.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
 				err = pb1.Finish(db.BuildStatusErrored)
@@ -162,7 +195,7 @@ var _ = Describe("BuildFactory", func() {
 
 			DescribeTable("completed builds",
 				func(status db.BuildStatus, matcher types.GomegaMatcher) {
-					b, err := defaultJob.CreateBuild()
+					b, err := defaultJob.CreateBuild(db.BuildTriggerManual)
 					Expect(err).NotTo(HaveOccurred())
 
 					var i bool
@@ -183,7 +216,7 @@ var _ = Describe("BuildFactory", func() {
 			)
 
 			It("does not mark non-completed builds", func() {
-				b, err := defaultJob.CreateBuild()
+				b, err := defaultJob.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
 				var i bool
@@ -228,7 +261,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build2, err = privateJob.CreateBuild()
+			build2, err = privateJob.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
 			publicPipeline, _, err := team.SavePipeline("public-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
@@ -240,7 +273,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build3, err = publicJob.CreateBuild()
+			build3, err = publicJob.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
 			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
@@ -275,7 +308,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			_, err = privateJob.CreateBuild()
+			_, err = privateJob.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
 			publicPipeline, _, err := team.SavePipeline("public-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
@@ -287,7 +320,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			publicBuild, err = publicJob.CreateBuild()
+			publicBuild, err = publicJob.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -323,10 +356,10 @@ var _ = Describe("BuildFactory", func() {
 			build2DB, err = team.CreateOneOffBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			build3DB, err = job.CreateBuild()
+			build3DB, err = job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
-			build4DB, err = job.CreateBuild()
+			build4DB, err = job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
 			started, err := build2DB.Start(atc.Plan{})
@@ -375,7 +408,7 @@ var _ = Describe("BuildFactory", func() {
 			build1DB, err = team.CreateOneOffBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			build2DB, err = job.CreateBuild()
+			build2DB, err = job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
 			_, err = team.CreateOneOffBuild()
@@ -390,7 +423,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(started).To(BeTrue())
 		})
 
-		It("returns all builds that have been started, regardless of pipeline", func() {
+		It("returns all builds that have been started, regardless of pipeline, in order of id", func() {
 			builds, err := buildFactory.GetAllStartedBuilds()
 			Expect(err).NotTo(HaveOccurred())
 
@@ -399,7 +432,231 @@ var _ = Describe("BuildFactory", func() {
 			_, err = build2DB.Reload()
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(builds).To(ConsistOf(build1DB, build2DB))
+			Expect(builds).To(Equal([]db.Build{build1DB, build2DB}))
+		})
+	})
+
+	Describe("AbortTimedOutBuilds", func() {
+		var (
+			timedOutBuild   db.Build
+			withinDeadline  db.Build
+			withoutDeadline db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			timedOutBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			withinDeadline, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			withoutDeadline, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := timedOutBuild.StartWithTimeout(atc.Plan{}, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			started, err = withinDeadline.StartWithTimeout(atc.Plan{}, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			started, err = withoutDeadline.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			_, err = dbConn.Exec(`UPDATE builds SET deadline = now() - '1 minute'::interval WHERE id = $1`, timedOutBuild.ID())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("aborts only the started builds past their deadline", func() {
+			abortedIDs, err := buildFactory.AbortTimedOutBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(abortedIDs).To(ConsistOf(timedOutBuild.ID()))
+
+			for build, aborted := range map[db.Build]bool{
+				timedOutBuild:   true,
+				withinDeadline:  false,
+				withoutDeadline: false,
+			} {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.IsAborted()).To(Equal(aborted))
+			}
+		})
+
+		It("does not abort the same build twice", func() {
+			_, err := buildFactory.AbortTimedOutBuilds()
+			Expect(err).NotTo(HaveOccurred())
+
+			abortedIDs, err := buildFactory.AbortTimedOutBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(abortedIDs).To(BeEmpty())
+		})
+	})
+
+	Describe("Close", func() {
+		It("ends the event streams waiting for new events", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			errs := make(chan error, 1)
+			go func() {
+				_, err := events.Next()
+				errs <- err
+			}()
+
+			Consistently(errs).ShouldNot(Receive())
+
+			err = buildFactory.Close()
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(errs).Should(Receive(Equal(db.ErrBuildEventStreamClosed)))
+		})
+
+		It("can be called more than once", func() {
+			Expect(buildFactory.Close()).To(Succeed())
+			Expect(buildFactory.Close()).To(Succeed())
+		})
+	})
+
+	Describe("PruneEventsOlderThan", func() {
+		var runningBuild, completedBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			runningBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			completedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, b := range []db.Build{runningBuild, completedBuild} {
+				err = b.SaveEvent(event.Log{Payload: "some log"})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			err = completedBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the builds completed within the retention period", func() {
+			It("does not prune anything", func() {
+				pruned, err := buildFactory.PruneEventsOlderThan(time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pruned).To(BeZero())
+
+				count, err := completedBuild.EventCount()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(Equal(uint(2)))
+			})
+		})
+
+		Context("when the builds completed before the retention period", func() {
+			It("prunes the events of completed builds only", func() {
+				pruned, err := buildFactory.PruneEventsOlderThan(-time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pruned).To(Equal(1))
+
+				count, err := completedBuild.EventCount()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(BeZero())
+
+				count, err = runningBuild.EventCount()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(Equal(uint(1)))
+
+				found, err := completedBuild.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(completedBuild.ReapTime()).NotTo(BeZero())
+			})
+
+			It("does not count already pruned builds again", func() {
+				_, err := buildFactory.PruneEventsOlderThan(-time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+
+				pruned, err := buildFactory.PruneEventsOlderThan(-time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pruned).To(BeZero())
+			})
+		})
+	})
+
+	Context("when build event compression is enabled", func() {
+		var (
+			compressingConn db.Conn
+			oneOffBuild     db.Build
+
+			longLog string
+		)
+
+		BeforeEach(func() {
+			compressingConn = db.WithCompressedBuildEvents(dbConn)
+
+			longLog = strings.Repeat("some long log line\n", 100)
+
+			createdBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = createdBuild.SaveEvent(event.Log{Payload: longLog})
+			Expect(err).NotTo(HaveOccurred())
+
+			var found bool
+			oneOffBuild, found, err = db.NewBuildFactory(compressingConn, lockFactory, 0).Build(createdBuild.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = oneOffBuild.SaveEvents([]atc.Event{
+				event.Log{Payload: longLog},
+				event.Log{Payload: "short"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		compressedEvents := func(build db.Build) int {
+			var compressed int
+			err := dbConn.QueryRow(`
+				SELECT COUNT(*) FROM build_events
+				WHERE build_id = $1 AND compressed
+			`, build.ID()).Scan(&compressed)
+			Expect(err).NotTo(HaveOccurred())
+			return compressed
+		}
+
+		It("only stores the payloads compressed which are large enough to benefit", func() {
+			Expect(compressedEvents(oneOffBuild)).To(Equal(1))
+		})
+
+		It("reads back both compressed and uncompressed events", func() {
+			events, _, err := oneOffBuild.EventsPage(0, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: longLog}),
+				envelope(event.Log{Payload: longLog}),
+				envelope(event.Log{Payload: "short"}),
+			}))
+		})
+
+		It("compresses the events of builds however they were looked up", func() {
+			compressingTeam, found, err := db.NewTeamFactory(compressingConn, lockFactory).FindTeam(team.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			build, err := compressingTeam.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: longLog})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(compressedEvents(build)).To(Equal(1))
 		})
 	})
 })
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// BuildReport is a self-contained snapshot of a build and the events it had
+// saved at the time, suitable for exporting as JSON.
+type BuildReport struct {
+	ID           int               `json:"id"`
+	Name         string            `json:"name"`
+	Status       BuildStatus       `json:"status"`
+	TeamName     string            `json:"team_name"`
+	PipelineName string            `json:"pipeline_name,omitempty"`
+	JobName      string            `json:"job_name,omitempty"`
+	CreateTime   time.Time         `json:"create_time"`
+	StartTime    time.Time         `json:"start_time"`
+	EndTime      time.Time         `json:"end_time"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	PublicPlan   *json.RawMessage  `json:"plan,omitempty"`
+	Events       []event.Envelope  `json:"events"`
+}
+
+// Snapshot reads the build and its saved events in a single repeatable read
+// transaction, so that events saved by a running build while the snapshot is
+// taken are either all reflected in the build's status or not at all. It
+// returns ErrBuildNotFound if the build has been deleted.
+func (b *build) Snapshot() (BuildReport, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return BuildReport{}, err
+	}
+
+	defer Rollback(tx)
+
+	_, err = tx.Exec(`SET TRANSACTION ISOLATION LEVEL REPEATABLE READ`)
+	if err != nil {
+		return BuildReport{}, err
+	}
+
+	snapshot := &build{conn: b.conn, lockFactory: b.lockFactory}
+
+	row := buildsQuery.Where(sq.Eq{"b.id": b.id}).
+		RunWith(tx).
+		QueryRow()
+
+	err = scanBuild(snapshot, row, b.conn.EncryptionStrategy())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return BuildReport{}, ErrBuildNotFound
+		}
+		return BuildReport{}, err
+	}
+
+	rows, err := psql.Select("type", "version", "payload", "COALESCE(compressed, false)").
+		From(snapshot.eventsTable()).
+		Where(sq.Eq{"build_id": snapshot.id}).
+		OrderBy("event_id ASC").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return BuildReport{}, err
+	}
+
+	events, err := scanEventEnvelopes(rows)
+	if err != nil {
+		return BuildReport{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return BuildReport{}, err
+	}
+
+	return BuildReport{
+		ID:           snapshot.id,
+		Name:         snapshot.name,
+		Status:       snapshot.status,
+		TeamName:     snapshot.teamName,
+		PipelineName: snapshot.pipelineName,
+		JobName:      snapshot.jobName,
+		CreateTime:   snapshot.createTime,
+		StartTime:    snapshot.startTime,
+		EndTime:      snapshot.endTime,
+		Labels:       snapshot.labels,
+		PublicPlan:   snapshot.publicPlan,
+		Events:       events,
+	}, nil
+}
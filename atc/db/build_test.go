@@ -1,8 +1,14 @@
 package db_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
@@ -162,6 +168,268 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("Heartbeat", func() {
+		var build db.Build
+
+		lastTracked := func() time.Time {
+			var t time.Time
+			err := dbConn.QueryRow(`SELECT last_tracked FROM builds WHERE id = $1`, build.ID()).Scan(&t)
+			Expect(err).NotTo(HaveOccurred())
+			return t
+		}
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			_, err = dbConn.Exec(`UPDATE builds SET last_tracked = now() - '1 hour'::interval WHERE id = $1`, build.ID())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates when the started build was last tracked", func() {
+			err := build.Heartbeat()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(lastTracked()).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("does nothing once the build has finished", func() {
+			err := build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Heartbeat()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(lastTracked()).To(BeTemporally("<", time.Now().Add(-30*time.Minute)))
+		})
+
+		Describe("IsStale", func() {
+			It("is stale once it has not been tracked for longer than the timeout", func() {
+				stale, err := build.IsStale(10 * time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stale).To(BeTrue())
+			})
+
+			It("is not stale while it is tracked within the timeout", func() {
+				err := build.Heartbeat()
+				Expect(err).NotTo(HaveOccurred())
+
+				stale, err := build.IsStale(10 * time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stale).To(BeFalse())
+			})
+
+			It("is not stale if it was never tracked", func() {
+				_, err := dbConn.Exec(`UPDATE builds SET last_tracked = NULL WHERE id = $1`, build.ID())
+				Expect(err).NotTo(HaveOccurred())
+
+				stale, err := build.IsStale(10 * time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stale).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("MarkAsErrored", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.MarkAsErrored(errors.New("no workers"), db.ErrorCategoryWorker)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("finishes the build as errored with the category", func() {
+			Expect(build.ErrorCategory()).To(Equal(db.ErrorCategoryWorker))
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.BuildStatusErrored))
+			Expect(build.IsCompleted()).To(BeTrue())
+			Expect(build.ErrorCategory()).To(Equal(db.ErrorCategoryWorker))
+		})
+
+		It("saves an error event with the category before the status event", func() {
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			ev, err := events.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev.Event).To(Equal(event.EventTypeError))
+
+			var errorEvent event.Error
+			err = json.Unmarshal(*ev.Data, &errorEvent)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errorEvent.Message).To(Equal("no workers"))
+			Expect(errorEvent.Category).To(Equal("worker"))
+
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusErrored,
+				Time:   build.EndTime().Unix(),
+			})))
+		})
+
+		Context("when a step already reported the error", func() {
+			BeforeEach(func() {
+				var err error
+				build, err = team.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveEvent(event.Error{
+					Message: "no workers",
+					Origin:  event.Origin{ID: "some-step"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.MarkAsErrored(errors.New("no workers"), db.ErrorCategoryWorker)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("records the category without saving the error again", func() {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.Status()).To(Equal(db.BuildStatusErrored))
+				Expect(build.ErrorCategory()).To(Equal(db.ErrorCategoryWorker))
+
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				Expect(events.Next()).To(Equal(envelope(event.Error{
+					Message: "no workers",
+					Origin:  event.Origin{ID: "some-step"},
+				})))
+
+				Expect(events.Next()).To(Equal(envelope(event.Status{
+					Status: atc.StatusErrored,
+					Time:   build.EndTime().Unix(),
+				})))
+			})
+
+			Context("with a different category", func() {
+				BeforeEach(func() {
+					var err error
+					build, err = team.CreateOneOffBuild()
+					Expect(err).NotTo(HaveOccurred())
+
+					err = build.SaveEvent(event.Error{
+						Message:  "timed out",
+						Category: string(db.ErrorCategoryTimeout),
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = build.MarkAsErrored(errors.New("no workers"), db.ErrorCategoryWorker)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("records the given category", func() {
+					Expect(build.ErrorCategory()).To(Equal(db.ErrorCategoryWorker))
+
+					found, err := build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.ErrorCategory()).To(Equal(db.ErrorCategoryWorker))
+				})
+			})
+		})
+	})
+
+	Describe("SaveEventAndFinish", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEventAndFinish(event.Log{Payload: "last words"}, db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("finishes the build", func() {
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.BuildStatusSucceeded))
+			Expect(build.IsCompleted()).To(BeTrue())
+		})
+
+		It("saves the event before the status event", func() {
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "last words"})))
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusSucceeded,
+				Time:   build.EndTime().Unix(),
+			})))
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+
+		Context("when the event is an error and the build already has one", func() {
+			BeforeEach(func() {
+				var err error
+				build, err = team.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveEvent(event.Error{
+					Message: "first",
+					Origin:  event.Origin{ID: "some-step"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveEventAndFinish(event.Error{Message: "second"}, db.BuildStatusErrored)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("still saves the event", func() {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				Expect(events.Next()).To(Equal(envelope(event.Error{
+					Message: "first",
+					Origin:  event.Origin{ID: "some-step"},
+				})))
+				Expect(events.Next()).To(Equal(envelope(event.Error{Message: "second"})))
+				Expect(events.Next()).To(Equal(envelope(event.Status{
+					Status: atc.StatusErrored,
+					Time:   build.EndTime().Unix(),
+				})))
+			})
+		})
+	})
+
 	Describe("Finish", func() {
 		var build db.Build
 		BeforeEach(func() {
@@ -214,6 +482,76 @@ var _ = Describe("Build", func() {
 			Expect(build.IsCompleted()).To(BeTrue())
 			Expect(build.IsRunning()).To(BeFalse())
 		})
+
+		It("refuses to finish the build again", func() {
+			err := build.Finish(db.BuildStatusFailed)
+			Expect(err).To(Equal(db.ErrInvalidBuildTransition{
+				From: db.BuildStatusSucceeded,
+				To:   db.BuildStatusFailed,
+			}))
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.BuildStatusSucceeded))
+		})
+
+		It("refuses to abort the finished build", func() {
+			err := build.MarkAsAborted()
+			Expect(err).To(Equal(db.ErrInvalidBuildTransition{
+				From: db.BuildStatusSucceeded,
+				To:   db.BuildStatusAborted,
+			}))
+		})
+	})
+
+	Describe("Duration", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("is zero for a build that has not started", func() {
+			Expect(build.Duration()).To(BeZero())
+		})
+
+		It("is zero for a build that finished without starting", func() {
+			err := build.Finish(db.BuildStatusAborted)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Duration()).To(BeZero())
+		})
+
+		It("measures a running build up to now", func() {
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Duration()).To(BeNumerically(">", 0))
+		})
+
+		It("measures a finished build from start to end", func() {
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Duration()).To(Equal(build.EndTime().Sub(build.StartTime())))
+		})
 	})
 
 	Describe("Abort", func() {
@@ -232,24 +570,67 @@ var _ = Describe("Build", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 			Expect(build.IsAborted()).To(BeTrue())
+			Expect(build.AbortReason()).To(BeEmpty())
 		})
 	})
 
-	Describe("Events", func() {
-		It("saves and emits status events", func() {
-			build, err := team.CreateOneOffBuild()
+	Describe("AbortWithReason", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			By("allowing you to subscribe when no events have yet occurred")
+			err = build.AbortWithReason("pipeline destroyed")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("records the reason on the build", func() {
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.IsAborted()).To(BeTrue())
+			Expect(build.AbortReason()).To(Equal("pipeline destroyed"))
+		})
+
+		It("emits the reason into the event stream", func() {
 			events, err := build.Events(0)
 			Expect(err).NotTo(HaveOccurred())
 
 			defer db.Close(events)
 
-			By("emitting a status event when started")
-			started, err := build.Start(atc.Plan{})
+			Expect(events.Next()).To(Equal(envelope(event.Error{
+				Message: "aborted: pipeline destroyed",
+			})))
+		})
+
+		It("keeps the reason when aborted again without one", func() {
+			err := build.MarkAsAborted()
 			Expect(err).NotTo(HaveOccurred())
-			Expect(started).To(BeTrue())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.AbortReason()).To(Equal("pipeline destroyed"))
+		})
+	})
+
+	Describe("Events", func() {
+		It("saves and emits status events", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			By("allowing you to subscribe when no events have yet occurred")
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			By("emitting a status event when started")
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
 
 			found, err := build.Reload()
 			Expect(err).NotTo(HaveOccurred())
@@ -268,93 +649,787 @@ var _ = Describe("Build", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			Expect(events.Next()).To(Equal(envelope(event.Status{
-				Status: atc.StatusSucceeded,
-				Time:   build.EndTime().Unix(),
-			})))
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusSucceeded,
+				Time:   build.EndTime().Unix(),
+			})))
+
+			By("ending the stream when finished")
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+
+		It("only emits events of the requested types", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0, db.WithTypes(event.EventTypeStatus, event.EventTypeError))
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			err = build.SaveEvent(event.Log{Payload: "some log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Error{Message: "some error"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusErrored)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(events.Next()).To(Equal(envelope(event.Error{
+				Message: "some error",
+			})))
+
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusErrored,
+				Time:   build.EndTime().Unix(),
+			})))
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+
+		It("emits every event in order with a small buffer", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvents([]atc.Event{
+				event.Log{Payload: "some "},
+				event.Log{Payload: "log"},
+				event.Log{Payload: "lines"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0, db.WithBufferSize(1))
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			err = build.SaveEvent(event.Log{Payload: "more"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "some "})))
+			Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "log"})))
+			Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "lines"})))
+			Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "more"})))
+		})
+
+		It("emits heartbeats while no events arrive", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0, db.WithHeartbeat(10*time.Millisecond))
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			ev, err := events.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev.Event).To(Equal(event.EventTypeHeartbeat))
+
+			err = build.SaveEvent(event.Log{Payload: "some log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() atc.EventType {
+				ev, err := events.Next()
+				Expect(err).NotTo(HaveOccurred())
+				return ev.Event
+			}).Should(Equal(event.EventTypeLog))
+		})
+
+		It("stops waiting for the next event when the context is cancelled", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			err = build.SaveEvent(event.Log{Payload: "some log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx, cancel := context.WithCancel(context.Background())
+
+			Expect(events.NextContext(ctx)).To(Equal(envelope(event.Log{
+				Payload: "some log",
+			})))
+
+			cancel()
+
+			_, err = events.NextContext(ctx)
+			Expect(err).To(Equal(context.Canceled))
+		})
+	})
+
+	Describe("EventsPage", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			for i := 0; i < 5; i++ {
+				err = build.SaveEvent(event.Log{
+					Payload: fmt.Sprintf("log %d", i),
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("returns a bounded page of events and the next id", func() {
+			events, next, err := build.EventsPage(1, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "log 1"}),
+				envelope(event.Log{Payload: "log 2"}),
+			}))
+			Expect(next).To(Equal(uint(3)))
+		})
+
+		It("returns whatever is persisted for a running build without blocking", func() {
+			events, next, err := build.EventsPage(3, 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "log 3"}),
+				envelope(event.Log{Payload: "log 4"}),
+			}))
+			Expect(next).To(Equal(uint(5)))
+		})
+
+		Context("when there are no more events", func() {
+			It("returns an empty page and the same id", func() {
+				events, next, err := build.EventsPage(5, 100)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(BeEmpty())
+				Expect(next).To(Equal(uint(5)))
+			})
+		})
+
+		Context("when there are gaps in the event ids", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(fmt.Sprintf("DELETE FROM team_build_events_%d WHERE build_id = $1 AND event_id = 1", team.ID()), build.ID())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("continues from the id after the last event returned", func() {
+				events, next, err := build.EventsPage(0, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(Equal([]event.Envelope{
+					envelope(event.Log{Payload: "log 0"}),
+					envelope(event.Log{Payload: "log 2"}),
+				}))
+				Expect(next).To(Equal(uint(3)))
+			})
+		})
+	})
+
+	Describe("Snapshot", func() {
+		It("returns the build along with its saved events", func() {
+			build, err := defaultJob.CreateBuild(db.BuildTriggerManual)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "some log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			report, err := build.Snapshot()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.ID).To(Equal(build.ID()))
+			Expect(report.Name).To(Equal(build.Name()))
+			Expect(report.Status).To(Equal(db.BuildStatusSucceeded))
+			Expect(report.TeamName).To(Equal(build.TeamName()))
+			Expect(report.PipelineName).To(Equal(build.PipelineName()))
+			Expect(report.JobName).To(Equal(build.JobName()))
+			Expect(report.Events).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "some log"}),
+				envelope(event.Status{
+					Status: atc.StatusSucceeded,
+					Time:   build.EndTime().Unix(),
+				}),
+			}))
+
+			_, err = json.Marshal(report)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns ErrBuildNotFound once the build has been deleted", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = build.Delete()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = build.Snapshot()
+			Expect(err).To(Equal(db.ErrBuildNotFound))
+		})
+	})
+
+	Describe("TailEvents", func() {
+		saveLogs := func(build db.Build, count int) {
+			for i := 0; i < count; i++ {
+				err := build.SaveEvent(event.Log{
+					Payload: fmt.Sprintf("log %d", i),
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		}
+
+		It("returns the last events of a one-off build in order", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			saveLogs(build, 5)
+
+			events, err := build.TailEvents(2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "log 3"}),
+				envelope(event.Log{Payload: "log 4"}),
+			}))
+		})
+
+		It("returns the last events of a job build in order", func() {
+			build, err := defaultJob.CreateBuild(db.BuildTriggerManual)
+			Expect(err).NotTo(HaveOccurred())
+
+			saveLogs(build, 3)
+
+			events, err := build.TailEvents(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "log 2"}),
+			}))
+		})
+
+		Context("when the build has fewer events than requested", func() {
+			It("returns all of them", func() {
+				build, err := team.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				saveLogs(build, 2)
+
+				events, err := build.TailEvents(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(Equal([]event.Envelope{
+					envelope(event.Log{Payload: "log 0"}),
+					envelope(event.Log{Payload: "log 1"}),
+				}))
+			})
+		})
+	})
+
+	Describe("WriteEventsJSON", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "log 0"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "log 1"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		decode := func(buf *bytes.Buffer) []event.Envelope {
+			decoder := json.NewDecoder(buf)
+
+			envelopes := []event.Envelope{}
+			for decoder.More() {
+				var envelope event.Envelope
+				err := decoder.Decode(&envelope)
+				Expect(err).NotTo(HaveOccurred())
+
+				envelopes = append(envelopes, envelope)
+			}
+
+			return envelopes
+		}
+
+		It("writes the events as newline-delimited JSON until the end of the stream", func() {
+			buf := new(bytes.Buffer)
+
+			err := build.WriteEventsJSON(buf, 0, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(strings.Count(buf.String(), "\n")).To(Equal(3))
+			Expect(decode(buf)).To(Equal([]event.Envelope{
+				envelope(event.Log{Payload: "log 0"}),
+				envelope(event.Log{Payload: "log 1"}),
+				envelope(event.Status{
+					Status: atc.StatusSucceeded,
+					Time:   build.EndTime().Unix(),
+				}),
+			}))
+		})
+
+		It("starts at the given offset", func() {
+			buf := new(bytes.Buffer)
+
+			err := build.WriteEventsJSON(buf, 2, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(decode(buf)).To(Equal([]event.Envelope{
+				envelope(event.Status{
+					Status: atc.StatusSucceeded,
+					Time:   build.EndTime().Unix(),
+				}),
+			}))
+		})
+
+		Context("when the build is still running", func() {
+			var runningBuild db.Build
+
+			BeforeEach(func() {
+				var err error
+				runningBuild, err = team.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = runningBuild.SaveEvent(event.Log{Payload: "log 0"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns once the saved events are written when not live", func() {
+				buf := new(bytes.Buffer)
+
+				err := runningBuild.WriteEventsJSON(buf, 0, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(decode(buf)).To(Equal([]event.Envelope{
+					envelope(event.Log{Payload: "log 0"}),
+				}))
+			})
+
+			It("follows the live events until the build finishes when live", func() {
+				buf := new(bytes.Buffer)
+
+				errs := make(chan error, 1)
+				go func() {
+					errs <- runningBuild.WriteEventsJSON(buf, 0, true)
+				}()
+
+				Consistently(errs).ShouldNot(Receive())
+
+				err := runningBuild.SaveEvent(event.Log{Payload: "log 1"})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = runningBuild.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(errs).Should(Receive(BeNil()))
+				Expect(buf.String()).To(ContainSubstring("log 1"))
+			})
+		})
+	})
+
+	Describe("WriteSSE", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "log 0"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "log 1"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("writes the events with their offsets as ids, followed by an end event", func() {
+			err := build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			buf := new(bytes.Buffer)
+
+			err = build.WriteSSE(context.Background(), buf, 1)
+			Expect(err).NotTo(HaveOccurred())
+
+			payload, err := json.Marshal(envelope(event.Log{Payload: "log 1"}))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buf.String()).To(HavePrefix("id: 1\nevent: event\ndata: " + string(payload) + "\n\n"))
+			Expect(buf.String()).To(ContainSubstring("id: 2\nevent: event\n"))
+			Expect(buf.String()).To(HaveSuffix("id: 3\nevent: end\ndata\n\n"))
+		})
+
+		It("stops once the context is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			buf := new(bytes.Buffer)
+
+			errs := make(chan error, 1)
+			go func() {
+				errs <- build.WriteSSE(ctx, buf, 0)
+			}()
+
+			Consistently(errs).ShouldNot(Receive())
+
+			cancel()
+
+			Eventually(errs).Should(Receive(Equal(context.Canceled)))
+		})
+	})
+
+	Describe("SaveEvent", func() {
+		It("saves and propagates events correctly", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			By("allowing you to subscribe when no events have yet occurred")
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			By("saving them in order")
+			err = build.SaveEvent(event.Log{
+				Payload: "some ",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{
+				Payload: "some ",
+			})))
+
+			err = build.SaveEvent(event.Log{
+				Payload: "log",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{
+				Payload: "log",
+			})))
+
+			By("allowing you to subscribe from an offset")
+			eventsFrom1, err := build.Events(1)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(eventsFrom1)
+
+			Expect(eventsFrom1.Next()).To(Equal(envelope(event.Log{
+				Payload: "log",
+			})))
+
+			By("notifying those waiting on events as soon as they're saved")
+			nextEvent := make(chan event.Envelope)
+			nextErr := make(chan error)
+
+			go func() {
+				event, err := events.Next()
+				if err != nil {
+					nextErr <- err
+				} else {
+					nextEvent <- event
+				}
+			}()
+
+			Consistently(nextEvent).ShouldNot(Receive())
+			Consistently(nextErr).ShouldNot(Receive())
+
+			err = build.SaveEvent(event.Log{
+				Payload: "log 2",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(nextEvent).Should(Receive(Equal(envelope(event.Log{
+				Payload: "log 2",
+			}))))
+
+			By("returning ErrBuildEventStreamClosed for Next calls after Close")
+			events3, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = events3.Close()
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() error {
+				_, err := events3.Next()
+				return err
+			}).Should(Equal(db.ErrBuildEventStreamClosed))
+		})
+
+		// run with -race; the build is shared by steps running in parallel
+		It("can save events concurrently", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			wg := new(sync.WaitGroup)
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					err := build.SaveEvent(event.Log{
+						Payload: fmt.Sprintf("log %d", i),
+					})
+					Expect(err).NotTo(HaveOccurred())
+				}(i)
+			}
+
+			wg.Wait()
+
+			payloads := []string{}
+			for i := 0; i < 10; i++ {
+				ev, err := events.Next()
+				Expect(err).NotTo(HaveOccurred())
+
+				var log event.Log
+				err = json.Unmarshal(*ev.Data, &log)
+				Expect(err).NotTo(HaveOccurred())
+
+				payloads = append(payloads, log.Payload)
+			}
+
+			Expect(payloads).To(ConsistOf(
+				"log 0", "log 1", "log 2", "log 3", "log 4",
+				"log 5", "log 6", "log 7", "log 8", "log 9",
+			))
+		})
+	})
+
+	Describe("EventCount", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns 0 when no events have been saved", func() {
+			count, err := build.EventCount()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+
+		It("returns the number of saved events", func() {
+			err := build.SaveEvents([]atc.Event{
+				event.Log{Payload: "some "},
+				event.Log{Payload: "log"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			count, err := build.EventCount()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(uint(2)))
+		})
+	})
+
+	Describe("LastEventTime", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns false when no events have been saved", func() {
+			_, found, err := build.LastEventTime()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns the time the newest event was saved", func() {
+			before := time.Now()
+
+			err := build.SaveEvents([]atc.Event{
+				event.Log{Payload: "some "},
+				event.Log{Payload: "log"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			lastEventTime, found, err := build.LastEventTime()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(lastEventTime).To(BeTemporally("~", before, time.Minute))
+		})
+	})
+
+	Describe("DeleteEvents", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "some log"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build has completed", func() {
+			BeforeEach(func() {
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("deletes the events and marks the build as reaped", func() {
+				err := build.DeleteEvents()
+				Expect(err).NotTo(HaveOccurred())
+
+				count, err := build.EventCount()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(BeZero())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.ReapTime()).NotTo(BeZero())
+			})
+		})
+
+		Context("when the build is still running", func() {
+			It("returns an error and keeps the events", func() {
+				err := build.DeleteEvents()
+				Expect(err).To(Equal(db.ErrBuildNotCompleted))
 
-			By("ending the stream when finished")
-			_, err = events.Next()
-			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+				count, err := build.EventCount()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(Equal(uint(1)))
+			})
 		})
 	})
 
-	Describe("SaveEvent", func() {
-		It("saves and propagates events correctly", func() {
+	Describe("SaveEvents", func() {
+		It("saves the events in order and propagates them", func() {
 			build, err := team.CreateOneOffBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			By("allowing you to subscribe when no events have yet occurred")
 			events, err := build.Events(0)
 			Expect(err).NotTo(HaveOccurred())
 
 			defer db.Close(events)
 
-			By("saving them in order")
-			err = build.SaveEvent(event.Log{
-				Payload: "some ",
+			err = build.SaveEvents([]atc.Event{
+				event.Log{Payload: "some "},
+				event.Log{Payload: "log"},
+				event.Log{Payload: "lines"},
 			})
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(events.Next()).To(Equal(envelope(event.Log{
-				Payload: "some ",
-			})))
+			Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "some "})))
+			Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "log"})))
+			Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "lines"})))
 
-			err = build.SaveEvent(event.Log{
-				Payload: "log",
+			By("continuing the sequence for subsequent events")
+			err = build.SaveEvent(event.Log{Payload: "more"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "more"})))
+		})
+
+		Context("when there are no events", func() {
+			It("does nothing", func() {
+				build, err := team.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveEvents(nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				events, _, err := build.EventsPage(0, 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(BeEmpty())
 			})
+		})
+	})
+
+	Describe("SaveMetric", func() {
+		It("saves a metric event with the current time", func() {
+			build, err := team.CreateOneOffBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(events.Next()).To(Equal(envelope(event.Log{
-				Payload: "log",
-			})))
+			before := time.Now().Unix()
 
-			By("allowing you to subscribe from an offset")
-			eventsFrom1, err := build.Events(1)
+			err = build.SaveMetric("some-step", 1.5)
 			Expect(err).NotTo(HaveOccurred())
 
-			defer db.Close(eventsFrom1)
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
 
-			Expect(eventsFrom1.Next()).To(Equal(envelope(event.Log{
-				Payload: "log",
-			})))
+			defer db.Close(events)
 
-			By("notifying those waiting on events as soon as they're saved")
-			nextEvent := make(chan event.Envelope)
-			nextErr := make(chan error)
+			ev, err := events.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev.Event).To(Equal(event.EventTypeMetric))
+			Expect(ev.Version).To(Equal(atc.EventVersion("1.0")))
 
-			go func() {
-				event, err := events.Next()
-				if err != nil {
-					nextErr <- err
-				} else {
-					nextEvent <- event
-				}
-			}()
+			var metric event.Metric
+			err = json.Unmarshal(*ev.Data, &metric)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metric.Name).To(Equal("some-step"))
+			Expect(metric.Value).To(Equal(1.5))
+			Expect(metric.Time).To(BeNumerically(">=", before))
+		})
+	})
 
-			Consistently(nextEvent).ShouldNot(Receive())
-			Consistently(nextErr).ShouldNot(Receive())
+	Describe("Comments", func() {
+		var build db.Build
 
-			err = build.SaveEvent(event.Log{
-				Payload: "log 2",
-			})
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
 			Expect(err).NotTo(HaveOccurred())
+		})
 
-			Eventually(nextEvent).Should(Receive(Equal(envelope(event.Log{
-				Payload: "log 2",
-			}))))
+		It("returns no comments by default", func() {
+			comments, err := build.GetComments()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(comments).To(BeEmpty())
+		})
 
-			By("returning ErrBuildEventStreamClosed for Next calls after Close")
-			events3, err := build.Events(0)
+		It("returns the comments left on the build, oldest first", func() {
+			err := build.AddComment("some-user", "flaky, re-ran")
 			Expect(err).NotTo(HaveOccurred())
 
-			err = events3.Close()
+			err = build.AddComment("other-user", "investigated")
 			Expect(err).NotTo(HaveOccurred())
 
-			Eventually(func() error {
-				_, err := events3.Next()
-				return err
-			}).Should(Equal(db.ErrBuildEventStreamClosed))
+			otherBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = otherBuild.AddComment("some-user", "unrelated")
+			Expect(err).NotTo(HaveOccurred())
+
+			comments, err := build.GetComments()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(comments).To(HaveLen(2))
+			Expect(comments[0].Author).To(Equal("some-user"))
+			Expect(comments[0].Text).To(Equal("flaky, re-ran"))
+			Expect(comments[0].Time).NotTo(BeZero())
+			Expect(comments[1].Author).To(Equal("other-user"))
+			Expect(comments[1].Text).To(Equal("investigated"))
 		})
 	})
 
@@ -413,7 +1488,7 @@ var _ = Describe("Build", func() {
 
 		Context("when the version does not exist", func() {
 			It("can save a build's output", func() {
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = build.SaveOutput(logger, "some-type", atc.Source{"some": "explicit-source"}, creds.VersionedResourceTypes{}, atc.Version{"some": "version"}, []db.ResourceConfigMetadataField{
@@ -454,7 +1529,7 @@ var _ = Describe("Build", func() {
 			})
 
 			It("does not increment the check order", func() {
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = build.SaveOutput(logger, "some-type", atc.Source{"some": "explicit-source"}, creds.VersionedResourceTypes{}, atc.Version{"some": "version"}, []db.ResourceConfigMetadataField{
@@ -478,6 +1553,254 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("GetResourceMetadata", func() {
+		var build db.Build
+
+		saveOutput := func(build db.Build, version string, metadata db.ResourceConfigMetadataFields) {
+			err := build.SaveOutput(logger, "some-base-resource-type", atc.Source{"some": "source"}, creds.VersionedResourceTypes{}, atc.Version{"version": version}, metadata, "some-output", "some-resource")
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			_, err := defaultResource.SetResourceConfig(logger, atc.Source{"some": "source"}, creds.VersionedResourceTypes{})
+			Expect(err).NotTo(HaveOccurred())
+
+			previousBuild, err := defaultJob.CreateBuild(db.BuildTriggerManual)
+			Expect(err).NotTo(HaveOccurred())
+
+			saveOutput(previousBuild, "v1", db.ResourceConfigMetadataFields{
+				{Name: "commit", Value: "abc"},
+				{Name: "author", Value: "someone"},
+			})
+
+			build, err = defaultJob.CreateBuild(db.BuildTriggerManual)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an empty map for a build without resources", func() {
+			metadata, err := build.GetResourceMetadata()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metadata).To(BeEmpty())
+		})
+
+		It("flattens the metadata of the inputs and outputs, letting the last key win", func() {
+			err := build.UseInputs([]db.BuildInput{
+				{
+					Name:       "some-input",
+					Version:    atc.Version{"version": "v1"},
+					ResourceID: defaultResource.ID(),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			saveOutput(build, "v2", db.ResourceConfigMetadataFields{
+				{Name: "commit", Value: "def"},
+				{Name: "branch", Value: "some-branch"},
+				{Name: "branch", Value: "other-branch"},
+			})
+
+			metadata, err := build.GetResourceMetadata()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metadata).To(Equal(map[string]map[string]string{
+				"some-resource": {
+					"commit": "def",
+					"author": "someone",
+					"branch": "other-branch",
+				},
+			}))
+		})
+
+		It("orders several inputs of the same resource by name", func() {
+			saveOutput(build, "v2", db.ResourceConfigMetadataFields{
+				{Name: "commit", Value: "def"},
+			})
+
+			otherBuild, err := defaultJob.CreateBuild(db.BuildTriggerManual)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = otherBuild.UseInputs([]db.BuildInput{
+				{
+					Name:       "b-input",
+					Version:    atc.Version{"version": "v1"},
+					ResourceID: defaultResource.ID(),
+				},
+				{
+					Name:       "a-input",
+					Version:    atc.Version{"version": "v2"},
+					ResourceID: defaultResource.ID(),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			for i := 0; i < 5; i++ {
+				metadata, err := otherBuild.GetResourceMetadata()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metadata).To(Equal(map[string]map[string]string{
+					"some-resource": {
+						"commit": "abc",
+						"author": "someone",
+					},
+				}))
+			}
+		})
+	})
+
+	Describe("GetInputs/GetOutputs", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			_, err := defaultResource.SetResourceConfig(logger, atc.Source{"some": "source"}, creds.VersionedResourceTypes{})
+			Expect(err).NotTo(HaveOccurred())
+
+			previousBuild, err := defaultJob.CreateBuild(db.BuildTriggerManual)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = previousBuild.SaveOutput(logger, "some-base-resource-type", atc.Source{"some": "source"}, creds.VersionedResourceTypes{}, atc.Version{"version": "v1"}, db.ResourceConfigMetadataFields{{Name: "commit", Value: "abc"}}, "some-output", "some-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			build, err = defaultJob.CreateBuild(db.BuildTriggerManual)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.UseInputs([]db.BuildInput{
+				{
+					Name:       "some-input",
+					Version:    atc.Version{"version": "v1"},
+					ResourceID: defaultResource.ID(),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveOutput(logger, "some-base-resource-type", atc.Source{"some": "source"}, creds.VersionedResourceTypes{}, atc.Version{"version": "v1"}, db.ResourceConfigMetadataFields{{Name: "commit", Value: "abc"}}, "some-output", "some-resource")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveOutput(logger, "some-base-resource-type", atc.Source{"some": "source"}, creds.VersionedResourceTypes{}, atc.Version{"version": "v2"}, db.ResourceConfigMetadataFields{{Name: "commit", Value: "def"}}, "other-output", "some-resource")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the inputs with their metadata, including those also produced as outputs", func() {
+			inputs, err := build.GetInputs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputs).To(Equal([]db.BuildInput{
+				{
+					Name:            "some-input",
+					Version:         atc.Version{"version": "v1"},
+					ResourceID:      defaultResource.ID(),
+					FirstOccurrence: true,
+					Enabled:         true,
+					Metadata:        db.ResourceConfigMetadataFields{{Name: "commit", Value: "abc"}},
+				},
+			}))
+		})
+
+		It("returns the current enabled and pinned state of the versions", func() {
+			scope, err := defaultResource.SetResourceConfig(logger, atc.Source{"some": "source"}, creds.VersionedResourceTypes{})
+			Expect(err).NotTo(HaveOccurred())
+
+			v1, found, err := scope.FindVersion(atc.Version{"version": "v1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			v2, found, err := scope.FindVersion(atc.Version{"version": "v2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = defaultResource.DisableVersion(v1.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			err = defaultResource.PinVersion(v2.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			inputs, err := build.GetInputs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputs).To(HaveLen(1))
+			Expect(inputs[0].Enabled).To(BeFalse())
+			Expect(inputs[0].Pinned).To(BeFalse())
+
+			outputs, err := build.GetOutputs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(HaveLen(2))
+			Expect(outputs[0].Name).To(Equal("other-output"))
+			Expect(outputs[0].Enabled).To(BeTrue())
+			Expect(outputs[0].Pinned).To(BeTrue())
+			Expect(outputs[1].Enabled).To(BeFalse())
+		})
+
+		It("returns the outputs with their metadata ordered by name", func() {
+			outputs, err := build.GetOutputs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(Equal([]db.BuildOutput{
+				{
+					Name:     "other-output",
+					Version:  atc.Version{"version": "v2"},
+					Enabled:  true,
+					Metadata: db.ResourceConfigMetadataFields{{Name: "commit", Value: "def"}},
+				},
+				{
+					Name:     "some-output",
+					Version:  atc.Version{"version": "v1"},
+					Enabled:  true,
+					Metadata: db.ResourceConfigMetadataFields{{Name: "commit", Value: "abc"}},
+				},
+			}))
+		})
+	})
+
+	Describe("GetInputResourceConfigVersionIDs", func() {
+		var (
+			build db.Build
+			scope db.ResourceConfigScope
+		)
+
+		BeforeEach(func() {
+			var err error
+			scope, err = defaultResource.SetResourceConfig(logger, atc.Source{"some": "source"}, creds.VersionedResourceTypes{})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = scope.SaveVersions([]atc.Version{
+				{"version": "v1"},
+				{"version": "v2"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			build, err = defaultJob.CreateBuild(db.BuildTriggerManual)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns no ids for a build without inputs", func() {
+			ids, err := build.GetInputResourceConfigVersionIDs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(BeEmpty())
+		})
+
+		It("returns the ids of the input versions ordered by input name", func() {
+			err := build.UseInputs([]db.BuildInput{
+				{
+					Name:       "b-input",
+					Version:    atc.Version{"version": "v1"},
+					ResourceID: defaultResource.ID(),
+				},
+				{
+					Name:       "a-input",
+					Version:    atc.Version{"version": "v2"},
+					ResourceID: defaultResource.ID(),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			v1, found, err := scope.FindVersion(atc.Version{"version": "v1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			v2, found, err := scope.FindVersion(atc.Version{"version": "v2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			ids, err := build.GetInputResourceConfigVersionIDs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal([]int{v2.ID(), v1.ID()}))
+		})
+	})
+
 	Describe("Resources", func() {
 		var (
 			pipeline             db.Pipeline
@@ -558,7 +1881,7 @@ var _ = Describe("Build", func() {
 		})
 
 		It("returns build inputs and outputs", func() {
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
 			// save a normal 'get'
@@ -572,21 +1895,64 @@ var _ = Describe("Build", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			// save explicit output from 'put'
-			err = build.SaveOutput(logger, "some-type", atc.Source{"some": "source-2"}, creds.VersionedResourceTypes{}, atc.Version{"ver": "2"}, nil, "some-output-name", "some-other-resource")
+			err = build.SaveOutput(logger, "some-type", atc.Source{"some": "source-2"}, creds.VersionedResourceTypes{}, atc.Version{"ver": "2"}, db.ResourceConfigMetadataFields{{Name: "commit", Value: "abc"}}, "some-output-name", "some-other-resource")
 			Expect(err).NotTo(HaveOccurred())
 
 			inputs, outputs, err := build.Resources()
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(inputs).To(ConsistOf([]db.BuildInput{
-				{Name: "some-input", Version: atc.Version{"ver": "1"}, ResourceID: resource1.ID(), FirstOccurrence: true},
+				{Name: "some-input", Version: atc.Version{"ver": "1"}, ResourceID: resource1.ID(), FirstOccurrence: true, Enabled: true},
 			}))
 
 			Expect(outputs).To(ConsistOf([]db.BuildOutput{
 				{
-					Name:    "some-output-name",
-					Version: atc.Version{"ver": "2"},
+					Name:     "some-output-name",
+					Version:  atc.Version{"ver": "2"},
+					Enabled:  true,
+					Metadata: db.ResourceConfigMetadataFields{{Name: "commit", Value: "abc"}},
+				},
+			}))
+		})
+
+		It("returns the current enabled and pinned state of the versions", func() {
+			build, err := job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.UseInputs([]db.BuildInput{
+				{
+					Name:       "some-input",
+					Version:    atc.Version{"ver": "1"},
+					ResourceID: resource1.ID(),
+				},
+				{
+					Name:       "some-other-input",
+					Version:    atc.Version{"ver": "2"},
+					ResourceID: resource1.ID(),
 				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			rcv1, found, err := resourceConfigScope1.FindVersion(atc.Version{"ver": "1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			rcv2, found, err := resourceConfigScope1.FindVersion(atc.Version{"ver": "2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = resource1.DisableVersion(rcv1.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			err = resource1.PinVersion(rcv2.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			inputs, _, err := build.Resources()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs).To(ConsistOf([]db.BuildInput{
+				{Name: "some-input", Version: atc.Version{"ver": "1"}, ResourceID: resource1.ID(), FirstOccurrence: true, Enabled: false, Pinned: false},
+				{Name: "some-other-input", Version: atc.Version{"ver": "2"}, ResourceID: resource1.ID(), FirstOccurrence: true, Enabled: true, Pinned: true},
 			}))
 		})
 
@@ -632,7 +1998,7 @@ var _ = Describe("Build", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err = job.CreateBuild()
+				build, err = job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -740,7 +2106,7 @@ var _ = Describe("Build", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err = job.CreateBuild()
+				build, err = job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
 				expectedBuildPrep.BuildID = build.ID()
@@ -810,7 +2176,7 @@ var _ = Describe("Build", func() {
 
 				Context("when pipeline is paused", func() {
 					BeforeEach(func() {
-						err := pipeline.Pause()
+						err := pipeline.Pause("")
 						Expect(err).NotTo(HaveOccurred())
 
 						expectedBuildPrep.PausedPipeline = db.BuildPreparationStatusBlocking
@@ -1023,7 +2389,7 @@ var _ = Describe("Build", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err = job.CreateBuild()
+				build, err = job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(build.IsScheduled()).To(BeFalse())
 			})
@@ -1099,7 +2465,7 @@ var _ = Describe("Build", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err = job.CreateBuild()
+			build, err = job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 
 			setupTx, err := dbConn.Begin()
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// CausalityEdge links a build to a resource version it either used as an
+// input or produced as an output.
+type CausalityEdge struct {
+	BuildID           int  `json:"build_id"`
+	ResourceVersionID int  `json:"resource_version_id"`
+	Input             bool `json:"input"`
+}
+
+// CausalityGraph is the provenance of a resource version: the builds that
+// produced it, the versions those builds used as inputs, the builds that
+// produced those, and so on.
+type CausalityGraph struct {
+	ResourceVersionIDs []int           `json:"resource_version_ids"`
+	BuildIDs           []int           `json:"build_ids"`
+	Edges              []CausalityEdge `json:"edges"`
+}
+
+// GetVersionCausality walks back from the given resource config version to
+// the builds of the pipeline that produced it and the versions they used,
+// following at most maxDepth builds deep. Each build and version appears in
+// the graph once, so cycles, e.g. a job which puts to its own input, end the
+// walk. It returns ErrVersionNotFound if none of the pipeline's resources
+// have the version.
+func (p *pipeline) GetVersionCausality(resourceConfigVersionID int, maxDepth int) (CausalityGraph, error) {
+	var id int
+	err := psql.Select("v.id").
+		From("resource_config_versions v").
+		Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
+		Where(sq.Eq{
+			"v.id":          resourceConfigVersionID,
+			"r.pipeline_id": p.id,
+		}).
+		Limit(1).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return CausalityGraph{}, ErrVersionNotFound
+		}
+		return CausalityGraph{}, err
+	}
+
+	graph := CausalityGraph{
+		ResourceVersionIDs: []int{resourceConfigVersionID},
+		BuildIDs:           []int{},
+		Edges:              []CausalityEdge{},
+	}
+
+	seenVersions := map[int]bool{resourceConfigVersionID: true}
+	seenBuilds := map[int]bool{}
+
+	versions := []int{resourceConfigVersionID}
+	for depth := 0; depth < maxDepth && len(versions) > 0; depth++ {
+		producers, err := p.causalityEdges("build_resource_config_version_outputs", sq.Eq{"v.id": versions})
+		if err != nil {
+			return CausalityGraph{}, err
+		}
+
+		builds := []int{}
+		for _, edge := range producers {
+			graph.Edges = append(graph.Edges, edge)
+
+			if !seenBuilds[edge.BuildID] {
+				seenBuilds[edge.BuildID] = true
+				graph.BuildIDs = append(graph.BuildIDs, edge.BuildID)
+				builds = append(builds, edge.BuildID)
+			}
+		}
+
+		if len(builds) == 0 {
+			break
+		}
+
+		inputs, err := p.causalityEdges("build_resource_config_version_inputs", sq.Eq{"io.build_id": builds})
+		if err != nil {
+			return CausalityGraph{}, err
+		}
+
+		versions = []int{}
+		for _, edge := range inputs {
+			edge.Input = true
+			graph.Edges = append(graph.Edges, edge)
+
+			if !seenVersions[edge.ResourceVersionID] {
+				seenVersions[edge.ResourceVersionID] = true
+				graph.ResourceVersionIDs = append(graph.ResourceVersionIDs, edge.ResourceVersionID)
+				versions = append(versions, edge.ResourceVersionID)
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+// causalityEdges returns the rows of the pipeline's resources in the given
+// inputs or outputs table matching the condition, as edges between builds and
+// resource config versions.
+func (p *pipeline) causalityEdges(table string, cond sq.Eq) ([]CausalityEdge, error) {
+	rows, err := psql.Select("io.build_id", "v.id").
+		From(table+" io").
+		Join("resources r ON r.id = io.resource_id").
+		Join("resource_config_versions v ON v.version_md5 = io.version_md5 AND v.resource_config_scope_id = r.resource_config_scope_id").
+		Where(sq.Eq{"r.pipeline_id": p.id}).
+		Where(cond).
+		OrderBy("io.build_id ASC", "v.id ASC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	edges := []CausalityEdge{}
+	for rows.Next() {
+		var edge CausalityEdge
+		err = rows.Scan(&edge.BuildID, &edge.ResourceVersionID)
+		if err != nil {
+			return nil, err
+		}
+
+		edges = append(edges, edge)
+	}
+
+	return edges, rows.Err()
+}
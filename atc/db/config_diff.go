@@ -0,0 +1,104 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// ConfigDiff describes how the jobs and resources of a pipeline changed
+// between two configs. Entries are compared by name, so reordering jobs or
+// resources (or the fields within them) is not considered a change.
+type ConfigDiff struct {
+	AddedJobs    []atc.JobConfig
+	RemovedJobs  []atc.JobConfig
+	ModifiedJobs []JobConfigChange
+
+	AddedResources    []atc.ResourceConfig
+	RemovedResources  []atc.ResourceConfig
+	ModifiedResources []ResourceConfigChange
+}
+
+type JobConfigChange struct {
+	Before atc.JobConfig
+	After  atc.JobConfig
+}
+
+type ResourceConfigChange struct {
+	Before atc.ResourceConfig
+	After  atc.ResourceConfig
+}
+
+// IsEmpty returns true if the two configs had the same jobs and resources.
+func (diff ConfigDiff) IsEmpty() bool {
+	return len(diff.AddedJobs) == 0 &&
+		len(diff.RemovedJobs) == 0 &&
+		len(diff.ModifiedJobs) == 0 &&
+		len(diff.AddedResources) == 0 &&
+		len(diff.RemovedResources) == 0 &&
+		len(diff.ModifiedResources) == 0
+}
+
+// DiffConfigs computes the job and resource level differences between two
+// pipeline configs.
+func DiffConfigs(from atc.Config, to atc.Config) ConfigDiff {
+	var diff ConfigDiff
+
+	for _, before := range from.Jobs {
+		after, found := to.Jobs.Lookup(before.Name)
+		if !found {
+			diff.RemovedJobs = append(diff.RemovedJobs, before)
+			continue
+		}
+
+		if configsDiffer(before, after) {
+			diff.ModifiedJobs = append(diff.ModifiedJobs, JobConfigChange{
+				Before: before,
+				After:  after,
+			})
+		}
+	}
+
+	for _, after := range to.Jobs {
+		if _, found := from.Jobs.Lookup(after.Name); !found {
+			diff.AddedJobs = append(diff.AddedJobs, after)
+		}
+	}
+
+	for _, before := range from.Resources {
+		after, found := to.Resources.Lookup(before.Name)
+		if !found {
+			diff.RemovedResources = append(diff.RemovedResources, before)
+			continue
+		}
+
+		if configsDiffer(before, after) {
+			diff.ModifiedResources = append(diff.ModifiedResources, ResourceConfigChange{
+				Before: before,
+				After:  after,
+			})
+		}
+	}
+
+	for _, after := range to.Resources {
+		if _, found := from.Resources.Lookup(after.Name); !found {
+			diff.AddedResources = append(diff.AddedResources, after)
+		}
+	}
+
+	return diff
+}
+
+// configsDiffer compares the JSON encoding of two configs rather than the
+// values themselves, so that numbers decoded from YAML and from JSON (e.g. 300
+// and 300.0) are treated as equal.
+func configsDiffer(a interface{}, b interface{}) bool {
+	payloadA, errA := json.Marshal(a)
+	payloadB, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return true
+	}
+
+	return !bytes.Equal(payloadA, payloadB)
+}
@@ -0,0 +1,64 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffConfigs", func() {
+	var from, to atc.Config
+
+	BeforeEach(func() {
+		from = atc.Config{
+			Resources: atc.ResourceConfigs{
+				{Name: "some-resource", Type: "git", Source: atc.Source{"uri": "some-uri"}},
+				{Name: "removed-resource", Type: "git"},
+			},
+			Jobs: atc.JobConfigs{
+				{Name: "some-job", Plan: atc.PlanSequence{{Get: "some-resource"}}},
+				{Name: "changed-job", Plan: atc.PlanSequence{{Get: "some-resource"}}},
+				{Name: "removed-job"},
+			},
+		}
+
+		to = atc.Config{
+			Resources: atc.ResourceConfigs{
+				{Name: "added-resource", Type: "time"},
+				{Name: "some-resource", Type: "git", Source: atc.Source{"uri": "some-other-uri"}},
+			},
+			Jobs: atc.JobConfigs{
+				{Name: "added-job"},
+				{Name: "changed-job", Plan: atc.PlanSequence{{Get: "some-resource", Trigger: true}}},
+				{Name: "some-job", Plan: atc.PlanSequence{{Get: "some-resource"}}},
+			},
+		}
+	})
+
+	It("reports added, removed and modified jobs and resources", func() {
+		diff := db.DiffConfigs(from, to)
+
+		Expect(diff.AddedJobs).To(Equal([]atc.JobConfig{to.Jobs[0]}))
+		Expect(diff.RemovedJobs).To(Equal([]atc.JobConfig{from.Jobs[2]}))
+		Expect(diff.ModifiedJobs).To(Equal([]db.JobConfigChange{
+			{Before: from.Jobs[1], After: to.Jobs[1]},
+		}))
+
+		Expect(diff.AddedResources).To(Equal([]atc.ResourceConfig{to.Resources[0]}))
+		Expect(diff.RemovedResources).To(Equal([]atc.ResourceConfig{from.Resources[1]}))
+		Expect(diff.ModifiedResources).To(Equal([]db.ResourceConfigChange{
+			{Before: from.Resources[0], After: to.Resources[1]},
+		}))
+	})
+
+	It("ignores reordering", func() {
+		reordered := atc.Config{
+			Resources: atc.ResourceConfigs{from.Resources[1], from.Resources[0]},
+			Jobs:      atc.JobConfigs{from.Jobs[2], from.Jobs[0], from.Jobs[1]},
+		}
+
+		Expect(db.DiffConfigs(from, reordered).IsEmpty()).To(BeTrue())
+	})
+})
@@ -9,3 +9,11 @@ type DashboardJob struct {
 }
 
 type Dashboard []DashboardJob
+
+// JobStatus holds the builds needed to render a single job. Any of them may be
+// nil if the job has not had such a build yet.
+type JobStatus struct {
+	NextBuild       Build
+	FinishedBuild   Build
+	TransitionBuild Build
+}
@@ -2,7 +2,9 @@
 package dbfakes
 
 import (
+	"context"
 	"encoding/json"
+	"io"
 	"sync"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/event"
 )
 
 type FakeBuild struct {
@@ -26,6 +29,27 @@ type FakeBuild struct {
 		result1 db.Notifier
 		result2 error
 	}
+	AbortReasonStub        func() string
+	abortReasonMutex       sync.RWMutex
+	abortReasonArgsForCall []struct {
+	}
+	abortReasonReturns struct {
+		result1 string
+	}
+	abortReasonReturnsOnCall map[int]struct {
+		result1 string
+	}
+	AbortWithReasonStub        func(string) error
+	abortWithReasonMutex       sync.RWMutex
+	abortWithReasonArgsForCall []struct {
+		arg1 string
+	}
+	abortWithReasonReturns struct {
+		result1 error
+	}
+	abortWithReasonReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AcquireTrackingLockStub        func(lager.Logger, time.Duration) (lock.Lock, bool, error)
 	acquireTrackingLockMutex       sync.RWMutex
 	acquireTrackingLockArgsForCall []struct {
@@ -42,6 +66,18 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	AddCommentStub        func(string, string) error
+	addCommentMutex       sync.RWMutex
+	addCommentArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	addCommentReturns struct {
+		result1 error
+	}
+	addCommentReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ArtifactStub        func(int) (db.WorkerArtifact, error)
 	artifactMutex       sync.RWMutex
 	artifactArgsForCall []struct {
@@ -89,6 +125,26 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	DeleteEventsStub        func() error
+	deleteEventsMutex       sync.RWMutex
+	deleteEventsArgsForCall []struct {
+	}
+	deleteEventsReturns struct {
+		result1 error
+	}
+	deleteEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DurationStub        func() time.Duration
+	durationMutex       sync.RWMutex
+	durationArgsForCall []struct {
+	}
+	durationReturns struct {
+		result1 time.Duration
+	}
+	durationReturnsOnCall map[int]struct {
+		result1 time.Duration
+	}
 	EndTimeStub        func() time.Time
 	endTimeMutex       sync.RWMutex
 	endTimeArgsForCall []struct {
@@ -99,10 +155,33 @@ type FakeBuild struct {
 	endTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
-	EventsStub        func(uint) (db.EventSource, error)
+	ErrorCategoryStub        func() db.ErrorCategory
+	errorCategoryMutex       sync.RWMutex
+	errorCategoryArgsForCall []struct {
+	}
+	errorCategoryReturns struct {
+		result1 db.ErrorCategory
+	}
+	errorCategoryReturnsOnCall map[int]struct {
+		result1 db.ErrorCategory
+	}
+	EventCountStub        func() (uint, error)
+	eventCountMutex       sync.RWMutex
+	eventCountArgsForCall []struct {
+	}
+	eventCountReturns struct {
+		result1 uint
+		result2 error
+	}
+	eventCountReturnsOnCall map[int]struct {
+		result1 uint
+		result2 error
+	}
+	EventsStub        func(uint, ...db.EventsOption) (db.EventSource, error)
 	eventsMutex       sync.RWMutex
 	eventsArgsForCall []struct {
 		arg1 uint
+		arg2 []db.EventsOption
 	}
 	eventsReturns struct {
 		result1 db.EventSource
@@ -112,6 +191,22 @@ type FakeBuild struct {
 		result1 db.EventSource
 		result2 error
 	}
+	EventsPageStub        func(uint, uint) ([]event.Envelope, uint, error)
+	eventsPageMutex       sync.RWMutex
+	eventsPageArgsForCall []struct {
+		arg1 uint
+		arg2 uint
+	}
+	eventsPageReturns struct {
+		result1 []event.Envelope
+		result2 uint
+		result3 error
+	}
+	eventsPageReturnsOnCall map[int]struct {
+		result1 []event.Envelope
+		result2 uint
+		result3 error
+	}
 	FinishStub        func(db.BuildStatus) error
 	finishMutex       sync.RWMutex
 	finishArgsForCall []struct {
@@ -123,6 +218,76 @@ type FakeBuild struct {
 	finishReturnsOnCall map[int]struct {
 		result1 error
 	}
+	GetCommentsStub        func() ([]db.BuildComment, error)
+	getCommentsMutex       sync.RWMutex
+	getCommentsArgsForCall []struct {
+	}
+	getCommentsReturns struct {
+		result1 []db.BuildComment
+		result2 error
+	}
+	getCommentsReturnsOnCall map[int]struct {
+		result1 []db.BuildComment
+		result2 error
+	}
+	GetInputResourceConfigVersionIDsStub        func() ([]int, error)
+	getInputResourceConfigVersionIDsMutex       sync.RWMutex
+	getInputResourceConfigVersionIDsArgsForCall []struct {
+	}
+	getInputResourceConfigVersionIDsReturns struct {
+		result1 []int
+		result2 error
+	}
+	getInputResourceConfigVersionIDsReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
+	GetInputsStub        func() ([]db.BuildInput, error)
+	getInputsMutex       sync.RWMutex
+	getInputsArgsForCall []struct {
+	}
+	getInputsReturns struct {
+		result1 []db.BuildInput
+		result2 error
+	}
+	getInputsReturnsOnCall map[int]struct {
+		result1 []db.BuildInput
+		result2 error
+	}
+	GetOutputsStub        func() ([]db.BuildOutput, error)
+	getOutputsMutex       sync.RWMutex
+	getOutputsArgsForCall []struct {
+	}
+	getOutputsReturns struct {
+		result1 []db.BuildOutput
+		result2 error
+	}
+	getOutputsReturnsOnCall map[int]struct {
+		result1 []db.BuildOutput
+		result2 error
+	}
+	GetResourceMetadataStub        func() (map[string]map[string]string, error)
+	getResourceMetadataMutex       sync.RWMutex
+	getResourceMetadataArgsForCall []struct {
+	}
+	getResourceMetadataReturns struct {
+		result1 map[string]map[string]string
+		result2 error
+	}
+	getResourceMetadataReturnsOnCall map[int]struct {
+		result1 map[string]map[string]string
+		result2 error
+	}
+	HeartbeatStub        func() error
+	heartbeatMutex       sync.RWMutex
+	heartbeatArgsForCall []struct {
+	}
+	heartbeatReturns struct {
+		result1 error
+	}
+	heartbeatReturnsOnCall map[int]struct {
+		result1 error
+	}
 	IDStub        func() int
 	iDMutex       sync.RWMutex
 	iDArgsForCall []struct {
@@ -205,6 +370,19 @@ type FakeBuild struct {
 	isScheduledReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	IsStaleStub        func(time.Duration) (bool, error)
+	isStaleMutex       sync.RWMutex
+	isStaleArgsForCall []struct {
+		arg1 time.Duration
+	}
+	isStaleReturns struct {
+		result1 bool
+		result2 error
+	}
+	isStaleReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	JobIDStub        func() int
 	jobIDMutex       sync.RWMutex
 	jobIDArgsForCall []struct {
@@ -225,6 +403,30 @@ type FakeBuild struct {
 	jobNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	LabelsStub        func() map[string]string
+	labelsMutex       sync.RWMutex
+	labelsArgsForCall []struct {
+	}
+	labelsReturns struct {
+		result1 map[string]string
+	}
+	labelsReturnsOnCall map[int]struct {
+		result1 map[string]string
+	}
+	LastEventTimeStub        func() (time.Time, bool, error)
+	lastEventTimeMutex       sync.RWMutex
+	lastEventTimeArgsForCall []struct {
+	}
+	lastEventTimeReturns struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}
+	lastEventTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}
 	MarkAsAbortedStub        func() error
 	markAsAbortedMutex       sync.RWMutex
 	markAsAbortedArgsForCall []struct {
@@ -235,6 +437,18 @@ type FakeBuild struct {
 	markAsAbortedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	MarkAsErroredStub        func(error, db.ErrorCategory) error
+	markAsErroredMutex       sync.RWMutex
+	markAsErroredArgsForCall []struct {
+		arg1 error
+		arg2 db.ErrorCategory
+	}
+	markAsErroredReturns struct {
+		result1 error
+	}
+	markAsErroredReturnsOnCall map[int]struct {
+		result1 error
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -245,6 +459,16 @@ type FakeBuild struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	OverridesPauseStub        func() bool
+	overridesPauseMutex       sync.RWMutex
+	overridesPauseArgsForCall []struct {
+	}
+	overridesPauseReturns struct {
+		result1 bool
+	}
+	overridesPauseReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	PipelineStub        func() (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
@@ -335,6 +559,16 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	RerunOfStub        func() int
+	rerunOfMutex       sync.RWMutex
+	rerunOfArgsForCall []struct {
+	}
+	rerunOfReturns struct {
+		result1 int
+	}
+	rerunOfReturnsOnCall map[int]struct {
+		result1 int
+	}
 	ResourcesStub        func() ([]db.BuildInput, []db.BuildOutput, error)
 	resourcesMutex       sync.RWMutex
 	resourcesArgsForCall []struct {
@@ -360,6 +594,29 @@ type FakeBuild struct {
 	saveEventReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveEventAndFinishStub        func(atc.Event, db.BuildStatus) error
+	saveEventAndFinishMutex       sync.RWMutex
+	saveEventAndFinishArgsForCall []struct {
+		arg1 atc.Event
+		arg2 db.BuildStatus
+	}
+	saveEventAndFinishReturns struct {
+		result1 error
+	}
+	saveEventAndFinishReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveEventsStub        func([]atc.Event) error
+	saveEventsMutex       sync.RWMutex
+	saveEventsArgsForCall []struct {
+		arg1 []atc.Event
+	}
+	saveEventsReturns struct {
+		result1 error
+	}
+	saveEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveImageResourceVersionStub        func(db.UsedResourceCache) error
 	saveImageResourceVersionMutex       sync.RWMutex
 	saveImageResourceVersionArgsForCall []struct {
@@ -371,6 +628,30 @@ type FakeBuild struct {
 	saveImageResourceVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveInputStub        func(db.BuildInput, bool) error
+	saveInputMutex       sync.RWMutex
+	saveInputArgsForCall []struct {
+		arg1 db.BuildInput
+		arg2 bool
+	}
+	saveInputReturns struct {
+		result1 error
+	}
+	saveInputReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveMetricStub        func(string, float64) error
+	saveMetricMutex       sync.RWMutex
+	saveMetricArgsForCall []struct {
+		arg1 string
+		arg2 float64
+	}
+	saveMetricReturns struct {
+		result1 error
+	}
+	saveMetricReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveOutputStub        func(lager.Logger, string, atc.Source, creds.VersionedResourceTypes, atc.Version, db.ResourceConfigMetadataFields, string, string) error
 	saveOutputMutex       sync.RWMutex
 	saveOutputArgsForCall []struct {
@@ -433,6 +714,18 @@ type FakeBuild struct {
 	setInterceptibleReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SnapshotStub        func() (db.BuildReport, error)
+	snapshotMutex       sync.RWMutex
+	snapshotArgsForCall []struct {
+	}
+	snapshotReturns struct {
+		result1 db.BuildReport
+		result2 error
+	}
+	snapshotReturnsOnCall map[int]struct {
+		result1 db.BuildReport
+		result2 error
+	}
 	StartStub        func(atc.Plan) (bool, error)
 	startMutex       sync.RWMutex
 	startArgsForCall []struct {
@@ -456,6 +749,20 @@ type FakeBuild struct {
 	startTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	StartWithTimeoutStub        func(atc.Plan, time.Duration) (bool, error)
+	startWithTimeoutMutex       sync.RWMutex
+	startWithTimeoutArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 time.Duration
+	}
+	startWithTimeoutReturns struct {
+		result1 bool
+		result2 error
+	}
+	startWithTimeoutReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	StatusStub        func() db.BuildStatus
 	statusMutex       sync.RWMutex
 	statusArgsForCall []struct {
@@ -466,6 +773,19 @@ type FakeBuild struct {
 	statusReturnsOnCall map[int]struct {
 		result1 db.BuildStatus
 	}
+	TailEventsStub        func(uint) ([]event.Envelope, error)
+	tailEventsMutex       sync.RWMutex
+	tailEventsArgsForCall []struct {
+		arg1 uint
+	}
+	tailEventsReturns struct {
+		result1 []event.Envelope
+		result2 error
+	}
+	tailEventsReturnsOnCall map[int]struct {
+		result1 []event.Envelope
+		result2 error
+	}
 	TeamIDStub        func() int
 	teamIDMutex       sync.RWMutex
 	teamIDArgsForCall []struct {
@@ -486,6 +806,16 @@ type FakeBuild struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TriggerSourceStub        func() db.BuildTrigger
+	triggerSourceMutex       sync.RWMutex
+	triggerSourceArgsForCall []struct {
+	}
+	triggerSourceReturns struct {
+		result1 db.BuildTrigger
+	}
+	triggerSourceReturnsOnCall map[int]struct {
+		result1 db.BuildTrigger
+	}
 	UseInputsStub        func([]db.BuildInput) error
 	useInputsMutex       sync.RWMutex
 	useInputsArgsForCall []struct {
@@ -497,6 +827,32 @@ type FakeBuild struct {
 	useInputsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WriteEventsJSONStub        func(io.Writer, uint, bool) error
+	writeEventsJSONMutex       sync.RWMutex
+	writeEventsJSONArgsForCall []struct {
+		arg1 io.Writer
+		arg2 uint
+		arg3 bool
+	}
+	writeEventsJSONReturns struct {
+		result1 error
+	}
+	writeEventsJSONReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WriteSSEStub        func(context.Context, io.Writer, uint) error
+	writeSSEMutex       sync.RWMutex
+	writeSSEArgsForCall []struct {
+		arg1 context.Context
+		arg2 io.Writer
+		arg3 uint
+	}
+	writeSSEReturns struct {
+		result1 error
+	}
+	writeSSEReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -556,6 +912,118 @@ func (fake *FakeBuild) AbortNotifierReturnsOnCall(i int, result1 db.Notifier, re
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) AbortReason() string {
+	fake.abortReasonMutex.Lock()
+	ret, specificReturn := fake.abortReasonReturnsOnCall[len(fake.abortReasonArgsForCall)]
+	fake.abortReasonArgsForCall = append(fake.abortReasonArgsForCall, struct {
+	}{})
+	fake.recordInvocation("AbortReason", []interface{}{})
+	fake.abortReasonMutex.Unlock()
+	if fake.AbortReasonStub != nil {
+		return fake.AbortReasonStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.abortReasonReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) AbortReasonCallCount() int {
+	fake.abortReasonMutex.RLock()
+	defer fake.abortReasonMutex.RUnlock()
+	return len(fake.abortReasonArgsForCall)
+}
+
+func (fake *FakeBuild) AbortReasonCalls(stub func() string) {
+	fake.abortReasonMutex.Lock()
+	defer fake.abortReasonMutex.Unlock()
+	fake.AbortReasonStub = stub
+}
+
+func (fake *FakeBuild) AbortReasonReturns(result1 string) {
+	fake.abortReasonMutex.Lock()
+	defer fake.abortReasonMutex.Unlock()
+	fake.AbortReasonStub = nil
+	fake.abortReasonReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortReasonReturnsOnCall(i int, result1 string) {
+	fake.abortReasonMutex.Lock()
+	defer fake.abortReasonMutex.Unlock()
+	fake.AbortReasonStub = nil
+	if fake.abortReasonReturnsOnCall == nil {
+		fake.abortReasonReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.abortReasonReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortWithReason(arg1 string) error {
+	fake.abortWithReasonMutex.Lock()
+	ret, specificReturn := fake.abortWithReasonReturnsOnCall[len(fake.abortWithReasonArgsForCall)]
+	fake.abortWithReasonArgsForCall = append(fake.abortWithReasonArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("AbortWithReason", []interface{}{arg1})
+	fake.abortWithReasonMutex.Unlock()
+	if fake.AbortWithReasonStub != nil {
+		return fake.AbortWithReasonStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.abortWithReasonReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) AbortWithReasonCallCount() int {
+	fake.abortWithReasonMutex.RLock()
+	defer fake.abortWithReasonMutex.RUnlock()
+	return len(fake.abortWithReasonArgsForCall)
+}
+
+func (fake *FakeBuild) AbortWithReasonCalls(stub func(string) error) {
+	fake.abortWithReasonMutex.Lock()
+	defer fake.abortWithReasonMutex.Unlock()
+	fake.AbortWithReasonStub = stub
+}
+
+func (fake *FakeBuild) AbortWithReasonArgsForCall(i int) string {
+	fake.abortWithReasonMutex.RLock()
+	defer fake.abortWithReasonMutex.RUnlock()
+	argsForCall := fake.abortWithReasonArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) AbortWithReasonReturns(result1 error) {
+	fake.abortWithReasonMutex.Lock()
+	defer fake.abortWithReasonMutex.Unlock()
+	fake.AbortWithReasonStub = nil
+	fake.abortWithReasonReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortWithReasonReturnsOnCall(i int, result1 error) {
+	fake.abortWithReasonMutex.Lock()
+	defer fake.abortWithReasonMutex.Unlock()
+	fake.AbortWithReasonStub = nil
+	if fake.abortWithReasonReturnsOnCall == nil {
+		fake.abortWithReasonReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.abortWithReasonReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) AcquireTrackingLock(arg1 lager.Logger, arg2 time.Duration) (lock.Lock, bool, error) {
 	fake.acquireTrackingLockMutex.Lock()
 	ret, specificReturn := fake.acquireTrackingLockReturnsOnCall[len(fake.acquireTrackingLockArgsForCall)]
@@ -623,6 +1091,67 @@ func (fake *FakeBuild) AcquireTrackingLockReturnsOnCall(i int, result1 lock.Lock
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) AddComment(arg1 string, arg2 string) error {
+	fake.addCommentMutex.Lock()
+	ret, specificReturn := fake.addCommentReturnsOnCall[len(fake.addCommentArgsForCall)]
+	fake.addCommentArgsForCall = append(fake.addCommentArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("AddComment", []interface{}{arg1, arg2})
+	fake.addCommentMutex.Unlock()
+	if fake.AddCommentStub != nil {
+		return fake.AddCommentStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.addCommentReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) AddCommentCallCount() int {
+	fake.addCommentMutex.RLock()
+	defer fake.addCommentMutex.RUnlock()
+	return len(fake.addCommentArgsForCall)
+}
+
+func (fake *FakeBuild) AddCommentCalls(stub func(string, string) error) {
+	fake.addCommentMutex.Lock()
+	defer fake.addCommentMutex.Unlock()
+	fake.AddCommentStub = stub
+}
+
+func (fake *FakeBuild) AddCommentArgsForCall(i int) (string, string) {
+	fake.addCommentMutex.RLock()
+	defer fake.addCommentMutex.RUnlock()
+	argsForCall := fake.addCommentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) AddCommentReturns(result1 error) {
+	fake.addCommentMutex.Lock()
+	defer fake.addCommentMutex.Unlock()
+	fake.AddCommentStub = nil
+	fake.addCommentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) AddCommentReturnsOnCall(i int, result1 error) {
+	fake.addCommentMutex.Lock()
+	defer fake.addCommentMutex.Unlock()
+	fake.AddCommentStub = nil
+	if fake.addCommentReturnsOnCall == nil {
+		fake.addCommentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addCommentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Artifact(arg1 int) (db.WorkerArtifact, error) {
 	fake.artifactMutex.Lock()
 	ret, specificReturn := fake.artifactReturnsOnCall[len(fake.artifactArgsForCall)]
@@ -848,40 +1377,144 @@ func (fake *FakeBuild) DeleteReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *FakeBuild) EndTime() time.Time {
-	fake.endTimeMutex.Lock()
-	ret, specificReturn := fake.endTimeReturnsOnCall[len(fake.endTimeArgsForCall)]
-	fake.endTimeArgsForCall = append(fake.endTimeArgsForCall, struct {
+func (fake *FakeBuild) DeleteEvents() error {
+	fake.deleteEventsMutex.Lock()
+	ret, specificReturn := fake.deleteEventsReturnsOnCall[len(fake.deleteEventsArgsForCall)]
+	fake.deleteEventsArgsForCall = append(fake.deleteEventsArgsForCall, struct {
 	}{})
-	fake.recordInvocation("EndTime", []interface{}{})
-	fake.endTimeMutex.Unlock()
-	if fake.EndTimeStub != nil {
-		return fake.EndTimeStub()
+	fake.recordInvocation("DeleteEvents", []interface{}{})
+	fake.deleteEventsMutex.Unlock()
+	if fake.DeleteEventsStub != nil {
+		return fake.DeleteEventsStub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.endTimeReturns
+	fakeReturns := fake.deleteEventsReturns
 	return fakeReturns.result1
 }
 
-func (fake *FakeBuild) EndTimeCallCount() int {
-	fake.endTimeMutex.RLock()
-	defer fake.endTimeMutex.RUnlock()
-	return len(fake.endTimeArgsForCall)
+func (fake *FakeBuild) DeleteEventsCallCount() int {
+	fake.deleteEventsMutex.RLock()
+	defer fake.deleteEventsMutex.RUnlock()
+	return len(fake.deleteEventsArgsForCall)
 }
 
-func (fake *FakeBuild) EndTimeCalls(stub func() time.Time) {
-	fake.endTimeMutex.Lock()
-	defer fake.endTimeMutex.Unlock()
-	fake.EndTimeStub = stub
+func (fake *FakeBuild) DeleteEventsCalls(stub func() error) {
+	fake.deleteEventsMutex.Lock()
+	defer fake.deleteEventsMutex.Unlock()
+	fake.DeleteEventsStub = stub
 }
 
-func (fake *FakeBuild) EndTimeReturns(result1 time.Time) {
-	fake.endTimeMutex.Lock()
-	defer fake.endTimeMutex.Unlock()
-	fake.EndTimeStub = nil
-	fake.endTimeReturns = struct {
+func (fake *FakeBuild) DeleteEventsReturns(result1 error) {
+	fake.deleteEventsMutex.Lock()
+	defer fake.deleteEventsMutex.Unlock()
+	fake.DeleteEventsStub = nil
+	fake.deleteEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) DeleteEventsReturnsOnCall(i int, result1 error) {
+	fake.deleteEventsMutex.Lock()
+	defer fake.deleteEventsMutex.Unlock()
+	fake.DeleteEventsStub = nil
+	if fake.deleteEventsReturnsOnCall == nil {
+		fake.deleteEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) Duration() time.Duration {
+	fake.durationMutex.Lock()
+	ret, specificReturn := fake.durationReturnsOnCall[len(fake.durationArgsForCall)]
+	fake.durationArgsForCall = append(fake.durationArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Duration", []interface{}{})
+	fake.durationMutex.Unlock()
+	if fake.DurationStub != nil {
+		return fake.DurationStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.durationReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) DurationCallCount() int {
+	fake.durationMutex.RLock()
+	defer fake.durationMutex.RUnlock()
+	return len(fake.durationArgsForCall)
+}
+
+func (fake *FakeBuild) DurationCalls(stub func() time.Duration) {
+	fake.durationMutex.Lock()
+	defer fake.durationMutex.Unlock()
+	fake.DurationStub = stub
+}
+
+func (fake *FakeBuild) DurationReturns(result1 time.Duration) {
+	fake.durationMutex.Lock()
+	defer fake.durationMutex.Unlock()
+	fake.DurationStub = nil
+	fake.durationReturns = struct {
+		result1 time.Duration
+	}{result1}
+}
+
+func (fake *FakeBuild) DurationReturnsOnCall(i int, result1 time.Duration) {
+	fake.durationMutex.Lock()
+	defer fake.durationMutex.Unlock()
+	fake.DurationStub = nil
+	if fake.durationReturnsOnCall == nil {
+		fake.durationReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+		})
+	}
+	fake.durationReturnsOnCall[i] = struct {
+		result1 time.Duration
+	}{result1}
+}
+
+func (fake *FakeBuild) EndTime() time.Time {
+	fake.endTimeMutex.Lock()
+	ret, specificReturn := fake.endTimeReturnsOnCall[len(fake.endTimeArgsForCall)]
+	fake.endTimeArgsForCall = append(fake.endTimeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("EndTime", []interface{}{})
+	fake.endTimeMutex.Unlock()
+	if fake.EndTimeStub != nil {
+		return fake.EndTimeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.endTimeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) EndTimeCallCount() int {
+	fake.endTimeMutex.RLock()
+	defer fake.endTimeMutex.RUnlock()
+	return len(fake.endTimeArgsForCall)
+}
+
+func (fake *FakeBuild) EndTimeCalls(stub func() time.Time) {
+	fake.endTimeMutex.Lock()
+	defer fake.endTimeMutex.Unlock()
+	fake.EndTimeStub = stub
+}
+
+func (fake *FakeBuild) EndTimeReturns(result1 time.Time) {
+	fake.endTimeMutex.Lock()
+	defer fake.endTimeMutex.Unlock()
+	fake.EndTimeStub = nil
+	fake.endTimeReturns = struct {
 		result1 time.Time
 	}{result1}
 }
@@ -900,16 +1533,124 @@ func (fake *FakeBuild) EndTimeReturnsOnCall(i int, result1 time.Time) {
 	}{result1}
 }
 
-func (fake *FakeBuild) Events(arg1 uint) (db.EventSource, error) {
+func (fake *FakeBuild) ErrorCategory() db.ErrorCategory {
+	fake.errorCategoryMutex.Lock()
+	ret, specificReturn := fake.errorCategoryReturnsOnCall[len(fake.errorCategoryArgsForCall)]
+	fake.errorCategoryArgsForCall = append(fake.errorCategoryArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ErrorCategory", []interface{}{})
+	fake.errorCategoryMutex.Unlock()
+	if fake.ErrorCategoryStub != nil {
+		return fake.ErrorCategoryStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.errorCategoryReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ErrorCategoryCallCount() int {
+	fake.errorCategoryMutex.RLock()
+	defer fake.errorCategoryMutex.RUnlock()
+	return len(fake.errorCategoryArgsForCall)
+}
+
+func (fake *FakeBuild) ErrorCategoryCalls(stub func() db.ErrorCategory) {
+	fake.errorCategoryMutex.Lock()
+	defer fake.errorCategoryMutex.Unlock()
+	fake.ErrorCategoryStub = stub
+}
+
+func (fake *FakeBuild) ErrorCategoryReturns(result1 db.ErrorCategory) {
+	fake.errorCategoryMutex.Lock()
+	defer fake.errorCategoryMutex.Unlock()
+	fake.ErrorCategoryStub = nil
+	fake.errorCategoryReturns = struct {
+		result1 db.ErrorCategory
+	}{result1}
+}
+
+func (fake *FakeBuild) ErrorCategoryReturnsOnCall(i int, result1 db.ErrorCategory) {
+	fake.errorCategoryMutex.Lock()
+	defer fake.errorCategoryMutex.Unlock()
+	fake.ErrorCategoryStub = nil
+	if fake.errorCategoryReturnsOnCall == nil {
+		fake.errorCategoryReturnsOnCall = make(map[int]struct {
+			result1 db.ErrorCategory
+		})
+	}
+	fake.errorCategoryReturnsOnCall[i] = struct {
+		result1 db.ErrorCategory
+	}{result1}
+}
+
+func (fake *FakeBuild) EventCount() (uint, error) {
+	fake.eventCountMutex.Lock()
+	ret, specificReturn := fake.eventCountReturnsOnCall[len(fake.eventCountArgsForCall)]
+	fake.eventCountArgsForCall = append(fake.eventCountArgsForCall, struct {
+	}{})
+	fake.recordInvocation("EventCount", []interface{}{})
+	fake.eventCountMutex.Unlock()
+	if fake.EventCountStub != nil {
+		return fake.EventCountStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.eventCountReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) EventCountCallCount() int {
+	fake.eventCountMutex.RLock()
+	defer fake.eventCountMutex.RUnlock()
+	return len(fake.eventCountArgsForCall)
+}
+
+func (fake *FakeBuild) EventCountCalls(stub func() (uint, error)) {
+	fake.eventCountMutex.Lock()
+	defer fake.eventCountMutex.Unlock()
+	fake.EventCountStub = stub
+}
+
+func (fake *FakeBuild) EventCountReturns(result1 uint, result2 error) {
+	fake.eventCountMutex.Lock()
+	defer fake.eventCountMutex.Unlock()
+	fake.EventCountStub = nil
+	fake.eventCountReturns = struct {
+		result1 uint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EventCountReturnsOnCall(i int, result1 uint, result2 error) {
+	fake.eventCountMutex.Lock()
+	defer fake.eventCountMutex.Unlock()
+	fake.EventCountStub = nil
+	if fake.eventCountReturnsOnCall == nil {
+		fake.eventCountReturnsOnCall = make(map[int]struct {
+			result1 uint
+			result2 error
+		})
+	}
+	fake.eventCountReturnsOnCall[i] = struct {
+		result1 uint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) Events(arg1 uint, arg2 ...db.EventsOption) (db.EventSource, error) {
 	fake.eventsMutex.Lock()
 	ret, specificReturn := fake.eventsReturnsOnCall[len(fake.eventsArgsForCall)]
 	fake.eventsArgsForCall = append(fake.eventsArgsForCall, struct {
 		arg1 uint
-	}{arg1})
-	fake.recordInvocation("Events", []interface{}{arg1})
+		arg2 []db.EventsOption
+	}{arg1, arg2})
+	fake.recordInvocation("Events", []interface{}{arg1, arg2})
 	fake.eventsMutex.Unlock()
 	if fake.EventsStub != nil {
-		return fake.EventsStub(arg1)
+		return fake.EventsStub(arg1, arg2...)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -924,17 +1665,17 @@ func (fake *FakeBuild) EventsCallCount() int {
 	return len(fake.eventsArgsForCall)
 }
 
-func (fake *FakeBuild) EventsCalls(stub func(uint) (db.EventSource, error)) {
+func (fake *FakeBuild) EventsCalls(stub func(uint, ...db.EventsOption) (db.EventSource, error)) {
 	fake.eventsMutex.Lock()
 	defer fake.eventsMutex.Unlock()
 	fake.EventsStub = stub
 }
 
-func (fake *FakeBuild) EventsArgsForCall(i int) uint {
+func (fake *FakeBuild) EventsArgsForCall(i int) (uint, []db.EventsOption) {
 	fake.eventsMutex.RLock()
 	defer fake.eventsMutex.RUnlock()
 	argsForCall := fake.eventsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeBuild) EventsReturns(result1 db.EventSource, result2 error) {
@@ -963,6 +1704,73 @@ func (fake *FakeBuild) EventsReturnsOnCall(i int, result1 db.EventSource, result
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) EventsPage(arg1 uint, arg2 uint) ([]event.Envelope, uint, error) {
+	fake.eventsPageMutex.Lock()
+	ret, specificReturn := fake.eventsPageReturnsOnCall[len(fake.eventsPageArgsForCall)]
+	fake.eventsPageArgsForCall = append(fake.eventsPageArgsForCall, struct {
+		arg1 uint
+		arg2 uint
+	}{arg1, arg2})
+	fake.recordInvocation("EventsPage", []interface{}{arg1, arg2})
+	fake.eventsPageMutex.Unlock()
+	if fake.EventsPageStub != nil {
+		return fake.EventsPageStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.eventsPageReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) EventsPageCallCount() int {
+	fake.eventsPageMutex.RLock()
+	defer fake.eventsPageMutex.RUnlock()
+	return len(fake.eventsPageArgsForCall)
+}
+
+func (fake *FakeBuild) EventsPageCalls(stub func(uint, uint) ([]event.Envelope, uint, error)) {
+	fake.eventsPageMutex.Lock()
+	defer fake.eventsPageMutex.Unlock()
+	fake.EventsPageStub = stub
+}
+
+func (fake *FakeBuild) EventsPageArgsForCall(i int) (uint, uint) {
+	fake.eventsPageMutex.RLock()
+	defer fake.eventsPageMutex.RUnlock()
+	argsForCall := fake.eventsPageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) EventsPageReturns(result1 []event.Envelope, result2 uint, result3 error) {
+	fake.eventsPageMutex.Lock()
+	defer fake.eventsPageMutex.Unlock()
+	fake.EventsPageStub = nil
+	fake.eventsPageReturns = struct {
+		result1 []event.Envelope
+		result2 uint
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) EventsPageReturnsOnCall(i int, result1 []event.Envelope, result2 uint, result3 error) {
+	fake.eventsPageMutex.Lock()
+	defer fake.eventsPageMutex.Unlock()
+	fake.EventsPageStub = nil
+	if fake.eventsPageReturnsOnCall == nil {
+		fake.eventsPageReturnsOnCall = make(map[int]struct {
+			result1 []event.Envelope
+			result2 uint
+			result3 error
+		})
+	}
+	fake.eventsPageReturnsOnCall[i] = struct {
+		result1 []event.Envelope
+		result2 uint
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuild) Finish(arg1 db.BuildStatus) error {
 	fake.finishMutex.Lock()
 	ret, specificReturn := fake.finishReturnsOnCall[len(fake.finishArgsForCall)]
@@ -1023,6 +1831,333 @@ func (fake *FakeBuild) FinishReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) GetComments() ([]db.BuildComment, error) {
+	fake.getCommentsMutex.Lock()
+	ret, specificReturn := fake.getCommentsReturnsOnCall[len(fake.getCommentsArgsForCall)]
+	fake.getCommentsArgsForCall = append(fake.getCommentsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetComments", []interface{}{})
+	fake.getCommentsMutex.Unlock()
+	if fake.GetCommentsStub != nil {
+		return fake.GetCommentsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getCommentsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) GetCommentsCallCount() int {
+	fake.getCommentsMutex.RLock()
+	defer fake.getCommentsMutex.RUnlock()
+	return len(fake.getCommentsArgsForCall)
+}
+
+func (fake *FakeBuild) GetCommentsCalls(stub func() ([]db.BuildComment, error)) {
+	fake.getCommentsMutex.Lock()
+	defer fake.getCommentsMutex.Unlock()
+	fake.GetCommentsStub = stub
+}
+
+func (fake *FakeBuild) GetCommentsReturns(result1 []db.BuildComment, result2 error) {
+	fake.getCommentsMutex.Lock()
+	defer fake.getCommentsMutex.Unlock()
+	fake.GetCommentsStub = nil
+	fake.getCommentsReturns = struct {
+		result1 []db.BuildComment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) GetCommentsReturnsOnCall(i int, result1 []db.BuildComment, result2 error) {
+	fake.getCommentsMutex.Lock()
+	defer fake.getCommentsMutex.Unlock()
+	fake.GetCommentsStub = nil
+	if fake.getCommentsReturnsOnCall == nil {
+		fake.getCommentsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildComment
+			result2 error
+		})
+	}
+	fake.getCommentsReturnsOnCall[i] = struct {
+		result1 []db.BuildComment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) GetInputResourceConfigVersionIDs() ([]int, error) {
+	fake.getInputResourceConfigVersionIDsMutex.Lock()
+	ret, specificReturn := fake.getInputResourceConfigVersionIDsReturnsOnCall[len(fake.getInputResourceConfigVersionIDsArgsForCall)]
+	fake.getInputResourceConfigVersionIDsArgsForCall = append(fake.getInputResourceConfigVersionIDsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetInputResourceConfigVersionIDs", []interface{}{})
+	fake.getInputResourceConfigVersionIDsMutex.Unlock()
+	if fake.GetInputResourceConfigVersionIDsStub != nil {
+		return fake.GetInputResourceConfigVersionIDsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getInputResourceConfigVersionIDsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) GetInputResourceConfigVersionIDsCallCount() int {
+	fake.getInputResourceConfigVersionIDsMutex.RLock()
+	defer fake.getInputResourceConfigVersionIDsMutex.RUnlock()
+	return len(fake.getInputResourceConfigVersionIDsArgsForCall)
+}
+
+func (fake *FakeBuild) GetInputResourceConfigVersionIDsCalls(stub func() ([]int, error)) {
+	fake.getInputResourceConfigVersionIDsMutex.Lock()
+	defer fake.getInputResourceConfigVersionIDsMutex.Unlock()
+	fake.GetInputResourceConfigVersionIDsStub = stub
+}
+
+func (fake *FakeBuild) GetInputResourceConfigVersionIDsReturns(result1 []int, result2 error) {
+	fake.getInputResourceConfigVersionIDsMutex.Lock()
+	defer fake.getInputResourceConfigVersionIDsMutex.Unlock()
+	fake.GetInputResourceConfigVersionIDsStub = nil
+	fake.getInputResourceConfigVersionIDsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) GetInputResourceConfigVersionIDsReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.getInputResourceConfigVersionIDsMutex.Lock()
+	defer fake.getInputResourceConfigVersionIDsMutex.Unlock()
+	fake.GetInputResourceConfigVersionIDsStub = nil
+	if fake.getInputResourceConfigVersionIDsReturnsOnCall == nil {
+		fake.getInputResourceConfigVersionIDsReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.getInputResourceConfigVersionIDsReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) GetInputs() ([]db.BuildInput, error) {
+	fake.getInputsMutex.Lock()
+	ret, specificReturn := fake.getInputsReturnsOnCall[len(fake.getInputsArgsForCall)]
+	fake.getInputsArgsForCall = append(fake.getInputsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetInputs", []interface{}{})
+	fake.getInputsMutex.Unlock()
+	if fake.GetInputsStub != nil {
+		return fake.GetInputsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getInputsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) GetInputsCallCount() int {
+	fake.getInputsMutex.RLock()
+	defer fake.getInputsMutex.RUnlock()
+	return len(fake.getInputsArgsForCall)
+}
+
+func (fake *FakeBuild) GetInputsCalls(stub func() ([]db.BuildInput, error)) {
+	fake.getInputsMutex.Lock()
+	defer fake.getInputsMutex.Unlock()
+	fake.GetInputsStub = stub
+}
+
+func (fake *FakeBuild) GetInputsReturns(result1 []db.BuildInput, result2 error) {
+	fake.getInputsMutex.Lock()
+	defer fake.getInputsMutex.Unlock()
+	fake.GetInputsStub = nil
+	fake.getInputsReturns = struct {
+		result1 []db.BuildInput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) GetInputsReturnsOnCall(i int, result1 []db.BuildInput, result2 error) {
+	fake.getInputsMutex.Lock()
+	defer fake.getInputsMutex.Unlock()
+	fake.GetInputsStub = nil
+	if fake.getInputsReturnsOnCall == nil {
+		fake.getInputsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildInput
+			result2 error
+		})
+	}
+	fake.getInputsReturnsOnCall[i] = struct {
+		result1 []db.BuildInput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) GetOutputs() ([]db.BuildOutput, error) {
+	fake.getOutputsMutex.Lock()
+	ret, specificReturn := fake.getOutputsReturnsOnCall[len(fake.getOutputsArgsForCall)]
+	fake.getOutputsArgsForCall = append(fake.getOutputsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetOutputs", []interface{}{})
+	fake.getOutputsMutex.Unlock()
+	if fake.GetOutputsStub != nil {
+		return fake.GetOutputsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getOutputsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) GetOutputsCallCount() int {
+	fake.getOutputsMutex.RLock()
+	defer fake.getOutputsMutex.RUnlock()
+	return len(fake.getOutputsArgsForCall)
+}
+
+func (fake *FakeBuild) GetOutputsCalls(stub func() ([]db.BuildOutput, error)) {
+	fake.getOutputsMutex.Lock()
+	defer fake.getOutputsMutex.Unlock()
+	fake.GetOutputsStub = stub
+}
+
+func (fake *FakeBuild) GetOutputsReturns(result1 []db.BuildOutput, result2 error) {
+	fake.getOutputsMutex.Lock()
+	defer fake.getOutputsMutex.Unlock()
+	fake.GetOutputsStub = nil
+	fake.getOutputsReturns = struct {
+		result1 []db.BuildOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) GetOutputsReturnsOnCall(i int, result1 []db.BuildOutput, result2 error) {
+	fake.getOutputsMutex.Lock()
+	defer fake.getOutputsMutex.Unlock()
+	fake.GetOutputsStub = nil
+	if fake.getOutputsReturnsOnCall == nil {
+		fake.getOutputsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildOutput
+			result2 error
+		})
+	}
+	fake.getOutputsReturnsOnCall[i] = struct {
+		result1 []db.BuildOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) GetResourceMetadata() (map[string]map[string]string, error) {
+	fake.getResourceMetadataMutex.Lock()
+	ret, specificReturn := fake.getResourceMetadataReturnsOnCall[len(fake.getResourceMetadataArgsForCall)]
+	fake.getResourceMetadataArgsForCall = append(fake.getResourceMetadataArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetResourceMetadata", []interface{}{})
+	fake.getResourceMetadataMutex.Unlock()
+	if fake.GetResourceMetadataStub != nil {
+		return fake.GetResourceMetadataStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getResourceMetadataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) GetResourceMetadataCallCount() int {
+	fake.getResourceMetadataMutex.RLock()
+	defer fake.getResourceMetadataMutex.RUnlock()
+	return len(fake.getResourceMetadataArgsForCall)
+}
+
+func (fake *FakeBuild) GetResourceMetadataCalls(stub func() (map[string]map[string]string, error)) {
+	fake.getResourceMetadataMutex.Lock()
+	defer fake.getResourceMetadataMutex.Unlock()
+	fake.GetResourceMetadataStub = stub
+}
+
+func (fake *FakeBuild) GetResourceMetadataReturns(result1 map[string]map[string]string, result2 error) {
+	fake.getResourceMetadataMutex.Lock()
+	defer fake.getResourceMetadataMutex.Unlock()
+	fake.GetResourceMetadataStub = nil
+	fake.getResourceMetadataReturns = struct {
+		result1 map[string]map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) GetResourceMetadataReturnsOnCall(i int, result1 map[string]map[string]string, result2 error) {
+	fake.getResourceMetadataMutex.Lock()
+	defer fake.getResourceMetadataMutex.Unlock()
+	fake.GetResourceMetadataStub = nil
+	if fake.getResourceMetadataReturnsOnCall == nil {
+		fake.getResourceMetadataReturnsOnCall = make(map[int]struct {
+			result1 map[string]map[string]string
+			result2 error
+		})
+	}
+	fake.getResourceMetadataReturnsOnCall[i] = struct {
+		result1 map[string]map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) Heartbeat() error {
+	fake.heartbeatMutex.Lock()
+	ret, specificReturn := fake.heartbeatReturnsOnCall[len(fake.heartbeatArgsForCall)]
+	fake.heartbeatArgsForCall = append(fake.heartbeatArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Heartbeat", []interface{}{})
+	fake.heartbeatMutex.Unlock()
+	if fake.HeartbeatStub != nil {
+		return fake.HeartbeatStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.heartbeatReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) HeartbeatCallCount() int {
+	fake.heartbeatMutex.RLock()
+	defer fake.heartbeatMutex.RUnlock()
+	return len(fake.heartbeatArgsForCall)
+}
+
+func (fake *FakeBuild) HeartbeatCalls(stub func() error) {
+	fake.heartbeatMutex.Lock()
+	defer fake.heartbeatMutex.Unlock()
+	fake.HeartbeatStub = stub
+}
+
+func (fake *FakeBuild) HeartbeatReturns(result1 error) {
+	fake.heartbeatMutex.Lock()
+	defer fake.heartbeatMutex.Unlock()
+	fake.HeartbeatStub = nil
+	fake.heartbeatReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) HeartbeatReturnsOnCall(i int, result1 error) {
+	fake.heartbeatMutex.Lock()
+	defer fake.heartbeatMutex.Unlock()
+	fake.HeartbeatStub = nil
+	if fake.heartbeatReturnsOnCall == nil {
+		fake.heartbeatReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.heartbeatReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) ID() int {
 	fake.iDMutex.Lock()
 	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
@@ -1442,6 +2577,69 @@ func (fake *FakeBuild) IsScheduledReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeBuild) IsStale(arg1 time.Duration) (bool, error) {
+	fake.isStaleMutex.Lock()
+	ret, specificReturn := fake.isStaleReturnsOnCall[len(fake.isStaleArgsForCall)]
+	fake.isStaleArgsForCall = append(fake.isStaleArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("IsStale", []interface{}{arg1})
+	fake.isStaleMutex.Unlock()
+	if fake.IsStaleStub != nil {
+		return fake.IsStaleStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.isStaleReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) IsStaleCallCount() int {
+	fake.isStaleMutex.RLock()
+	defer fake.isStaleMutex.RUnlock()
+	return len(fake.isStaleArgsForCall)
+}
+
+func (fake *FakeBuild) IsStaleCalls(stub func(time.Duration) (bool, error)) {
+	fake.isStaleMutex.Lock()
+	defer fake.isStaleMutex.Unlock()
+	fake.IsStaleStub = stub
+}
+
+func (fake *FakeBuild) IsStaleArgsForCall(i int) time.Duration {
+	fake.isStaleMutex.RLock()
+	defer fake.isStaleMutex.RUnlock()
+	argsForCall := fake.isStaleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) IsStaleReturns(result1 bool, result2 error) {
+	fake.isStaleMutex.Lock()
+	defer fake.isStaleMutex.Unlock()
+	fake.IsStaleStub = nil
+	fake.isStaleReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) IsStaleReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.isStaleMutex.Lock()
+	defer fake.isStaleMutex.Unlock()
+	fake.IsStaleStub = nil
+	if fake.isStaleReturnsOnCall == nil {
+		fake.isStaleReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.isStaleReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) JobID() int {
 	fake.jobIDMutex.Lock()
 	ret, specificReturn := fake.jobIDReturnsOnCall[len(fake.jobIDArgsForCall)]
@@ -1546,6 +2744,116 @@ func (fake *FakeBuild) JobNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) Labels() map[string]string {
+	fake.labelsMutex.Lock()
+	ret, specificReturn := fake.labelsReturnsOnCall[len(fake.labelsArgsForCall)]
+	fake.labelsArgsForCall = append(fake.labelsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Labels", []interface{}{})
+	fake.labelsMutex.Unlock()
+	if fake.LabelsStub != nil {
+		return fake.LabelsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.labelsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) LabelsCallCount() int {
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
+	return len(fake.labelsArgsForCall)
+}
+
+func (fake *FakeBuild) LabelsCalls(stub func() map[string]string) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = stub
+}
+
+func (fake *FakeBuild) LabelsReturns(result1 map[string]string) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = nil
+	fake.labelsReturns = struct {
+		result1 map[string]string
+	}{result1}
+}
+
+func (fake *FakeBuild) LabelsReturnsOnCall(i int, result1 map[string]string) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = nil
+	if fake.labelsReturnsOnCall == nil {
+		fake.labelsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+		})
+	}
+	fake.labelsReturnsOnCall[i] = struct {
+		result1 map[string]string
+	}{result1}
+}
+
+func (fake *FakeBuild) LastEventTime() (time.Time, bool, error) {
+	fake.lastEventTimeMutex.Lock()
+	ret, specificReturn := fake.lastEventTimeReturnsOnCall[len(fake.lastEventTimeArgsForCall)]
+	fake.lastEventTimeArgsForCall = append(fake.lastEventTimeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("LastEventTime", []interface{}{})
+	fake.lastEventTimeMutex.Unlock()
+	if fake.LastEventTimeStub != nil {
+		return fake.LastEventTimeStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.lastEventTimeReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) LastEventTimeCallCount() int {
+	fake.lastEventTimeMutex.RLock()
+	defer fake.lastEventTimeMutex.RUnlock()
+	return len(fake.lastEventTimeArgsForCall)
+}
+
+func (fake *FakeBuild) LastEventTimeCalls(stub func() (time.Time, bool, error)) {
+	fake.lastEventTimeMutex.Lock()
+	defer fake.lastEventTimeMutex.Unlock()
+	fake.LastEventTimeStub = stub
+}
+
+func (fake *FakeBuild) LastEventTimeReturns(result1 time.Time, result2 bool, result3 error) {
+	fake.lastEventTimeMutex.Lock()
+	defer fake.lastEventTimeMutex.Unlock()
+	fake.LastEventTimeStub = nil
+	fake.lastEventTimeReturns = struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) LastEventTimeReturnsOnCall(i int, result1 time.Time, result2 bool, result3 error) {
+	fake.lastEventTimeMutex.Lock()
+	defer fake.lastEventTimeMutex.Unlock()
+	fake.LastEventTimeStub = nil
+	if fake.lastEventTimeReturnsOnCall == nil {
+		fake.lastEventTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+			result2 bool
+			result3 error
+		})
+	}
+	fake.lastEventTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuild) MarkAsAborted() error {
 	fake.markAsAbortedMutex.Lock()
 	ret, specificReturn := fake.markAsAbortedReturnsOnCall[len(fake.markAsAbortedArgsForCall)]
@@ -1598,6 +2906,67 @@ func (fake *FakeBuild) MarkAsAbortedReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) MarkAsErrored(arg1 error, arg2 db.ErrorCategory) error {
+	fake.markAsErroredMutex.Lock()
+	ret, specificReturn := fake.markAsErroredReturnsOnCall[len(fake.markAsErroredArgsForCall)]
+	fake.markAsErroredArgsForCall = append(fake.markAsErroredArgsForCall, struct {
+		arg1 error
+		arg2 db.ErrorCategory
+	}{arg1, arg2})
+	fake.recordInvocation("MarkAsErrored", []interface{}{arg1, arg2})
+	fake.markAsErroredMutex.Unlock()
+	if fake.MarkAsErroredStub != nil {
+		return fake.MarkAsErroredStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.markAsErroredReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) MarkAsErroredCallCount() int {
+	fake.markAsErroredMutex.RLock()
+	defer fake.markAsErroredMutex.RUnlock()
+	return len(fake.markAsErroredArgsForCall)
+}
+
+func (fake *FakeBuild) MarkAsErroredCalls(stub func(error, db.ErrorCategory) error) {
+	fake.markAsErroredMutex.Lock()
+	defer fake.markAsErroredMutex.Unlock()
+	fake.MarkAsErroredStub = stub
+}
+
+func (fake *FakeBuild) MarkAsErroredArgsForCall(i int) (error, db.ErrorCategory) {
+	fake.markAsErroredMutex.RLock()
+	defer fake.markAsErroredMutex.RUnlock()
+	argsForCall := fake.markAsErroredArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) MarkAsErroredReturns(result1 error) {
+	fake.markAsErroredMutex.Lock()
+	defer fake.markAsErroredMutex.Unlock()
+	fake.MarkAsErroredStub = nil
+	fake.markAsErroredReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) MarkAsErroredReturnsOnCall(i int, result1 error) {
+	fake.markAsErroredMutex.Lock()
+	defer fake.markAsErroredMutex.Unlock()
+	fake.MarkAsErroredStub = nil
+	if fake.markAsErroredReturnsOnCall == nil {
+		fake.markAsErroredReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markAsErroredReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -1650,6 +3019,58 @@ func (fake *FakeBuild) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) OverridesPause() bool {
+	fake.overridesPauseMutex.Lock()
+	ret, specificReturn := fake.overridesPauseReturnsOnCall[len(fake.overridesPauseArgsForCall)]
+	fake.overridesPauseArgsForCall = append(fake.overridesPauseArgsForCall, struct {
+	}{})
+	fake.recordInvocation("OverridesPause", []interface{}{})
+	fake.overridesPauseMutex.Unlock()
+	if fake.OverridesPauseStub != nil {
+		return fake.OverridesPauseStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.overridesPauseReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) OverridesPauseCallCount() int {
+	fake.overridesPauseMutex.RLock()
+	defer fake.overridesPauseMutex.RUnlock()
+	return len(fake.overridesPauseArgsForCall)
+}
+
+func (fake *FakeBuild) OverridesPauseCalls(stub func() bool) {
+	fake.overridesPauseMutex.Lock()
+	defer fake.overridesPauseMutex.Unlock()
+	fake.OverridesPauseStub = stub
+}
+
+func (fake *FakeBuild) OverridesPauseReturns(result1 bool) {
+	fake.overridesPauseMutex.Lock()
+	defer fake.overridesPauseMutex.Unlock()
+	fake.OverridesPauseStub = nil
+	fake.overridesPauseReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) OverridesPauseReturnsOnCall(i int, result1 bool) {
+	fake.overridesPauseMutex.Lock()
+	defer fake.overridesPauseMutex.Unlock()
+	fake.OverridesPauseStub = nil
+	if fake.overridesPauseReturnsOnCall == nil {
+		fake.overridesPauseReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.overridesPauseReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) Pipeline() (db.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
@@ -2081,6 +3502,58 @@ func (fake *FakeBuild) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) RerunOf() int {
+	fake.rerunOfMutex.Lock()
+	ret, specificReturn := fake.rerunOfReturnsOnCall[len(fake.rerunOfArgsForCall)]
+	fake.rerunOfArgsForCall = append(fake.rerunOfArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RerunOf", []interface{}{})
+	fake.rerunOfMutex.Unlock()
+	if fake.RerunOfStub != nil {
+		return fake.RerunOfStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.rerunOfReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) RerunOfCallCount() int {
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	return len(fake.rerunOfArgsForCall)
+}
+
+func (fake *FakeBuild) RerunOfCalls(stub func() int) {
+	fake.rerunOfMutex.Lock()
+	defer fake.rerunOfMutex.Unlock()
+	fake.RerunOfStub = stub
+}
+
+func (fake *FakeBuild) RerunOfReturns(result1 int) {
+	fake.rerunOfMutex.Lock()
+	defer fake.rerunOfMutex.Unlock()
+	fake.RerunOfStub = nil
+	fake.rerunOfReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOfReturnsOnCall(i int, result1 int) {
+	fake.rerunOfMutex.Lock()
+	defer fake.rerunOfMutex.Unlock()
+	fake.RerunOfStub = nil
+	if fake.rerunOfReturnsOnCall == nil {
+		fake.rerunOfReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.rerunOfReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) Resources() ([]db.BuildInput, []db.BuildOutput, error) {
 	fake.resourcesMutex.Lock()
 	ret, specificReturn := fake.resourcesReturnsOnCall[len(fake.resourcesArgsForCall)]
@@ -2132,69 +3605,195 @@ func (fake *FakeBuild) ResourcesReturnsOnCall(i int, result1 []db.BuildInput, re
 			result3 error
 		})
 	}
-	fake.resourcesReturnsOnCall[i] = struct {
-		result1 []db.BuildInput
-		result2 []db.BuildOutput
-		result3 error
-	}{result1, result2, result3}
+	fake.resourcesReturnsOnCall[i] = struct {
+		result1 []db.BuildInput
+		result2 []db.BuildOutput
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) SaveEvent(arg1 atc.Event) error {
+	fake.saveEventMutex.Lock()
+	ret, specificReturn := fake.saveEventReturnsOnCall[len(fake.saveEventArgsForCall)]
+	fake.saveEventArgsForCall = append(fake.saveEventArgsForCall, struct {
+		arg1 atc.Event
+	}{arg1})
+	fake.recordInvocation("SaveEvent", []interface{}{arg1})
+	fake.saveEventMutex.Unlock()
+	if fake.SaveEventStub != nil {
+		return fake.SaveEventStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveEventReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveEventCallCount() int {
+	fake.saveEventMutex.RLock()
+	defer fake.saveEventMutex.RUnlock()
+	return len(fake.saveEventArgsForCall)
+}
+
+func (fake *FakeBuild) SaveEventCalls(stub func(atc.Event) error) {
+	fake.saveEventMutex.Lock()
+	defer fake.saveEventMutex.Unlock()
+	fake.SaveEventStub = stub
+}
+
+func (fake *FakeBuild) SaveEventArgsForCall(i int) atc.Event {
+	fake.saveEventMutex.RLock()
+	defer fake.saveEventMutex.RUnlock()
+	argsForCall := fake.saveEventArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveEventReturns(result1 error) {
+	fake.saveEventMutex.Lock()
+	defer fake.saveEventMutex.Unlock()
+	fake.SaveEventStub = nil
+	fake.saveEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveEventReturnsOnCall(i int, result1 error) {
+	fake.saveEventMutex.Lock()
+	defer fake.saveEventMutex.Unlock()
+	fake.SaveEventStub = nil
+	if fake.saveEventReturnsOnCall == nil {
+		fake.saveEventReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveEventReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveEventAndFinish(arg1 atc.Event, arg2 db.BuildStatus) error {
+	fake.saveEventAndFinishMutex.Lock()
+	ret, specificReturn := fake.saveEventAndFinishReturnsOnCall[len(fake.saveEventAndFinishArgsForCall)]
+	fake.saveEventAndFinishArgsForCall = append(fake.saveEventAndFinishArgsForCall, struct {
+		arg1 atc.Event
+		arg2 db.BuildStatus
+	}{arg1, arg2})
+	fake.recordInvocation("SaveEventAndFinish", []interface{}{arg1, arg2})
+	fake.saveEventAndFinishMutex.Unlock()
+	if fake.SaveEventAndFinishStub != nil {
+		return fake.SaveEventAndFinishStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveEventAndFinishReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveEventAndFinishCallCount() int {
+	fake.saveEventAndFinishMutex.RLock()
+	defer fake.saveEventAndFinishMutex.RUnlock()
+	return len(fake.saveEventAndFinishArgsForCall)
+}
+
+func (fake *FakeBuild) SaveEventAndFinishCalls(stub func(atc.Event, db.BuildStatus) error) {
+	fake.saveEventAndFinishMutex.Lock()
+	defer fake.saveEventAndFinishMutex.Unlock()
+	fake.SaveEventAndFinishStub = stub
+}
+
+func (fake *FakeBuild) SaveEventAndFinishArgsForCall(i int) (atc.Event, db.BuildStatus) {
+	fake.saveEventAndFinishMutex.RLock()
+	defer fake.saveEventAndFinishMutex.RUnlock()
+	argsForCall := fake.saveEventAndFinishArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) SaveEventAndFinishReturns(result1 error) {
+	fake.saveEventAndFinishMutex.Lock()
+	defer fake.saveEventAndFinishMutex.Unlock()
+	fake.SaveEventAndFinishStub = nil
+	fake.saveEventAndFinishReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveEventAndFinishReturnsOnCall(i int, result1 error) {
+	fake.saveEventAndFinishMutex.Lock()
+	defer fake.saveEventAndFinishMutex.Unlock()
+	fake.SaveEventAndFinishStub = nil
+	if fake.saveEventAndFinishReturnsOnCall == nil {
+		fake.saveEventAndFinishReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveEventAndFinishReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *FakeBuild) SaveEvent(arg1 atc.Event) error {
-	fake.saveEventMutex.Lock()
-	ret, specificReturn := fake.saveEventReturnsOnCall[len(fake.saveEventArgsForCall)]
-	fake.saveEventArgsForCall = append(fake.saveEventArgsForCall, struct {
-		arg1 atc.Event
-	}{arg1})
-	fake.recordInvocation("SaveEvent", []interface{}{arg1})
-	fake.saveEventMutex.Unlock()
-	if fake.SaveEventStub != nil {
-		return fake.SaveEventStub(arg1)
+func (fake *FakeBuild) SaveEvents(arg1 []atc.Event) error {
+	var arg1Copy []atc.Event
+	if arg1 != nil {
+		arg1Copy = make([]atc.Event, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.saveEventsMutex.Lock()
+	ret, specificReturn := fake.saveEventsReturnsOnCall[len(fake.saveEventsArgsForCall)]
+	fake.saveEventsArgsForCall = append(fake.saveEventsArgsForCall, struct {
+		arg1 []atc.Event
+	}{arg1Copy})
+	fake.recordInvocation("SaveEvents", []interface{}{arg1Copy})
+	fake.saveEventsMutex.Unlock()
+	if fake.SaveEventsStub != nil {
+		return fake.SaveEventsStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.saveEventReturns
+	fakeReturns := fake.saveEventsReturns
 	return fakeReturns.result1
 }
 
-func (fake *FakeBuild) SaveEventCallCount() int {
-	fake.saveEventMutex.RLock()
-	defer fake.saveEventMutex.RUnlock()
-	return len(fake.saveEventArgsForCall)
+func (fake *FakeBuild) SaveEventsCallCount() int {
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
+	return len(fake.saveEventsArgsForCall)
 }
 
-func (fake *FakeBuild) SaveEventCalls(stub func(atc.Event) error) {
-	fake.saveEventMutex.Lock()
-	defer fake.saveEventMutex.Unlock()
-	fake.SaveEventStub = stub
+func (fake *FakeBuild) SaveEventsCalls(stub func([]atc.Event) error) {
+	fake.saveEventsMutex.Lock()
+	defer fake.saveEventsMutex.Unlock()
+	fake.SaveEventsStub = stub
 }
 
-func (fake *FakeBuild) SaveEventArgsForCall(i int) atc.Event {
-	fake.saveEventMutex.RLock()
-	defer fake.saveEventMutex.RUnlock()
-	argsForCall := fake.saveEventArgsForCall[i]
+func (fake *FakeBuild) SaveEventsArgsForCall(i int) []atc.Event {
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
+	argsForCall := fake.saveEventsArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakeBuild) SaveEventReturns(result1 error) {
-	fake.saveEventMutex.Lock()
-	defer fake.saveEventMutex.Unlock()
-	fake.SaveEventStub = nil
-	fake.saveEventReturns = struct {
+func (fake *FakeBuild) SaveEventsReturns(result1 error) {
+	fake.saveEventsMutex.Lock()
+	defer fake.saveEventsMutex.Unlock()
+	fake.SaveEventsStub = nil
+	fake.saveEventsReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeBuild) SaveEventReturnsOnCall(i int, result1 error) {
-	fake.saveEventMutex.Lock()
-	defer fake.saveEventMutex.Unlock()
-	fake.SaveEventStub = nil
-	if fake.saveEventReturnsOnCall == nil {
-		fake.saveEventReturnsOnCall = make(map[int]struct {
+func (fake *FakeBuild) SaveEventsReturnsOnCall(i int, result1 error) {
+	fake.saveEventsMutex.Lock()
+	defer fake.saveEventsMutex.Unlock()
+	fake.SaveEventsStub = nil
+	if fake.saveEventsReturnsOnCall == nil {
+		fake.saveEventsReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.saveEventReturnsOnCall[i] = struct {
+	fake.saveEventsReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -2259,6 +3858,128 @@ func (fake *FakeBuild) SaveImageResourceVersionReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
+func (fake *FakeBuild) SaveInput(arg1 db.BuildInput, arg2 bool) error {
+	fake.saveInputMutex.Lock()
+	ret, specificReturn := fake.saveInputReturnsOnCall[len(fake.saveInputArgsForCall)]
+	fake.saveInputArgsForCall = append(fake.saveInputArgsForCall, struct {
+		arg1 db.BuildInput
+		arg2 bool
+	}{arg1, arg2})
+	fake.recordInvocation("SaveInput", []interface{}{arg1, arg2})
+	fake.saveInputMutex.Unlock()
+	if fake.SaveInputStub != nil {
+		return fake.SaveInputStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveInputReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveInputCallCount() int {
+	fake.saveInputMutex.RLock()
+	defer fake.saveInputMutex.RUnlock()
+	return len(fake.saveInputArgsForCall)
+}
+
+func (fake *FakeBuild) SaveInputCalls(stub func(db.BuildInput, bool) error) {
+	fake.saveInputMutex.Lock()
+	defer fake.saveInputMutex.Unlock()
+	fake.SaveInputStub = stub
+}
+
+func (fake *FakeBuild) SaveInputArgsForCall(i int) (db.BuildInput, bool) {
+	fake.saveInputMutex.RLock()
+	defer fake.saveInputMutex.RUnlock()
+	argsForCall := fake.saveInputArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) SaveInputReturns(result1 error) {
+	fake.saveInputMutex.Lock()
+	defer fake.saveInputMutex.Unlock()
+	fake.SaveInputStub = nil
+	fake.saveInputReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveInputReturnsOnCall(i int, result1 error) {
+	fake.saveInputMutex.Lock()
+	defer fake.saveInputMutex.Unlock()
+	fake.SaveInputStub = nil
+	if fake.saveInputReturnsOnCall == nil {
+		fake.saveInputReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveInputReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveMetric(arg1 string, arg2 float64) error {
+	fake.saveMetricMutex.Lock()
+	ret, specificReturn := fake.saveMetricReturnsOnCall[len(fake.saveMetricArgsForCall)]
+	fake.saveMetricArgsForCall = append(fake.saveMetricArgsForCall, struct {
+		arg1 string
+		arg2 float64
+	}{arg1, arg2})
+	fake.recordInvocation("SaveMetric", []interface{}{arg1, arg2})
+	fake.saveMetricMutex.Unlock()
+	if fake.SaveMetricStub != nil {
+		return fake.SaveMetricStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveMetricReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveMetricCallCount() int {
+	fake.saveMetricMutex.RLock()
+	defer fake.saveMetricMutex.RUnlock()
+	return len(fake.saveMetricArgsForCall)
+}
+
+func (fake *FakeBuild) SaveMetricCalls(stub func(string, float64) error) {
+	fake.saveMetricMutex.Lock()
+	defer fake.saveMetricMutex.Unlock()
+	fake.SaveMetricStub = stub
+}
+
+func (fake *FakeBuild) SaveMetricArgsForCall(i int) (string, float64) {
+	fake.saveMetricMutex.RLock()
+	defer fake.saveMetricMutex.RUnlock()
+	argsForCall := fake.saveMetricArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) SaveMetricReturns(result1 error) {
+	fake.saveMetricMutex.Lock()
+	defer fake.saveMetricMutex.Unlock()
+	fake.SaveMetricStub = nil
+	fake.saveMetricReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveMetricReturnsOnCall(i int, result1 error) {
+	fake.saveMetricMutex.Lock()
+	defer fake.saveMetricMutex.Unlock()
+	fake.SaveMetricStub = nil
+	if fake.saveMetricReturnsOnCall == nil {
+		fake.saveMetricReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveMetricReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SaveOutput(arg1 lager.Logger, arg2 string, arg3 atc.Source, arg4 creds.VersionedResourceTypes, arg5 atc.Version, arg6 db.ResourceConfigMetadataFields, arg7 string, arg8 string) error {
 	fake.saveOutputMutex.Lock()
 	ret, specificReturn := fake.saveOutputReturnsOnCall[len(fake.saveOutputArgsForCall)]
@@ -2553,6 +4274,61 @@ func (fake *FakeBuild) SetInterceptibleReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) Snapshot() (db.BuildReport, error) {
+	fake.snapshotMutex.Lock()
+	ret, specificReturn := fake.snapshotReturnsOnCall[len(fake.snapshotArgsForCall)]
+	fake.snapshotArgsForCall = append(fake.snapshotArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Snapshot", []interface{}{})
+	fake.snapshotMutex.Unlock()
+	if fake.SnapshotStub != nil {
+		return fake.SnapshotStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.snapshotReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) SnapshotCallCount() int {
+	fake.snapshotMutex.RLock()
+	defer fake.snapshotMutex.RUnlock()
+	return len(fake.snapshotArgsForCall)
+}
+
+func (fake *FakeBuild) SnapshotCalls(stub func() (db.BuildReport, error)) {
+	fake.snapshotMutex.Lock()
+	defer fake.snapshotMutex.Unlock()
+	fake.SnapshotStub = stub
+}
+
+func (fake *FakeBuild) SnapshotReturns(result1 db.BuildReport, result2 error) {
+	fake.snapshotMutex.Lock()
+	defer fake.snapshotMutex.Unlock()
+	fake.SnapshotStub = nil
+	fake.snapshotReturns = struct {
+		result1 db.BuildReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SnapshotReturnsOnCall(i int, result1 db.BuildReport, result2 error) {
+	fake.snapshotMutex.Lock()
+	defer fake.snapshotMutex.Unlock()
+	fake.SnapshotStub = nil
+	if fake.snapshotReturnsOnCall == nil {
+		fake.snapshotReturnsOnCall = make(map[int]struct {
+			result1 db.BuildReport
+			result2 error
+		})
+	}
+	fake.snapshotReturnsOnCall[i] = struct {
+		result1 db.BuildReport
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Start(arg1 atc.Plan) (bool, error) {
 	fake.startMutex.Lock()
 	ret, specificReturn := fake.startReturnsOnCall[len(fake.startArgsForCall)]
@@ -2668,6 +4444,70 @@ func (fake *FakeBuild) StartTimeReturnsOnCall(i int, result1 time.Time) {
 	}{result1}
 }
 
+func (fake *FakeBuild) StartWithTimeout(arg1 atc.Plan, arg2 time.Duration) (bool, error) {
+	fake.startWithTimeoutMutex.Lock()
+	ret, specificReturn := fake.startWithTimeoutReturnsOnCall[len(fake.startWithTimeoutArgsForCall)]
+	fake.startWithTimeoutArgsForCall = append(fake.startWithTimeoutArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("StartWithTimeout", []interface{}{arg1, arg2})
+	fake.startWithTimeoutMutex.Unlock()
+	if fake.StartWithTimeoutStub != nil {
+		return fake.StartWithTimeoutStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.startWithTimeoutReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) StartWithTimeoutCallCount() int {
+	fake.startWithTimeoutMutex.RLock()
+	defer fake.startWithTimeoutMutex.RUnlock()
+	return len(fake.startWithTimeoutArgsForCall)
+}
+
+func (fake *FakeBuild) StartWithTimeoutCalls(stub func(atc.Plan, time.Duration) (bool, error)) {
+	fake.startWithTimeoutMutex.Lock()
+	defer fake.startWithTimeoutMutex.Unlock()
+	fake.StartWithTimeoutStub = stub
+}
+
+func (fake *FakeBuild) StartWithTimeoutArgsForCall(i int) (atc.Plan, time.Duration) {
+	fake.startWithTimeoutMutex.RLock()
+	defer fake.startWithTimeoutMutex.RUnlock()
+	argsForCall := fake.startWithTimeoutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) StartWithTimeoutReturns(result1 bool, result2 error) {
+	fake.startWithTimeoutMutex.Lock()
+	defer fake.startWithTimeoutMutex.Unlock()
+	fake.StartWithTimeoutStub = nil
+	fake.startWithTimeoutReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) StartWithTimeoutReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.startWithTimeoutMutex.Lock()
+	defer fake.startWithTimeoutMutex.Unlock()
+	fake.StartWithTimeoutStub = nil
+	if fake.startWithTimeoutReturnsOnCall == nil {
+		fake.startWithTimeoutReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.startWithTimeoutReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Status() db.BuildStatus {
 	fake.statusMutex.Lock()
 	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
@@ -2720,6 +4560,69 @@ func (fake *FakeBuild) StatusReturnsOnCall(i int, result1 db.BuildStatus) {
 	}{result1}
 }
 
+func (fake *FakeBuild) TailEvents(arg1 uint) ([]event.Envelope, error) {
+	fake.tailEventsMutex.Lock()
+	ret, specificReturn := fake.tailEventsReturnsOnCall[len(fake.tailEventsArgsForCall)]
+	fake.tailEventsArgsForCall = append(fake.tailEventsArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.recordInvocation("TailEvents", []interface{}{arg1})
+	fake.tailEventsMutex.Unlock()
+	if fake.TailEventsStub != nil {
+		return fake.TailEventsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.tailEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) TailEventsCallCount() int {
+	fake.tailEventsMutex.RLock()
+	defer fake.tailEventsMutex.RUnlock()
+	return len(fake.tailEventsArgsForCall)
+}
+
+func (fake *FakeBuild) TailEventsCalls(stub func(uint) ([]event.Envelope, error)) {
+	fake.tailEventsMutex.Lock()
+	defer fake.tailEventsMutex.Unlock()
+	fake.TailEventsStub = stub
+}
+
+func (fake *FakeBuild) TailEventsArgsForCall(i int) uint {
+	fake.tailEventsMutex.RLock()
+	defer fake.tailEventsMutex.RUnlock()
+	argsForCall := fake.tailEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) TailEventsReturns(result1 []event.Envelope, result2 error) {
+	fake.tailEventsMutex.Lock()
+	defer fake.tailEventsMutex.Unlock()
+	fake.TailEventsStub = nil
+	fake.tailEventsReturns = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) TailEventsReturnsOnCall(i int, result1 []event.Envelope, result2 error) {
+	fake.tailEventsMutex.Lock()
+	defer fake.tailEventsMutex.Unlock()
+	fake.TailEventsStub = nil
+	if fake.tailEventsReturnsOnCall == nil {
+		fake.tailEventsReturnsOnCall = make(map[int]struct {
+			result1 []event.Envelope
+			result2 error
+		})
+	}
+	fake.tailEventsReturnsOnCall[i] = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) TeamID() int {
 	fake.teamIDMutex.Lock()
 	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
@@ -2824,6 +4727,58 @@ func (fake *FakeBuild) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) TriggerSource() db.BuildTrigger {
+	fake.triggerSourceMutex.Lock()
+	ret, specificReturn := fake.triggerSourceReturnsOnCall[len(fake.triggerSourceArgsForCall)]
+	fake.triggerSourceArgsForCall = append(fake.triggerSourceArgsForCall, struct {
+	}{})
+	fake.recordInvocation("TriggerSource", []interface{}{})
+	fake.triggerSourceMutex.Unlock()
+	if fake.TriggerSourceStub != nil {
+		return fake.TriggerSourceStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.triggerSourceReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) TriggerSourceCallCount() int {
+	fake.triggerSourceMutex.RLock()
+	defer fake.triggerSourceMutex.RUnlock()
+	return len(fake.triggerSourceArgsForCall)
+}
+
+func (fake *FakeBuild) TriggerSourceCalls(stub func() db.BuildTrigger) {
+	fake.triggerSourceMutex.Lock()
+	defer fake.triggerSourceMutex.Unlock()
+	fake.TriggerSourceStub = stub
+}
+
+func (fake *FakeBuild) TriggerSourceReturns(result1 db.BuildTrigger) {
+	fake.triggerSourceMutex.Lock()
+	defer fake.triggerSourceMutex.Unlock()
+	fake.TriggerSourceStub = nil
+	fake.triggerSourceReturns = struct {
+		result1 db.BuildTrigger
+	}{result1}
+}
+
+func (fake *FakeBuild) TriggerSourceReturnsOnCall(i int, result1 db.BuildTrigger) {
+	fake.triggerSourceMutex.Lock()
+	defer fake.triggerSourceMutex.Unlock()
+	fake.TriggerSourceStub = nil
+	if fake.triggerSourceReturnsOnCall == nil {
+		fake.triggerSourceReturnsOnCall = make(map[int]struct {
+			result1 db.BuildTrigger
+		})
+	}
+	fake.triggerSourceReturnsOnCall[i] = struct {
+		result1 db.BuildTrigger
+	}{result1}
+}
+
 func (fake *FakeBuild) UseInputs(arg1 []db.BuildInput) error {
 	var arg1Copy []db.BuildInput
 	if arg1 != nil {
@@ -2889,13 +4844,143 @@ func (fake *FakeBuild) UseInputsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) WriteEventsJSON(arg1 io.Writer, arg2 uint, arg3 bool) error {
+	fake.writeEventsJSONMutex.Lock()
+	ret, specificReturn := fake.writeEventsJSONReturnsOnCall[len(fake.writeEventsJSONArgsForCall)]
+	fake.writeEventsJSONArgsForCall = append(fake.writeEventsJSONArgsForCall, struct {
+		arg1 io.Writer
+		arg2 uint
+		arg3 bool
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("WriteEventsJSON", []interface{}{arg1, arg2, arg3})
+	fake.writeEventsJSONMutex.Unlock()
+	if fake.WriteEventsJSONStub != nil {
+		return fake.WriteEventsJSONStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.writeEventsJSONReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) WriteEventsJSONCallCount() int {
+	fake.writeEventsJSONMutex.RLock()
+	defer fake.writeEventsJSONMutex.RUnlock()
+	return len(fake.writeEventsJSONArgsForCall)
+}
+
+func (fake *FakeBuild) WriteEventsJSONCalls(stub func(io.Writer, uint, bool) error) {
+	fake.writeEventsJSONMutex.Lock()
+	defer fake.writeEventsJSONMutex.Unlock()
+	fake.WriteEventsJSONStub = stub
+}
+
+func (fake *FakeBuild) WriteEventsJSONArgsForCall(i int) (io.Writer, uint, bool) {
+	fake.writeEventsJSONMutex.RLock()
+	defer fake.writeEventsJSONMutex.RUnlock()
+	argsForCall := fake.writeEventsJSONArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuild) WriteEventsJSONReturns(result1 error) {
+	fake.writeEventsJSONMutex.Lock()
+	defer fake.writeEventsJSONMutex.Unlock()
+	fake.WriteEventsJSONStub = nil
+	fake.writeEventsJSONReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) WriteEventsJSONReturnsOnCall(i int, result1 error) {
+	fake.writeEventsJSONMutex.Lock()
+	defer fake.writeEventsJSONMutex.Unlock()
+	fake.WriteEventsJSONStub = nil
+	if fake.writeEventsJSONReturnsOnCall == nil {
+		fake.writeEventsJSONReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeEventsJSONReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) WriteSSE(arg1 context.Context, arg2 io.Writer, arg3 uint) error {
+	fake.writeSSEMutex.Lock()
+	ret, specificReturn := fake.writeSSEReturnsOnCall[len(fake.writeSSEArgsForCall)]
+	fake.writeSSEArgsForCall = append(fake.writeSSEArgsForCall, struct {
+		arg1 context.Context
+		arg2 io.Writer
+		arg3 uint
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("WriteSSE", []interface{}{arg1, arg2, arg3})
+	fake.writeSSEMutex.Unlock()
+	if fake.WriteSSEStub != nil {
+		return fake.WriteSSEStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.writeSSEReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) WriteSSECallCount() int {
+	fake.writeSSEMutex.RLock()
+	defer fake.writeSSEMutex.RUnlock()
+	return len(fake.writeSSEArgsForCall)
+}
+
+func (fake *FakeBuild) WriteSSECalls(stub func(context.Context, io.Writer, uint) error) {
+	fake.writeSSEMutex.Lock()
+	defer fake.writeSSEMutex.Unlock()
+	fake.WriteSSEStub = stub
+}
+
+func (fake *FakeBuild) WriteSSEArgsForCall(i int) (context.Context, io.Writer, uint) {
+	fake.writeSSEMutex.RLock()
+	defer fake.writeSSEMutex.RUnlock()
+	argsForCall := fake.writeSSEArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuild) WriteSSEReturns(result1 error) {
+	fake.writeSSEMutex.Lock()
+	defer fake.writeSSEMutex.Unlock()
+	fake.WriteSSEStub = nil
+	fake.writeSSEReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) WriteSSEReturnsOnCall(i int, result1 error) {
+	fake.writeSSEMutex.Lock()
+	defer fake.writeSSEMutex.Unlock()
+	fake.WriteSSEStub = nil
+	if fake.writeSSEReturnsOnCall == nil {
+		fake.writeSSEReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeSSEReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.abortNotifierMutex.RLock()
 	defer fake.abortNotifierMutex.RUnlock()
+	fake.abortReasonMutex.RLock()
+	defer fake.abortReasonMutex.RUnlock()
+	fake.abortWithReasonMutex.RLock()
+	defer fake.abortWithReasonMutex.RUnlock()
 	fake.acquireTrackingLockMutex.RLock()
 	defer fake.acquireTrackingLockMutex.RUnlock()
+	fake.addCommentMutex.RLock()
+	defer fake.addCommentMutex.RUnlock()
 	fake.artifactMutex.RLock()
 	defer fake.artifactMutex.RUnlock()
 	fake.artifactsMutex.RLock()
@@ -2904,12 +4989,34 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.createTimeMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.deleteEventsMutex.RLock()
+	defer fake.deleteEventsMutex.RUnlock()
+	fake.durationMutex.RLock()
+	defer fake.durationMutex.RUnlock()
 	fake.endTimeMutex.RLock()
 	defer fake.endTimeMutex.RUnlock()
+	fake.errorCategoryMutex.RLock()
+	defer fake.errorCategoryMutex.RUnlock()
+	fake.eventCountMutex.RLock()
+	defer fake.eventCountMutex.RUnlock()
 	fake.eventsMutex.RLock()
 	defer fake.eventsMutex.RUnlock()
+	fake.eventsPageMutex.RLock()
+	defer fake.eventsPageMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
+	fake.getCommentsMutex.RLock()
+	defer fake.getCommentsMutex.RUnlock()
+	fake.getInputResourceConfigVersionIDsMutex.RLock()
+	defer fake.getInputResourceConfigVersionIDsMutex.RUnlock()
+	fake.getInputsMutex.RLock()
+	defer fake.getInputsMutex.RUnlock()
+	fake.getOutputsMutex.RLock()
+	defer fake.getOutputsMutex.RUnlock()
+	fake.getResourceMetadataMutex.RLock()
+	defer fake.getResourceMetadataMutex.RUnlock()
+	fake.heartbeatMutex.RLock()
+	defer fake.heartbeatMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
 	fake.interceptibleMutex.RLock()
@@ -2926,14 +5033,24 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.isRunningMutex.RUnlock()
 	fake.isScheduledMutex.RLock()
 	defer fake.isScheduledMutex.RUnlock()
+	fake.isStaleMutex.RLock()
+	defer fake.isStaleMutex.RUnlock()
 	fake.jobIDMutex.RLock()
 	defer fake.jobIDMutex.RUnlock()
 	fake.jobNameMutex.RLock()
 	defer fake.jobNameMutex.RUnlock()
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
+	fake.lastEventTimeMutex.RLock()
+	defer fake.lastEventTimeMutex.RUnlock()
 	fake.markAsAbortedMutex.RLock()
 	defer fake.markAsAbortedMutex.RUnlock()
+	fake.markAsErroredMutex.RLock()
+	defer fake.markAsErroredMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.overridesPauseMutex.RLock()
+	defer fake.overridesPauseMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
@@ -2950,12 +5067,22 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.reapTimeMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
 	fake.saveEventMutex.RLock()
 	defer fake.saveEventMutex.RUnlock()
+	fake.saveEventAndFinishMutex.RLock()
+	defer fake.saveEventAndFinishMutex.RUnlock()
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
 	fake.saveImageResourceVersionMutex.RLock()
 	defer fake.saveImageResourceVersionMutex.RUnlock()
+	fake.saveInputMutex.RLock()
+	defer fake.saveInputMutex.RUnlock()
+	fake.saveMetricMutex.RLock()
+	defer fake.saveMetricMutex.RUnlock()
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
 	fake.scheduleMutex.RLock()
@@ -2966,18 +5093,30 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.setDrainedMutex.RUnlock()
 	fake.setInterceptibleMutex.RLock()
 	defer fake.setInterceptibleMutex.RUnlock()
+	fake.snapshotMutex.RLock()
+	defer fake.snapshotMutex.RUnlock()
 	fake.startMutex.RLock()
 	defer fake.startMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
+	fake.startWithTimeoutMutex.RLock()
+	defer fake.startWithTimeoutMutex.RUnlock()
 	fake.statusMutex.RLock()
 	defer fake.statusMutex.RUnlock()
+	fake.tailEventsMutex.RLock()
+	defer fake.tailEventsMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.triggerSourceMutex.RLock()
+	defer fake.triggerSourceMutex.RUnlock()
 	fake.useInputsMutex.RLock()
 	defer fake.useInputsMutex.RUnlock()
+	fake.writeEventsJSONMutex.RLock()
+	defer fake.writeEventsJSONMutex.RUnlock()
+	fake.writeSSEMutex.RLock()
+	defer fake.writeSSEMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
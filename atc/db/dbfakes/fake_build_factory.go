@@ -3,11 +3,24 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc/db"
 )
 
 type FakeBuildFactory struct {
+	AbortTimedOutBuildsStub        func() ([]int, error)
+	abortTimedOutBuildsMutex       sync.RWMutex
+	abortTimedOutBuildsArgsForCall []struct {
+	}
+	abortTimedOutBuildsReturns struct {
+		result1 []int
+		result2 error
+	}
+	abortTimedOutBuildsReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
 	BuildStub        func(int) (db.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -23,6 +36,34 @@ type FakeBuildFactory struct {
 		result2 bool
 		result3 error
 	}
+	BuildByNameStub        func(string, string, string, string) (db.Build, bool, error)
+	buildByNameMutex       sync.RWMutex
+	buildByNameArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	buildByNameReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	buildByNameReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	CloseStub        func() error
+	closeMutex       sync.RWMutex
+	closeArgsForCall []struct {
+	}
+	closeReturns struct {
+		result1 error
+	}
+	closeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetAllStartedBuildsStub        func() ([]db.Build, error)
 	getAllStartedBuildsMutex       sync.RWMutex
 	getAllStartedBuildsArgsForCall []struct {
@@ -57,6 +98,19 @@ type FakeBuildFactory struct {
 	markNonInterceptibleBuildsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PruneEventsOlderThanStub        func(time.Duration) (int, error)
+	pruneEventsOlderThanMutex       sync.RWMutex
+	pruneEventsOlderThanArgsForCall []struct {
+		arg1 time.Duration
+	}
+	pruneEventsOlderThanReturns struct {
+		result1 int
+		result2 error
+	}
+	pruneEventsOlderThanReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	PublicBuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
 	publicBuildsMutex       sync.RWMutex
 	publicBuildsArgsForCall []struct {
@@ -108,6 +162,61 @@ type FakeBuildFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeBuildFactory) AbortTimedOutBuilds() ([]int, error) {
+	fake.abortTimedOutBuildsMutex.Lock()
+	ret, specificReturn := fake.abortTimedOutBuildsReturnsOnCall[len(fake.abortTimedOutBuildsArgsForCall)]
+	fake.abortTimedOutBuildsArgsForCall = append(fake.abortTimedOutBuildsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("AbortTimedOutBuilds", []interface{}{})
+	fake.abortTimedOutBuildsMutex.Unlock()
+	if fake.AbortTimedOutBuildsStub != nil {
+		return fake.AbortTimedOutBuildsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.abortTimedOutBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) AbortTimedOutBuildsCallCount() int {
+	fake.abortTimedOutBuildsMutex.RLock()
+	defer fake.abortTimedOutBuildsMutex.RUnlock()
+	return len(fake.abortTimedOutBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) AbortTimedOutBuildsCalls(stub func() ([]int, error)) {
+	fake.abortTimedOutBuildsMutex.Lock()
+	defer fake.abortTimedOutBuildsMutex.Unlock()
+	fake.AbortTimedOutBuildsStub = stub
+}
+
+func (fake *FakeBuildFactory) AbortTimedOutBuildsReturns(result1 []int, result2 error) {
+	fake.abortTimedOutBuildsMutex.Lock()
+	defer fake.abortTimedOutBuildsMutex.Unlock()
+	fake.AbortTimedOutBuildsStub = nil
+	fake.abortTimedOutBuildsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) AbortTimedOutBuildsReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.abortTimedOutBuildsMutex.Lock()
+	defer fake.abortTimedOutBuildsMutex.Unlock()
+	fake.AbortTimedOutBuildsStub = nil
+	if fake.abortTimedOutBuildsReturnsOnCall == nil {
+		fake.abortTimedOutBuildsReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.abortTimedOutBuildsReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) Build(arg1 int) (db.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -174,6 +283,127 @@ func (fake *FakeBuildFactory) BuildReturnsOnCall(i int, result1 db.Build, result
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildFactory) BuildByName(arg1 string, arg2 string, arg3 string, arg4 string) (db.Build, bool, error) {
+	fake.buildByNameMutex.Lock()
+	ret, specificReturn := fake.buildByNameReturnsOnCall[len(fake.buildByNameArgsForCall)]
+	fake.buildByNameArgsForCall = append(fake.buildByNameArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("BuildByName", []interface{}{arg1, arg2, arg3, arg4})
+	fake.buildByNameMutex.Unlock()
+	if fake.BuildByNameStub != nil {
+		return fake.BuildByNameStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.buildByNameReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuildFactory) BuildByNameCallCount() int {
+	fake.buildByNameMutex.RLock()
+	defer fake.buildByNameMutex.RUnlock()
+	return len(fake.buildByNameArgsForCall)
+}
+
+func (fake *FakeBuildFactory) BuildByNameCalls(stub func(string, string, string, string) (db.Build, bool, error)) {
+	fake.buildByNameMutex.Lock()
+	defer fake.buildByNameMutex.Unlock()
+	fake.BuildByNameStub = stub
+}
+
+func (fake *FakeBuildFactory) BuildByNameArgsForCall(i int) (string, string, string, string) {
+	fake.buildByNameMutex.RLock()
+	defer fake.buildByNameMutex.RUnlock()
+	argsForCall := fake.buildByNameArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeBuildFactory) BuildByNameReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.buildByNameMutex.Lock()
+	defer fake.buildByNameMutex.Unlock()
+	fake.BuildByNameStub = nil
+	fake.buildByNameReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildFactory) BuildByNameReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.buildByNameMutex.Lock()
+	defer fake.buildByNameMutex.Unlock()
+	fake.BuildByNameStub = nil
+	if fake.buildByNameReturnsOnCall == nil {
+		fake.buildByNameReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildByNameReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildFactory) Close() error {
+	fake.closeMutex.Lock()
+	ret, specificReturn := fake.closeReturnsOnCall[len(fake.closeArgsForCall)]
+	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Close", []interface{}{})
+	fake.closeMutex.Unlock()
+	if fake.CloseStub != nil {
+		return fake.CloseStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.closeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildFactory) CloseCallCount() int {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return len(fake.closeArgsForCall)
+}
+
+func (fake *FakeBuildFactory) CloseCalls(stub func() error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = stub
+}
+
+func (fake *FakeBuildFactory) CloseReturns(result1 error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = nil
+	fake.closeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildFactory) CloseReturnsOnCall(i int, result1 error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = nil
+	if fake.closeReturnsOnCall == nil {
+		fake.closeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.closeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuildFactory) GetAllStartedBuilds() ([]db.Build, error) {
 	fake.getAllStartedBuildsMutex.Lock()
 	ret, specificReturn := fake.getAllStartedBuildsReturnsOnCall[len(fake.getAllStartedBuildsArgsForCall)]
@@ -336,6 +566,69 @@ func (fake *FakeBuildFactory) MarkNonInterceptibleBuildsReturnsOnCall(i int, res
 	}{result1}
 }
 
+func (fake *FakeBuildFactory) PruneEventsOlderThan(arg1 time.Duration) (int, error) {
+	fake.pruneEventsOlderThanMutex.Lock()
+	ret, specificReturn := fake.pruneEventsOlderThanReturnsOnCall[len(fake.pruneEventsOlderThanArgsForCall)]
+	fake.pruneEventsOlderThanArgsForCall = append(fake.pruneEventsOlderThanArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("PruneEventsOlderThan", []interface{}{arg1})
+	fake.pruneEventsOlderThanMutex.Unlock()
+	if fake.PruneEventsOlderThanStub != nil {
+		return fake.PruneEventsOlderThanStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.pruneEventsOlderThanReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) PruneEventsOlderThanCallCount() int {
+	fake.pruneEventsOlderThanMutex.RLock()
+	defer fake.pruneEventsOlderThanMutex.RUnlock()
+	return len(fake.pruneEventsOlderThanArgsForCall)
+}
+
+func (fake *FakeBuildFactory) PruneEventsOlderThanCalls(stub func(time.Duration) (int, error)) {
+	fake.pruneEventsOlderThanMutex.Lock()
+	defer fake.pruneEventsOlderThanMutex.Unlock()
+	fake.PruneEventsOlderThanStub = stub
+}
+
+func (fake *FakeBuildFactory) PruneEventsOlderThanArgsForCall(i int) time.Duration {
+	fake.pruneEventsOlderThanMutex.RLock()
+	defer fake.pruneEventsOlderThanMutex.RUnlock()
+	argsForCall := fake.pruneEventsOlderThanArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildFactory) PruneEventsOlderThanReturns(result1 int, result2 error) {
+	fake.pruneEventsOlderThanMutex.Lock()
+	defer fake.pruneEventsOlderThanMutex.Unlock()
+	fake.PruneEventsOlderThanStub = nil
+	fake.pruneEventsOlderThanReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) PruneEventsOlderThanReturnsOnCall(i int, result1 int, result2 error) {
+	fake.pruneEventsOlderThanMutex.Lock()
+	defer fake.pruneEventsOlderThanMutex.Unlock()
+	fake.PruneEventsOlderThanStub = nil
+	if fake.pruneEventsOlderThanReturnsOnCall == nil {
+		fake.pruneEventsOlderThanReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.pruneEventsOlderThanReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) PublicBuilds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
 	fake.publicBuildsMutex.Lock()
 	ret, specificReturn := fake.publicBuildsReturnsOnCall[len(fake.publicBuildsArgsForCall)]
@@ -549,14 +842,22 @@ func (fake *FakeBuildFactory) VisibleBuildsWithTimeReturnsOnCall(i int, result1
 func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.abortTimedOutBuildsMutex.RLock()
+	defer fake.abortTimedOutBuildsMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
+	fake.buildByNameMutex.RLock()
+	defer fake.buildByNameMutex.RUnlock()
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
 	fake.getAllStartedBuildsMutex.RLock()
 	defer fake.getAllStartedBuildsMutex.RUnlock()
 	fake.getDrainableBuildsMutex.RLock()
 	defer fake.getDrainableBuildsMutex.RUnlock()
 	fake.markNonInterceptibleBuildsMutex.RLock()
 	defer fake.markNonInterceptibleBuildsMutex.RUnlock()
+	fake.pruneEventsOlderThanMutex.RLock()
+	defer fake.pruneEventsOlderThanMutex.RUnlock()
 	fake.publicBuildsMutex.RLock()
 	defer fake.publicBuildsMutex.RUnlock()
 	fake.visibleBuildsMutex.RLock()
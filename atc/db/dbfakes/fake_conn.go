@@ -2,6 +2,7 @@
 package dbfakes
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"sync"
@@ -44,6 +45,16 @@ type FakeConn struct {
 	closeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CompressBuildEventsStub        func() bool
+	compressBuildEventsMutex       sync.RWMutex
+	compressBuildEventsArgsForCall []struct {
+	}
+	compressBuildEventsReturns struct {
+		result1 bool
+	}
+	compressBuildEventsReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	DriverStub        func() driver.Driver
 	driverMutex       sync.RWMutex
 	driverArgsForCall []struct {
@@ -78,6 +89,21 @@ type FakeConn struct {
 		result1 sql.Result
 		result2 error
 	}
+	ExecContextStub        func(context.Context, string, ...interface{}) (sql.Result, error)
+	execContextMutex       sync.RWMutex
+	execContextArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []interface{}
+	}
+	execContextReturns struct {
+		result1 sql.Result
+		result2 error
+	}
+	execContextReturnsOnCall map[int]struct {
+		result1 sql.Result
+		result2 error
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -125,6 +151,21 @@ type FakeConn struct {
 		result1 *sql.Rows
 		result2 error
 	}
+	QueryContextStub        func(context.Context, string, ...interface{}) (*sql.Rows, error)
+	queryContextMutex       sync.RWMutex
+	queryContextArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []interface{}
+	}
+	queryContextReturns struct {
+		result1 *sql.Rows
+		result2 error
+	}
+	queryContextReturnsOnCall map[int]struct {
+		result1 *sql.Rows
+		result2 error
+	}
 	QueryRowStub        func(string, ...interface{}) squirrel.RowScanner
 	queryRowMutex       sync.RWMutex
 	queryRowArgsForCall []struct {
@@ -137,6 +178,29 @@ type FakeConn struct {
 	queryRowReturnsOnCall map[int]struct {
 		result1 squirrel.RowScanner
 	}
+	QueryRowContextStub        func(context.Context, string, ...interface{}) squirrel.RowScanner
+	queryRowContextMutex       sync.RWMutex
+	queryRowContextArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []interface{}
+	}
+	queryRowContextReturns struct {
+		result1 squirrel.RowScanner
+	}
+	queryRowContextReturnsOnCall map[int]struct {
+		result1 squirrel.RowScanner
+	}
+	ReadReplicaStub        func() db.Conn
+	readReplicaMutex       sync.RWMutex
+	readReplicaArgsForCall []struct {
+	}
+	readReplicaReturns struct {
+		result1 db.Conn
+	}
+	readReplicaReturnsOnCall map[int]struct {
+		result1 db.Conn
+	}
 	SetMaxIdleConnsStub        func(int)
 	setMaxIdleConnsMutex       sync.RWMutex
 	setMaxIdleConnsArgsForCall []struct {
@@ -157,6 +221,17 @@ type FakeConn struct {
 	statsReturnsOnCall map[int]struct {
 		result1 sql.DBStats
 	}
+	TxRetryStub        func(func(db.Tx) error) error
+	txRetryMutex       sync.RWMutex
+	txRetryArgsForCall []struct {
+		arg1 func(db.Tx) error
+	}
+	txRetryReturns struct {
+		result1 error
+	}
+	txRetryReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -320,6 +395,58 @@ func (fake *FakeConn) CloseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeConn) CompressBuildEvents() bool {
+	fake.compressBuildEventsMutex.Lock()
+	ret, specificReturn := fake.compressBuildEventsReturnsOnCall[len(fake.compressBuildEventsArgsForCall)]
+	fake.compressBuildEventsArgsForCall = append(fake.compressBuildEventsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CompressBuildEvents", []interface{}{})
+	fake.compressBuildEventsMutex.Unlock()
+	if fake.CompressBuildEventsStub != nil {
+		return fake.CompressBuildEventsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.compressBuildEventsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeConn) CompressBuildEventsCallCount() int {
+	fake.compressBuildEventsMutex.RLock()
+	defer fake.compressBuildEventsMutex.RUnlock()
+	return len(fake.compressBuildEventsArgsForCall)
+}
+
+func (fake *FakeConn) CompressBuildEventsCalls(stub func() bool) {
+	fake.compressBuildEventsMutex.Lock()
+	defer fake.compressBuildEventsMutex.Unlock()
+	fake.CompressBuildEventsStub = stub
+}
+
+func (fake *FakeConn) CompressBuildEventsReturns(result1 bool) {
+	fake.compressBuildEventsMutex.Lock()
+	defer fake.compressBuildEventsMutex.Unlock()
+	fake.CompressBuildEventsStub = nil
+	fake.compressBuildEventsReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeConn) CompressBuildEventsReturnsOnCall(i int, result1 bool) {
+	fake.compressBuildEventsMutex.Lock()
+	defer fake.compressBuildEventsMutex.Unlock()
+	fake.CompressBuildEventsStub = nil
+	if fake.compressBuildEventsReturnsOnCall == nil {
+		fake.compressBuildEventsReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.compressBuildEventsReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeConn) Driver() driver.Driver {
 	fake.driverMutex.Lock()
 	ret, specificReturn := fake.driverReturnsOnCall[len(fake.driverArgsForCall)]
@@ -488,6 +615,71 @@ func (fake *FakeConn) ExecReturnsOnCall(i int, result1 sql.Result, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeConn) ExecContext(arg1 context.Context, arg2 string, arg3 ...interface{}) (sql.Result, error) {
+	fake.execContextMutex.Lock()
+	ret, specificReturn := fake.execContextReturnsOnCall[len(fake.execContextArgsForCall)]
+	fake.execContextArgsForCall = append(fake.execContextArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []interface{}
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ExecContext", []interface{}{arg1, arg2, arg3})
+	fake.execContextMutex.Unlock()
+	if fake.ExecContextStub != nil {
+		return fake.ExecContextStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.execContextReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeConn) ExecContextCallCount() int {
+	fake.execContextMutex.RLock()
+	defer fake.execContextMutex.RUnlock()
+	return len(fake.execContextArgsForCall)
+}
+
+func (fake *FakeConn) ExecContextCalls(stub func(context.Context, string, ...interface{}) (sql.Result, error)) {
+	fake.execContextMutex.Lock()
+	defer fake.execContextMutex.Unlock()
+	fake.ExecContextStub = stub
+}
+
+func (fake *FakeConn) ExecContextArgsForCall(i int) (context.Context, string, []interface{}) {
+	fake.execContextMutex.RLock()
+	defer fake.execContextMutex.RUnlock()
+	argsForCall := fake.execContextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeConn) ExecContextReturns(result1 sql.Result, result2 error) {
+	fake.execContextMutex.Lock()
+	defer fake.execContextMutex.Unlock()
+	fake.ExecContextStub = nil
+	fake.execContextReturns = struct {
+		result1 sql.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeConn) ExecContextReturnsOnCall(i int, result1 sql.Result, result2 error) {
+	fake.execContextMutex.Lock()
+	defer fake.execContextMutex.Unlock()
+	fake.ExecContextStub = nil
+	if fake.execContextReturnsOnCall == nil {
+		fake.execContextReturnsOnCall = make(map[int]struct {
+			result1 sql.Result
+			result2 error
+		})
+	}
+	fake.execContextReturnsOnCall[i] = struct {
+		result1 sql.Result
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeConn) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -719,6 +911,71 @@ func (fake *FakeConn) QueryReturnsOnCall(i int, result1 *sql.Rows, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeConn) QueryContext(arg1 context.Context, arg2 string, arg3 ...interface{}) (*sql.Rows, error) {
+	fake.queryContextMutex.Lock()
+	ret, specificReturn := fake.queryContextReturnsOnCall[len(fake.queryContextArgsForCall)]
+	fake.queryContextArgsForCall = append(fake.queryContextArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []interface{}
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("QueryContext", []interface{}{arg1, arg2, arg3})
+	fake.queryContextMutex.Unlock()
+	if fake.QueryContextStub != nil {
+		return fake.QueryContextStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.queryContextReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeConn) QueryContextCallCount() int {
+	fake.queryContextMutex.RLock()
+	defer fake.queryContextMutex.RUnlock()
+	return len(fake.queryContextArgsForCall)
+}
+
+func (fake *FakeConn) QueryContextCalls(stub func(context.Context, string, ...interface{}) (*sql.Rows, error)) {
+	fake.queryContextMutex.Lock()
+	defer fake.queryContextMutex.Unlock()
+	fake.QueryContextStub = stub
+}
+
+func (fake *FakeConn) QueryContextArgsForCall(i int) (context.Context, string, []interface{}) {
+	fake.queryContextMutex.RLock()
+	defer fake.queryContextMutex.RUnlock()
+	argsForCall := fake.queryContextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeConn) QueryContextReturns(result1 *sql.Rows, result2 error) {
+	fake.queryContextMutex.Lock()
+	defer fake.queryContextMutex.Unlock()
+	fake.QueryContextStub = nil
+	fake.queryContextReturns = struct {
+		result1 *sql.Rows
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeConn) QueryContextReturnsOnCall(i int, result1 *sql.Rows, result2 error) {
+	fake.queryContextMutex.Lock()
+	defer fake.queryContextMutex.Unlock()
+	fake.QueryContextStub = nil
+	if fake.queryContextReturnsOnCall == nil {
+		fake.queryContextReturnsOnCall = make(map[int]struct {
+			result1 *sql.Rows
+			result2 error
+		})
+	}
+	fake.queryContextReturnsOnCall[i] = struct {
+		result1 *sql.Rows
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeConn) QueryRow(arg1 string, arg2 ...interface{}) squirrel.RowScanner {
 	fake.queryRowMutex.Lock()
 	ret, specificReturn := fake.queryRowReturnsOnCall[len(fake.queryRowArgsForCall)]
@@ -780,6 +1037,120 @@ func (fake *FakeConn) QueryRowReturnsOnCall(i int, result1 squirrel.RowScanner)
 	}{result1}
 }
 
+func (fake *FakeConn) QueryRowContext(arg1 context.Context, arg2 string, arg3 ...interface{}) squirrel.RowScanner {
+	fake.queryRowContextMutex.Lock()
+	ret, specificReturn := fake.queryRowContextReturnsOnCall[len(fake.queryRowContextArgsForCall)]
+	fake.queryRowContextArgsForCall = append(fake.queryRowContextArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []interface{}
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("QueryRowContext", []interface{}{arg1, arg2, arg3})
+	fake.queryRowContextMutex.Unlock()
+	if fake.QueryRowContextStub != nil {
+		return fake.QueryRowContextStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.queryRowContextReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeConn) QueryRowContextCallCount() int {
+	fake.queryRowContextMutex.RLock()
+	defer fake.queryRowContextMutex.RUnlock()
+	return len(fake.queryRowContextArgsForCall)
+}
+
+func (fake *FakeConn) QueryRowContextCalls(stub func(context.Context, string, ...interface{}) squirrel.RowScanner) {
+	fake.queryRowContextMutex.Lock()
+	defer fake.queryRowContextMutex.Unlock()
+	fake.QueryRowContextStub = stub
+}
+
+func (fake *FakeConn) QueryRowContextArgsForCall(i int) (context.Context, string, []interface{}) {
+	fake.queryRowContextMutex.RLock()
+	defer fake.queryRowContextMutex.RUnlock()
+	argsForCall := fake.queryRowContextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeConn) QueryRowContextReturns(result1 squirrel.RowScanner) {
+	fake.queryRowContextMutex.Lock()
+	defer fake.queryRowContextMutex.Unlock()
+	fake.QueryRowContextStub = nil
+	fake.queryRowContextReturns = struct {
+		result1 squirrel.RowScanner
+	}{result1}
+}
+
+func (fake *FakeConn) QueryRowContextReturnsOnCall(i int, result1 squirrel.RowScanner) {
+	fake.queryRowContextMutex.Lock()
+	defer fake.queryRowContextMutex.Unlock()
+	fake.QueryRowContextStub = nil
+	if fake.queryRowContextReturnsOnCall == nil {
+		fake.queryRowContextReturnsOnCall = make(map[int]struct {
+			result1 squirrel.RowScanner
+		})
+	}
+	fake.queryRowContextReturnsOnCall[i] = struct {
+		result1 squirrel.RowScanner
+	}{result1}
+}
+
+func (fake *FakeConn) ReadReplica() db.Conn {
+	fake.readReplicaMutex.Lock()
+	ret, specificReturn := fake.readReplicaReturnsOnCall[len(fake.readReplicaArgsForCall)]
+	fake.readReplicaArgsForCall = append(fake.readReplicaArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ReadReplica", []interface{}{})
+	fake.readReplicaMutex.Unlock()
+	if fake.ReadReplicaStub != nil {
+		return fake.ReadReplicaStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.readReplicaReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeConn) ReadReplicaCallCount() int {
+	fake.readReplicaMutex.RLock()
+	defer fake.readReplicaMutex.RUnlock()
+	return len(fake.readReplicaArgsForCall)
+}
+
+func (fake *FakeConn) ReadReplicaCalls(stub func() db.Conn) {
+	fake.readReplicaMutex.Lock()
+	defer fake.readReplicaMutex.Unlock()
+	fake.ReadReplicaStub = stub
+}
+
+func (fake *FakeConn) ReadReplicaReturns(result1 db.Conn) {
+	fake.readReplicaMutex.Lock()
+	defer fake.readReplicaMutex.Unlock()
+	fake.ReadReplicaStub = nil
+	fake.readReplicaReturns = struct {
+		result1 db.Conn
+	}{result1}
+}
+
+func (fake *FakeConn) ReadReplicaReturnsOnCall(i int, result1 db.Conn) {
+	fake.readReplicaMutex.Lock()
+	defer fake.readReplicaMutex.Unlock()
+	fake.ReadReplicaStub = nil
+	if fake.readReplicaReturnsOnCall == nil {
+		fake.readReplicaReturnsOnCall = make(map[int]struct {
+			result1 db.Conn
+		})
+	}
+	fake.readReplicaReturnsOnCall[i] = struct {
+		result1 db.Conn
+	}{result1}
+}
+
 func (fake *FakeConn) SetMaxIdleConns(arg1 int) {
 	fake.setMaxIdleConnsMutex.Lock()
 	fake.setMaxIdleConnsArgsForCall = append(fake.setMaxIdleConnsArgsForCall, struct {
@@ -894,6 +1265,66 @@ func (fake *FakeConn) StatsReturnsOnCall(i int, result1 sql.DBStats) {
 	}{result1}
 }
 
+func (fake *FakeConn) TxRetry(arg1 func(db.Tx) error) error {
+	fake.txRetryMutex.Lock()
+	ret, specificReturn := fake.txRetryReturnsOnCall[len(fake.txRetryArgsForCall)]
+	fake.txRetryArgsForCall = append(fake.txRetryArgsForCall, struct {
+		arg1 func(db.Tx) error
+	}{arg1})
+	fake.recordInvocation("TxRetry", []interface{}{arg1})
+	fake.txRetryMutex.Unlock()
+	if fake.TxRetryStub != nil {
+		return fake.TxRetryStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.txRetryReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeConn) TxRetryCallCount() int {
+	fake.txRetryMutex.RLock()
+	defer fake.txRetryMutex.RUnlock()
+	return len(fake.txRetryArgsForCall)
+}
+
+func (fake *FakeConn) TxRetryCalls(stub func(func(db.Tx) error) error) {
+	fake.txRetryMutex.Lock()
+	defer fake.txRetryMutex.Unlock()
+	fake.TxRetryStub = stub
+}
+
+func (fake *FakeConn) TxRetryArgsForCall(i int) func(db.Tx) error {
+	fake.txRetryMutex.RLock()
+	defer fake.txRetryMutex.RUnlock()
+	argsForCall := fake.txRetryArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeConn) TxRetryReturns(result1 error) {
+	fake.txRetryMutex.Lock()
+	defer fake.txRetryMutex.Unlock()
+	fake.TxRetryStub = nil
+	fake.txRetryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeConn) TxRetryReturnsOnCall(i int, result1 error) {
+	fake.txRetryMutex.Lock()
+	defer fake.txRetryMutex.Unlock()
+	fake.TxRetryStub = nil
+	if fake.txRetryReturnsOnCall == nil {
+		fake.txRetryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.txRetryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeConn) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -903,12 +1334,16 @@ func (fake *FakeConn) Invocations() map[string][][]interface{} {
 	defer fake.busMutex.RUnlock()
 	fake.closeMutex.RLock()
 	defer fake.closeMutex.RUnlock()
+	fake.compressBuildEventsMutex.RLock()
+	defer fake.compressBuildEventsMutex.RUnlock()
 	fake.driverMutex.RLock()
 	defer fake.driverMutex.RUnlock()
 	fake.encryptionStrategyMutex.RLock()
 	defer fake.encryptionStrategyMutex.RUnlock()
 	fake.execMutex.RLock()
 	defer fake.execMutex.RUnlock()
+	fake.execContextMutex.RLock()
+	defer fake.execContextMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.pingMutex.RLock()
@@ -917,14 +1352,22 @@ func (fake *FakeConn) Invocations() map[string][][]interface{} {
 	defer fake.prepareMutex.RUnlock()
 	fake.queryMutex.RLock()
 	defer fake.queryMutex.RUnlock()
+	fake.queryContextMutex.RLock()
+	defer fake.queryContextMutex.RUnlock()
 	fake.queryRowMutex.RLock()
 	defer fake.queryRowMutex.RUnlock()
+	fake.queryRowContextMutex.RLock()
+	defer fake.queryRowContextMutex.RUnlock()
+	fake.readReplicaMutex.RLock()
+	defer fake.readReplicaMutex.RUnlock()
 	fake.setMaxIdleConnsMutex.RLock()
 	defer fake.setMaxIdleConnsMutex.RUnlock()
 	fake.setMaxOpenConnsMutex.RLock()
 	defer fake.setMaxOpenConnsMutex.RUnlock()
 	fake.statsMutex.RLock()
 	defer fake.statsMutex.RUnlock()
+	fake.txRetryMutex.RLock()
+	defer fake.txRetryMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
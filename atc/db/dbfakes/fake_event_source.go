@@ -2,6 +2,7 @@
 package dbfakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/concourse/concourse/atc/db"
@@ -31,6 +32,19 @@ type FakeEventSource struct {
 		result1 event.Envelope
 		result2 error
 	}
+	NextContextStub        func(context.Context) (event.Envelope, error)
+	nextContextMutex       sync.RWMutex
+	nextContextArgsForCall []struct {
+		arg1 context.Context
+	}
+	nextContextReturns struct {
+		result1 event.Envelope
+		result2 error
+	}
+	nextContextReturnsOnCall map[int]struct {
+		result1 event.Envelope
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -142,6 +156,69 @@ func (fake *FakeEventSource) NextReturnsOnCall(i int, result1 event.Envelope, re
 	}{result1, result2}
 }
 
+func (fake *FakeEventSource) NextContext(arg1 context.Context) (event.Envelope, error) {
+	fake.nextContextMutex.Lock()
+	ret, specificReturn := fake.nextContextReturnsOnCall[len(fake.nextContextArgsForCall)]
+	fake.nextContextArgsForCall = append(fake.nextContextArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("NextContext", []interface{}{arg1})
+	fake.nextContextMutex.Unlock()
+	if fake.NextContextStub != nil {
+		return fake.NextContextStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.nextContextReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeEventSource) NextContextCallCount() int {
+	fake.nextContextMutex.RLock()
+	defer fake.nextContextMutex.RUnlock()
+	return len(fake.nextContextArgsForCall)
+}
+
+func (fake *FakeEventSource) NextContextCalls(stub func(context.Context) (event.Envelope, error)) {
+	fake.nextContextMutex.Lock()
+	defer fake.nextContextMutex.Unlock()
+	fake.NextContextStub = stub
+}
+
+func (fake *FakeEventSource) NextContextArgsForCall(i int) context.Context {
+	fake.nextContextMutex.RLock()
+	defer fake.nextContextMutex.RUnlock()
+	argsForCall := fake.nextContextArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeEventSource) NextContextReturns(result1 event.Envelope, result2 error) {
+	fake.nextContextMutex.Lock()
+	defer fake.nextContextMutex.Unlock()
+	fake.NextContextStub = nil
+	fake.nextContextReturns = struct {
+		result1 event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEventSource) NextContextReturnsOnCall(i int, result1 event.Envelope, result2 error) {
+	fake.nextContextMutex.Lock()
+	defer fake.nextContextMutex.Unlock()
+	fake.NextContextStub = nil
+	if fake.nextContextReturnsOnCall == nil {
+		fake.nextContextReturnsOnCall = make(map[int]struct {
+			result1 event.Envelope
+			result2 error
+		})
+	}
+	fake.nextContextReturnsOnCall[i] = struct {
+		result1 event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeEventSource) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -149,6 +226,8 @@ func (fake *FakeEventSource) Invocations() map[string][][]interface{} {
 	defer fake.closeMutex.RUnlock()
 	fake.nextMutex.RLock()
 	defer fake.nextMutex.RUnlock()
+	fake.nextContextMutex.RLock()
+	defer fake.nextContextMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -79,9 +79,10 @@ type FakeJob struct {
 	configReturnsOnCall map[int]struct {
 		result1 atc.JobConfig
 	}
-	CreateBuildStub        func() (db.Build, error)
+	CreateBuildStub        func(db.BuildTrigger) (db.Build, error)
 	createBuildMutex       sync.RWMutex
 	createBuildArgsForCall []struct {
+		arg1 db.BuildTrigger
 	}
 	createBuildReturns struct {
 		result1 db.Build
@@ -91,6 +92,18 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateBuildOverridingPauseStub        func() (db.Build, error)
+	createBuildOverridingPauseMutex       sync.RWMutex
+	createBuildOverridingPauseArgsForCall []struct {
+	}
+	createBuildOverridingPauseReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildOverridingPauseReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	DeleteNextInputMappingStub        func() error
 	deleteNextInputMappingMutex       sync.RWMutex
 	deleteNextInputMappingArgsForCall []struct {
@@ -101,9 +114,10 @@ type FakeJob struct {
 	deleteNextInputMappingReturnsOnCall map[int]struct {
 		result1 error
 	}
-	EnsurePendingBuildExistsStub        func() error
+	EnsurePendingBuildExistsStub        func(db.BuildTrigger) error
 	ensurePendingBuildExistsMutex       sync.RWMutex
 	ensurePendingBuildExistsArgsForCall []struct {
+		arg1 db.BuildTrigger
 	}
 	ensurePendingBuildExistsReturns struct {
 		result1 error
@@ -706,15 +720,16 @@ func (fake *FakeJob) ConfigReturnsOnCall(i int, result1 atc.JobConfig) {
 	}{result1}
 }
 
-func (fake *FakeJob) CreateBuild() (db.Build, error) {
+func (fake *FakeJob) CreateBuild(arg1 db.BuildTrigger) (db.Build, error) {
 	fake.createBuildMutex.Lock()
 	ret, specificReturn := fake.createBuildReturnsOnCall[len(fake.createBuildArgsForCall)]
 	fake.createBuildArgsForCall = append(fake.createBuildArgsForCall, struct {
-	}{})
-	fake.recordInvocation("CreateBuild", []interface{}{})
+		arg1 db.BuildTrigger
+	}{arg1})
+	fake.recordInvocation("CreateBuild", []interface{}{arg1})
 	fake.createBuildMutex.Unlock()
 	if fake.CreateBuildStub != nil {
-		return fake.CreateBuildStub()
+		return fake.CreateBuildStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -729,12 +744,19 @@ func (fake *FakeJob) CreateBuildCallCount() int {
 	return len(fake.createBuildArgsForCall)
 }
 
-func (fake *FakeJob) CreateBuildCalls(stub func() (db.Build, error)) {
+func (fake *FakeJob) CreateBuildCalls(stub func(db.BuildTrigger) (db.Build, error)) {
 	fake.createBuildMutex.Lock()
 	defer fake.createBuildMutex.Unlock()
 	fake.CreateBuildStub = stub
 }
 
+func (fake *FakeJob) CreateBuildArgsForCall(i int) db.BuildTrigger {
+	fake.createBuildMutex.RLock()
+	defer fake.createBuildMutex.RUnlock()
+	argsForCall := fake.createBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeJob) CreateBuildReturns(result1 db.Build, result2 error) {
 	fake.createBuildMutex.Lock()
 	defer fake.createBuildMutex.Unlock()
@@ -761,6 +783,61 @@ func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeJob) CreateBuildOverridingPause() (db.Build, error) {
+	fake.createBuildOverridingPauseMutex.Lock()
+	ret, specificReturn := fake.createBuildOverridingPauseReturnsOnCall[len(fake.createBuildOverridingPauseArgsForCall)]
+	fake.createBuildOverridingPauseArgsForCall = append(fake.createBuildOverridingPauseArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CreateBuildOverridingPause", []interface{}{})
+	fake.createBuildOverridingPauseMutex.Unlock()
+	if fake.CreateBuildOverridingPauseStub != nil {
+		return fake.CreateBuildOverridingPauseStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createBuildOverridingPauseReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildOverridingPauseCallCount() int {
+	fake.createBuildOverridingPauseMutex.RLock()
+	defer fake.createBuildOverridingPauseMutex.RUnlock()
+	return len(fake.createBuildOverridingPauseArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildOverridingPauseCalls(stub func() (db.Build, error)) {
+	fake.createBuildOverridingPauseMutex.Lock()
+	defer fake.createBuildOverridingPauseMutex.Unlock()
+	fake.CreateBuildOverridingPauseStub = stub
+}
+
+func (fake *FakeJob) CreateBuildOverridingPauseReturns(result1 db.Build, result2 error) {
+	fake.createBuildOverridingPauseMutex.Lock()
+	defer fake.createBuildOverridingPauseMutex.Unlock()
+	fake.CreateBuildOverridingPauseStub = nil
+	fake.createBuildOverridingPauseReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildOverridingPauseReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createBuildOverridingPauseMutex.Lock()
+	defer fake.createBuildOverridingPauseMutex.Unlock()
+	fake.CreateBuildOverridingPauseStub = nil
+	if fake.createBuildOverridingPauseReturnsOnCall == nil {
+		fake.createBuildOverridingPauseReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildOverridingPauseReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) DeleteNextInputMapping() error {
 	fake.deleteNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.deleteNextInputMappingReturnsOnCall[len(fake.deleteNextInputMappingArgsForCall)]
@@ -813,15 +890,16 @@ func (fake *FakeJob) DeleteNextInputMappingReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeJob) EnsurePendingBuildExists() error {
+func (fake *FakeJob) EnsurePendingBuildExists(arg1 db.BuildTrigger) error {
 	fake.ensurePendingBuildExistsMutex.Lock()
 	ret, specificReturn := fake.ensurePendingBuildExistsReturnsOnCall[len(fake.ensurePendingBuildExistsArgsForCall)]
 	fake.ensurePendingBuildExistsArgsForCall = append(fake.ensurePendingBuildExistsArgsForCall, struct {
-	}{})
-	fake.recordInvocation("EnsurePendingBuildExists", []interface{}{})
+		arg1 db.BuildTrigger
+	}{arg1})
+	fake.recordInvocation("EnsurePendingBuildExists", []interface{}{arg1})
 	fake.ensurePendingBuildExistsMutex.Unlock()
 	if fake.EnsurePendingBuildExistsStub != nil {
-		return fake.EnsurePendingBuildExistsStub()
+		return fake.EnsurePendingBuildExistsStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -836,12 +914,19 @@ func (fake *FakeJob) EnsurePendingBuildExistsCallCount() int {
 	return len(fake.ensurePendingBuildExistsArgsForCall)
 }
 
-func (fake *FakeJob) EnsurePendingBuildExistsCalls(stub func() error) {
+func (fake *FakeJob) EnsurePendingBuildExistsCalls(stub func(db.BuildTrigger) error) {
 	fake.ensurePendingBuildExistsMutex.Lock()
 	defer fake.ensurePendingBuildExistsMutex.Unlock()
 	fake.EnsurePendingBuildExistsStub = stub
 }
 
+func (fake *FakeJob) EnsurePendingBuildExistsArgsForCall(i int) db.BuildTrigger {
+	fake.ensurePendingBuildExistsMutex.RLock()
+	defer fake.ensurePendingBuildExistsMutex.RUnlock()
+	argsForCall := fake.ensurePendingBuildExistsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeJob) EnsurePendingBuildExistsReturns(result1 error) {
 	fake.ensurePendingBuildExistsMutex.Lock()
 	defer fake.ensurePendingBuildExistsMutex.Unlock()
@@ -2276,6 +2361,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.configMutex.RUnlock()
 	fake.createBuildMutex.RLock()
 	defer fake.createBuildMutex.RUnlock()
+	fake.createBuildOverridingPauseMutex.RLock()
+	defer fake.createBuildOverridingPauseMutex.RUnlock()
 	fake.deleteNextInputMappingMutex.RLock()
 	defer fake.deleteNextInputMappingMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
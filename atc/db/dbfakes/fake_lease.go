@@ -0,0 +1,165 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeLease struct {
+	BreakStub        func() error
+	breakMutex       sync.RWMutex
+	breakArgsForCall []struct {
+	}
+	breakReturns struct {
+		result1 error
+	}
+	breakReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RenewStub        func() error
+	renewMutex       sync.RWMutex
+	renewArgsForCall []struct {
+	}
+	renewReturns struct {
+		result1 error
+	}
+	renewReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLease) Break() error {
+	fake.breakMutex.Lock()
+	ret, specificReturn := fake.breakReturnsOnCall[len(fake.breakArgsForCall)]
+	fake.breakArgsForCall = append(fake.breakArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Break", []interface{}{})
+	fake.breakMutex.Unlock()
+	if fake.BreakStub != nil {
+		return fake.BreakStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.breakReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeLease) BreakCallCount() int {
+	fake.breakMutex.RLock()
+	defer fake.breakMutex.RUnlock()
+	return len(fake.breakArgsForCall)
+}
+
+func (fake *FakeLease) BreakCalls(stub func() error) {
+	fake.breakMutex.Lock()
+	defer fake.breakMutex.Unlock()
+	fake.BreakStub = stub
+}
+
+func (fake *FakeLease) BreakReturns(result1 error) {
+	fake.breakMutex.Lock()
+	defer fake.breakMutex.Unlock()
+	fake.BreakStub = nil
+	fake.breakReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLease) BreakReturnsOnCall(i int, result1 error) {
+	fake.breakMutex.Lock()
+	defer fake.breakMutex.Unlock()
+	fake.BreakStub = nil
+	if fake.breakReturnsOnCall == nil {
+		fake.breakReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.breakReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLease) Renew() error {
+	fake.renewMutex.Lock()
+	ret, specificReturn := fake.renewReturnsOnCall[len(fake.renewArgsForCall)]
+	fake.renewArgsForCall = append(fake.renewArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Renew", []interface{}{})
+	fake.renewMutex.Unlock()
+	if fake.RenewStub != nil {
+		return fake.RenewStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.renewReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeLease) RenewCallCount() int {
+	fake.renewMutex.RLock()
+	defer fake.renewMutex.RUnlock()
+	return len(fake.renewArgsForCall)
+}
+
+func (fake *FakeLease) RenewCalls(stub func() error) {
+	fake.renewMutex.Lock()
+	defer fake.renewMutex.Unlock()
+	fake.RenewStub = stub
+}
+
+func (fake *FakeLease) RenewReturns(result1 error) {
+	fake.renewMutex.Lock()
+	defer fake.renewMutex.Unlock()
+	fake.RenewStub = nil
+	fake.renewReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLease) RenewReturnsOnCall(i int, result1 error) {
+	fake.renewMutex.Lock()
+	defer fake.renewMutex.Unlock()
+	fake.RenewStub = nil
+	if fake.renewReturnsOnCall == nil {
+		fake.renewReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.renewReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLease) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.breakMutex.RLock()
+	defer fake.breakMutex.RUnlock()
+	fake.renewMutex.RLock()
+	defer fake.renewMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLease) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.Lease = new(FakeLease)
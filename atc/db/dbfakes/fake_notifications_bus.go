@@ -0,0 +1,608 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeNotificationsBus struct {
+	CloseStub        func() error
+	closeMutex       sync.RWMutex
+	closeArgsForCall []struct {
+	}
+	closeReturns struct {
+		result1 error
+	}
+	closeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DoneStub        func() <-chan struct{}
+	doneMutex       sync.RWMutex
+	doneArgsForCall []struct {
+	}
+	doneReturns struct {
+		result1 <-chan struct{}
+	}
+	doneReturnsOnCall map[int]struct {
+		result1 <-chan struct{}
+	}
+	LastPayloadStub        func(string) (string, bool)
+	lastPayloadMutex       sync.RWMutex
+	lastPayloadArgsForCall []struct {
+		arg1 string
+	}
+	lastPayloadReturns struct {
+		result1 string
+		result2 bool
+	}
+	lastPayloadReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+	}
+	ListenStub        func(string) (chan bool, error)
+	listenMutex       sync.RWMutex
+	listenArgsForCall []struct {
+		arg1 string
+	}
+	listenReturns struct {
+		result1 chan bool
+		result2 error
+	}
+	listenReturnsOnCall map[int]struct {
+		result1 chan bool
+		result2 error
+	}
+	NotifyStub        func(string) error
+	notifyMutex       sync.RWMutex
+	notifyArgsForCall []struct {
+		arg1 string
+	}
+	notifyReturns struct {
+		result1 error
+	}
+	notifyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	NotifyWithPayloadStub        func(string, string) error
+	notifyWithPayloadMutex       sync.RWMutex
+	notifyWithPayloadArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	notifyWithPayloadReturns struct {
+		result1 error
+	}
+	notifyWithPayloadReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StatsStub        func() db.BusStats
+	statsMutex       sync.RWMutex
+	statsArgsForCall []struct {
+	}
+	statsReturns struct {
+		result1 db.BusStats
+	}
+	statsReturnsOnCall map[int]struct {
+		result1 db.BusStats
+	}
+	UnlistenStub        func(string, chan bool) error
+	unlistenMutex       sync.RWMutex
+	unlistenArgsForCall []struct {
+		arg1 string
+		arg2 chan bool
+	}
+	unlistenReturns struct {
+		result1 error
+	}
+	unlistenReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeNotificationsBus) Close() error {
+	fake.closeMutex.Lock()
+	ret, specificReturn := fake.closeReturnsOnCall[len(fake.closeArgsForCall)]
+	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Close", []interface{}{})
+	fake.closeMutex.Unlock()
+	if fake.CloseStub != nil {
+		return fake.CloseStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.closeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationsBus) CloseCallCount() int {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return len(fake.closeArgsForCall)
+}
+
+func (fake *FakeNotificationsBus) CloseCalls(stub func() error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = stub
+}
+
+func (fake *FakeNotificationsBus) CloseReturns(result1 error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = nil
+	fake.closeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) CloseReturnsOnCall(i int, result1 error) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = nil
+	if fake.closeReturnsOnCall == nil {
+		fake.closeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.closeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) Done() <-chan struct{} {
+	fake.doneMutex.Lock()
+	ret, specificReturn := fake.doneReturnsOnCall[len(fake.doneArgsForCall)]
+	fake.doneArgsForCall = append(fake.doneArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Done", []interface{}{})
+	fake.doneMutex.Unlock()
+	if fake.DoneStub != nil {
+		return fake.DoneStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.doneReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationsBus) DoneCallCount() int {
+	fake.doneMutex.RLock()
+	defer fake.doneMutex.RUnlock()
+	return len(fake.doneArgsForCall)
+}
+
+func (fake *FakeNotificationsBus) DoneCalls(stub func() <-chan struct{}) {
+	fake.doneMutex.Lock()
+	defer fake.doneMutex.Unlock()
+	fake.DoneStub = stub
+}
+
+func (fake *FakeNotificationsBus) DoneReturns(result1 <-chan struct{}) {
+	fake.doneMutex.Lock()
+	defer fake.doneMutex.Unlock()
+	fake.DoneStub = nil
+	fake.doneReturns = struct {
+		result1 <-chan struct{}
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) DoneReturnsOnCall(i int, result1 <-chan struct{}) {
+	fake.doneMutex.Lock()
+	defer fake.doneMutex.Unlock()
+	fake.DoneStub = nil
+	if fake.doneReturnsOnCall == nil {
+		fake.doneReturnsOnCall = make(map[int]struct {
+			result1 <-chan struct{}
+		})
+	}
+	fake.doneReturnsOnCall[i] = struct {
+		result1 <-chan struct{}
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) LastPayload(arg1 string) (string, bool) {
+	fake.lastPayloadMutex.Lock()
+	ret, specificReturn := fake.lastPayloadReturnsOnCall[len(fake.lastPayloadArgsForCall)]
+	fake.lastPayloadArgsForCall = append(fake.lastPayloadArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("LastPayload", []interface{}{arg1})
+	fake.lastPayloadMutex.Unlock()
+	if fake.LastPayloadStub != nil {
+		return fake.LastPayloadStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.lastPayloadReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNotificationsBus) LastPayloadCallCount() int {
+	fake.lastPayloadMutex.RLock()
+	defer fake.lastPayloadMutex.RUnlock()
+	return len(fake.lastPayloadArgsForCall)
+}
+
+func (fake *FakeNotificationsBus) LastPayloadCalls(stub func(string) (string, bool)) {
+	fake.lastPayloadMutex.Lock()
+	defer fake.lastPayloadMutex.Unlock()
+	fake.LastPayloadStub = stub
+}
+
+func (fake *FakeNotificationsBus) LastPayloadArgsForCall(i int) string {
+	fake.lastPayloadMutex.RLock()
+	defer fake.lastPayloadMutex.RUnlock()
+	argsForCall := fake.lastPayloadArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNotificationsBus) LastPayloadReturns(result1 string, result2 bool) {
+	fake.lastPayloadMutex.Lock()
+	defer fake.lastPayloadMutex.Unlock()
+	fake.LastPayloadStub = nil
+	fake.lastPayloadReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeNotificationsBus) LastPayloadReturnsOnCall(i int, result1 string, result2 bool) {
+	fake.lastPayloadMutex.Lock()
+	defer fake.lastPayloadMutex.Unlock()
+	fake.LastPayloadStub = nil
+	if fake.lastPayloadReturnsOnCall == nil {
+		fake.lastPayloadReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+		})
+	}
+	fake.lastPayloadReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeNotificationsBus) Listen(arg1 string) (chan bool, error) {
+	fake.listenMutex.Lock()
+	ret, specificReturn := fake.listenReturnsOnCall[len(fake.listenArgsForCall)]
+	fake.listenArgsForCall = append(fake.listenArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Listen", []interface{}{arg1})
+	fake.listenMutex.Unlock()
+	if fake.ListenStub != nil {
+		return fake.ListenStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listenReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNotificationsBus) ListenCallCount() int {
+	fake.listenMutex.RLock()
+	defer fake.listenMutex.RUnlock()
+	return len(fake.listenArgsForCall)
+}
+
+func (fake *FakeNotificationsBus) ListenCalls(stub func(string) (chan bool, error)) {
+	fake.listenMutex.Lock()
+	defer fake.listenMutex.Unlock()
+	fake.ListenStub = stub
+}
+
+func (fake *FakeNotificationsBus) ListenArgsForCall(i int) string {
+	fake.listenMutex.RLock()
+	defer fake.listenMutex.RUnlock()
+	argsForCall := fake.listenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNotificationsBus) ListenReturns(result1 chan bool, result2 error) {
+	fake.listenMutex.Lock()
+	defer fake.listenMutex.Unlock()
+	fake.ListenStub = nil
+	fake.listenReturns = struct {
+		result1 chan bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNotificationsBus) ListenReturnsOnCall(i int, result1 chan bool, result2 error) {
+	fake.listenMutex.Lock()
+	defer fake.listenMutex.Unlock()
+	fake.ListenStub = nil
+	if fake.listenReturnsOnCall == nil {
+		fake.listenReturnsOnCall = make(map[int]struct {
+			result1 chan bool
+			result2 error
+		})
+	}
+	fake.listenReturnsOnCall[i] = struct {
+		result1 chan bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNotificationsBus) Notify(arg1 string) error {
+	fake.notifyMutex.Lock()
+	ret, specificReturn := fake.notifyReturnsOnCall[len(fake.notifyArgsForCall)]
+	fake.notifyArgsForCall = append(fake.notifyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Notify", []interface{}{arg1})
+	fake.notifyMutex.Unlock()
+	if fake.NotifyStub != nil {
+		return fake.NotifyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.notifyReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationsBus) NotifyCallCount() int {
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
+	return len(fake.notifyArgsForCall)
+}
+
+func (fake *FakeNotificationsBus) NotifyCalls(stub func(string) error) {
+	fake.notifyMutex.Lock()
+	defer fake.notifyMutex.Unlock()
+	fake.NotifyStub = stub
+}
+
+func (fake *FakeNotificationsBus) NotifyArgsForCall(i int) string {
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
+	argsForCall := fake.notifyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNotificationsBus) NotifyReturns(result1 error) {
+	fake.notifyMutex.Lock()
+	defer fake.notifyMutex.Unlock()
+	fake.NotifyStub = nil
+	fake.notifyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) NotifyReturnsOnCall(i int, result1 error) {
+	fake.notifyMutex.Lock()
+	defer fake.notifyMutex.Unlock()
+	fake.NotifyStub = nil
+	if fake.notifyReturnsOnCall == nil {
+		fake.notifyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.notifyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) NotifyWithPayload(arg1 string, arg2 string) error {
+	fake.notifyWithPayloadMutex.Lock()
+	ret, specificReturn := fake.notifyWithPayloadReturnsOnCall[len(fake.notifyWithPayloadArgsForCall)]
+	fake.notifyWithPayloadArgsForCall = append(fake.notifyWithPayloadArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("NotifyWithPayload", []interface{}{arg1, arg2})
+	fake.notifyWithPayloadMutex.Unlock()
+	if fake.NotifyWithPayloadStub != nil {
+		return fake.NotifyWithPayloadStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.notifyWithPayloadReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationsBus) NotifyWithPayloadCallCount() int {
+	fake.notifyWithPayloadMutex.RLock()
+	defer fake.notifyWithPayloadMutex.RUnlock()
+	return len(fake.notifyWithPayloadArgsForCall)
+}
+
+func (fake *FakeNotificationsBus) NotifyWithPayloadCalls(stub func(string, string) error) {
+	fake.notifyWithPayloadMutex.Lock()
+	defer fake.notifyWithPayloadMutex.Unlock()
+	fake.NotifyWithPayloadStub = stub
+}
+
+func (fake *FakeNotificationsBus) NotifyWithPayloadArgsForCall(i int) (string, string) {
+	fake.notifyWithPayloadMutex.RLock()
+	defer fake.notifyWithPayloadMutex.RUnlock()
+	argsForCall := fake.notifyWithPayloadArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNotificationsBus) NotifyWithPayloadReturns(result1 error) {
+	fake.notifyWithPayloadMutex.Lock()
+	defer fake.notifyWithPayloadMutex.Unlock()
+	fake.NotifyWithPayloadStub = nil
+	fake.notifyWithPayloadReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) NotifyWithPayloadReturnsOnCall(i int, result1 error) {
+	fake.notifyWithPayloadMutex.Lock()
+	defer fake.notifyWithPayloadMutex.Unlock()
+	fake.NotifyWithPayloadStub = nil
+	if fake.notifyWithPayloadReturnsOnCall == nil {
+		fake.notifyWithPayloadReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.notifyWithPayloadReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) Stats() db.BusStats {
+	fake.statsMutex.Lock()
+	ret, specificReturn := fake.statsReturnsOnCall[len(fake.statsArgsForCall)]
+	fake.statsArgsForCall = append(fake.statsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Stats", []interface{}{})
+	fake.statsMutex.Unlock()
+	if fake.StatsStub != nil {
+		return fake.StatsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.statsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationsBus) StatsCallCount() int {
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	return len(fake.statsArgsForCall)
+}
+
+func (fake *FakeNotificationsBus) StatsCalls(stub func() db.BusStats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = stub
+}
+
+func (fake *FakeNotificationsBus) StatsReturns(result1 db.BusStats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	fake.statsReturns = struct {
+		result1 db.BusStats
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) StatsReturnsOnCall(i int, result1 db.BusStats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	if fake.statsReturnsOnCall == nil {
+		fake.statsReturnsOnCall = make(map[int]struct {
+			result1 db.BusStats
+		})
+	}
+	fake.statsReturnsOnCall[i] = struct {
+		result1 db.BusStats
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) Unlisten(arg1 string, arg2 chan bool) error {
+	fake.unlistenMutex.Lock()
+	ret, specificReturn := fake.unlistenReturnsOnCall[len(fake.unlistenArgsForCall)]
+	fake.unlistenArgsForCall = append(fake.unlistenArgsForCall, struct {
+		arg1 string
+		arg2 chan bool
+	}{arg1, arg2})
+	fake.recordInvocation("Unlisten", []interface{}{arg1, arg2})
+	fake.unlistenMutex.Unlock()
+	if fake.UnlistenStub != nil {
+		return fake.UnlistenStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.unlistenReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeNotificationsBus) UnlistenCallCount() int {
+	fake.unlistenMutex.RLock()
+	defer fake.unlistenMutex.RUnlock()
+	return len(fake.unlistenArgsForCall)
+}
+
+func (fake *FakeNotificationsBus) UnlistenCalls(stub func(string, chan bool) error) {
+	fake.unlistenMutex.Lock()
+	defer fake.unlistenMutex.Unlock()
+	fake.UnlistenStub = stub
+}
+
+func (fake *FakeNotificationsBus) UnlistenArgsForCall(i int) (string, chan bool) {
+	fake.unlistenMutex.RLock()
+	defer fake.unlistenMutex.RUnlock()
+	argsForCall := fake.unlistenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNotificationsBus) UnlistenReturns(result1 error) {
+	fake.unlistenMutex.Lock()
+	defer fake.unlistenMutex.Unlock()
+	fake.UnlistenStub = nil
+	fake.unlistenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) UnlistenReturnsOnCall(i int, result1 error) {
+	fake.unlistenMutex.Lock()
+	defer fake.unlistenMutex.Unlock()
+	fake.UnlistenStub = nil
+	if fake.unlistenReturnsOnCall == nil {
+		fake.unlistenReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unlistenReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNotificationsBus) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	fake.doneMutex.RLock()
+	defer fake.doneMutex.RUnlock()
+	fake.lastPayloadMutex.RLock()
+	defer fake.lastPayloadMutex.RUnlock()
+	fake.listenMutex.RLock()
+	defer fake.listenMutex.RUnlock()
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
+	fake.notifyWithPayloadMutex.RLock()
+	defer fake.notifyWithPayloadMutex.RUnlock()
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	fake.unlistenMutex.RLock()
+	defer fake.unlistenMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeNotificationsBus) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.NotificationsBus = new(FakeNotificationsBus)
@@ -2,6 +2,7 @@
 package dbfakes
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -13,6 +14,19 @@ import (
 )
 
 type FakePipeline struct {
+	AbortAllRunningBuildsStub        func(string) ([]int, error)
+	abortAllRunningBuildsMutex       sync.RWMutex
+	abortAllRunningBuildsArgsForCall []struct {
+		arg1 string
+	}
+	abortAllRunningBuildsReturns struct {
+		result1 []int
+		result2 error
+	}
+	abortAllRunningBuildsReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
 	AcquireSchedulingLockStub        func(lager.Logger, time.Duration) (lock.Lock, bool, error)
 	acquireSchedulingLockMutex       sync.RWMutex
 	acquireSchedulingLockArgsForCall []struct {
@@ -29,6 +43,58 @@ type FakePipeline struct {
 		result2 bool
 		result3 error
 	}
+	AcquireSerialGroupLockStub        func(lager.Logger, []string) (lock.Lock, bool, error)
+	acquireSerialGroupLockMutex       sync.RWMutex
+	acquireSerialGroupLockArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 []string
+	}
+	acquireSerialGroupLockReturns struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	acquireSerialGroupLockReturnsOnCall map[int]struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	AcquireSerialLockStub        func(lager.Logger, string) (lock.Lock, bool, error)
+	acquireSerialLockMutex       sync.RWMutex
+	acquireSerialLockArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	acquireSerialLockReturns struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	acquireSerialLockReturnsOnCall map[int]struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	ArchiveStub        func() error
+	archiveMutex       sync.RWMutex
+	archiveArgsForCall []struct {
+	}
+	archiveReturns struct {
+		result1 error
+	}
+	archiveReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ArchivedStub        func() bool
+	archivedMutex       sync.RWMutex
+	archivedArgsForCall []struct {
+	}
+	archivedReturns struct {
+		result1 bool
+	}
+	archivedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	BuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -44,6 +110,20 @@ type FakePipeline struct {
 		result2 db.Pagination
 		result3 error
 	}
+	BuildsWithStatusStub        func([]db.BuildStatus, int) ([]db.Build, error)
+	buildsWithStatusMutex       sync.RWMutex
+	buildsWithStatusArgsForCall []struct {
+		arg1 []db.BuildStatus
+		arg2 int
+	}
+	buildsWithStatusReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	buildsWithStatusReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
 	BuildsWithTimeStub        func(db.Page) ([]db.Build, db.Pagination, error)
 	buildsWithTimeMutex       sync.RWMutex
 	buildsWithTimeArgsForCall []struct {
@@ -59,6 +139,20 @@ type FakePipeline struct {
 		result2 db.Pagination
 		result3 error
 	}
+	BuildsWithTriggerStub        func(db.BuildTrigger, int) ([]db.Build, error)
+	buildsWithTriggerMutex       sync.RWMutex
+	buildsWithTriggerArgsForCall []struct {
+		arg1 db.BuildTrigger
+		arg2 int
+	}
+	buildsWithTriggerReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	buildsWithTriggerReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
 	CausalityStub        func(int) ([]db.Cause, error)
 	causalityMutex       sync.RWMutex
 	causalityArgsForCall []struct {
@@ -84,6 +178,42 @@ type FakePipeline struct {
 		result1 bool
 		result2 error
 	}
+	ConfigStub        func() (atc.Config, error)
+	configMutex       sync.RWMutex
+	configArgsForCall []struct {
+	}
+	configReturns struct {
+		result1 atc.Config
+		result2 error
+	}
+	configReturnsOnCall map[int]struct {
+		result1 atc.Config
+		result2 error
+	}
+	ConfigDiffStub        func(db.ConfigVersion, db.ConfigVersion) (db.ConfigDiff, error)
+	configDiffMutex       sync.RWMutex
+	configDiffArgsForCall []struct {
+		arg1 db.ConfigVersion
+		arg2 db.ConfigVersion
+	}
+	configDiffReturns struct {
+		result1 db.ConfigDiff
+		result2 error
+	}
+	configDiffReturnsOnCall map[int]struct {
+		result1 db.ConfigDiff
+		result2 error
+	}
+	ConfigUpdatedStub        func() time.Time
+	configUpdatedMutex       sync.RWMutex
+	configUpdatedArgsForCall []struct {
+	}
+	configUpdatedReturns struct {
+		result1 time.Time
+	}
+	configUpdatedReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	ConfigVersionStub        func() db.ConfigVersion
 	configVersionMutex       sync.RWMutex
 	configVersionArgsForCall []struct {
@@ -94,6 +224,49 @@ type FakePipeline struct {
 	configVersionReturnsOnCall map[int]struct {
 		result1 db.ConfigVersion
 	}
+	ConsecutiveFailuresStub        func(string) (int, error)
+	consecutiveFailuresMutex       sync.RWMutex
+	consecutiveFailuresArgsForCall []struct {
+		arg1 string
+	}
+	consecutiveFailuresReturns struct {
+		result1 int
+		result2 error
+	}
+	consecutiveFailuresReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	CreateJobBuildStub        func(string, db.BuildTrigger) (db.Build, error)
+	createJobBuildMutex       sync.RWMutex
+	createJobBuildArgsForCall []struct {
+		arg1 string
+		arg2 db.BuildTrigger
+	}
+	createJobBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createJobBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
+	CreateJobBuildWithKeyStub        func(string, string) (db.Build, bool, error)
+	createJobBuildWithKeyMutex       sync.RWMutex
+	createJobBuildWithKeyArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	createJobBuildWithKeyReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	createJobBuildWithKeyReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
 	CreateOneOffBuildStub        func() (db.Build, error)
 	createOneOffBuildMutex       sync.RWMutex
 	createOneOffBuildArgsForCall []struct {
@@ -174,6 +347,37 @@ type FakePipeline struct {
 		result1 map[string][]db.Build
 		result2 error
 	}
+	GetBuildForOutputStub        func(string, atc.Version) (db.Build, bool, error)
+	getBuildForOutputMutex       sync.RWMutex
+	getBuildForOutputArgsForCall []struct {
+		arg1 string
+		arg2 atc.Version
+	}
+	getBuildForOutputReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	getBuildForOutputReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	GetBuildsForInputVersionStub        func(string, atc.Version, int) ([]db.Build, error)
+	getBuildsForInputVersionMutex       sync.RWMutex
+	getBuildsForInputVersionArgsForCall []struct {
+		arg1 string
+		arg2 atc.Version
+		arg3 int
+	}
+	getBuildsForInputVersionReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	getBuildsForInputVersionReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
 	GetBuildsWithVersionAsInputStub        func(int, int) ([]db.Build, error)
 	getBuildsWithVersionAsInputMutex       sync.RWMutex
 	getBuildsWithVersionAsInputArgsForCall []struct {
@@ -202,6 +406,60 @@ type FakePipeline struct {
 		result1 []db.Build
 		result2 error
 	}
+	GetConfigVersionStub        func(db.ConfigVersion) (atc.Config, bool, error)
+	getConfigVersionMutex       sync.RWMutex
+	getConfigVersionArgsForCall []struct {
+		arg1 db.ConfigVersion
+	}
+	getConfigVersionReturns struct {
+		result1 atc.Config
+		result2 bool
+		result3 error
+	}
+	getConfigVersionReturnsOnCall map[int]struct {
+		result1 atc.Config
+		result2 bool
+		result3 error
+	}
+	GetJobsUsingResourceStub        func(string) ([]db.ResourceUsage, error)
+	getJobsUsingResourceMutex       sync.RWMutex
+	getJobsUsingResourceArgsForCall []struct {
+		arg1 string
+	}
+	getJobsUsingResourceReturns struct {
+		result1 []db.ResourceUsage
+		result2 error
+	}
+	getJobsUsingResourceReturnsOnCall map[int]struct {
+		result1 []db.ResourceUsage
+		result2 error
+	}
+	GetVersionCausalityStub        func(int, int) (db.CausalityGraph, error)
+	getVersionCausalityMutex       sync.RWMutex
+	getVersionCausalityArgsForCall []struct {
+		arg1 int
+		arg2 int
+	}
+	getVersionCausalityReturns struct {
+		result1 db.CausalityGraph
+		result2 error
+	}
+	getVersionCausalityReturnsOnCall map[int]struct {
+		result1 db.CausalityGraph
+		result2 error
+	}
+	GraphStub        func() (db.PipelineGraph, error)
+	graphMutex       sync.RWMutex
+	graphArgsForCall []struct {
+	}
+	graphReturns struct {
+		result1 db.PipelineGraph
+		result2 error
+	}
+	graphReturnsOnCall map[int]struct {
+		result1 db.PipelineGraph
+		result2 error
+	}
 	GroupsStub        func() atc.GroupConfigs
 	groupsMutex       sync.RWMutex
 	groupsArgsForCall []struct {
@@ -247,6 +505,21 @@ type FakePipeline struct {
 		result2 bool
 		result3 error
 	}
+	JobStatusStub        func(string) (db.JobStatus, bool, error)
+	jobStatusMutex       sync.RWMutex
+	jobStatusArgsForCall []struct {
+		arg1 string
+	}
+	jobStatusReturns struct {
+		result1 db.JobStatus
+		result2 bool
+		result3 error
+	}
+	jobStatusReturnsOnCall map[int]struct {
+		result1 db.JobStatus
+		result2 bool
+		result3 error
+	}
 	JobsStub        func() (db.Jobs, error)
 	jobsMutex       sync.RWMutex
 	jobsArgsForCall []struct {
@@ -259,6 +532,72 @@ type FakePipeline struct {
 		result1 db.Jobs
 		result2 error
 	}
+	LastUpdatedStub        func() time.Time
+	lastUpdatedMutex       sync.RWMutex
+	lastUpdatedArgsForCall []struct {
+	}
+	lastUpdatedReturns struct {
+		result1 time.Time
+	}
+	lastUpdatedReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	LatestSuccessfulBuildsStub        func() (map[string]db.Build, error)
+	latestSuccessfulBuildsMutex       sync.RWMutex
+	latestSuccessfulBuildsArgsForCall []struct {
+	}
+	latestSuccessfulBuildsReturns struct {
+		result1 map[string]db.Build
+		result2 error
+	}
+	latestSuccessfulBuildsReturnsOnCall map[int]struct {
+		result1 map[string]db.Build
+		result2 error
+	}
+	LeaseResourceCheckingStub        func(string, time.Duration) (db.Lease, bool, error)
+	leaseResourceCheckingMutex       sync.RWMutex
+	leaseResourceCheckingArgsForCall []struct {
+		arg1 string
+		arg2 time.Duration
+	}
+	leaseResourceCheckingReturns struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}
+	leaseResourceCheckingReturnsOnCall map[int]struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}
+	LeaseSchedulingStub        func(time.Duration) (db.Lease, bool, error)
+	leaseSchedulingMutex       sync.RWMutex
+	leaseSchedulingArgsForCall []struct {
+		arg1 time.Duration
+	}
+	leaseSchedulingReturns struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}
+	leaseSchedulingReturnsOnCall map[int]struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}
+	ListConfigVersionsStub        func(int) ([]db.ConfigVersion, error)
+	listConfigVersionsMutex       sync.RWMutex
+	listConfigVersionsArgsForCall []struct {
+		arg1 int
+	}
+	listConfigVersionsReturns struct {
+		result1 []db.ConfigVersion
+		result2 error
+	}
+	listConfigVersionsReturnsOnCall map[int]struct {
+		result1 []db.ConfigVersion
+		result2 error
+	}
 	LoadVersionsDBStub        func() (*algorithm.VersionsDB, error)
 	loadVersionsDBMutex       sync.RWMutex
 	loadVersionsDBArgsForCall []struct {
@@ -271,6 +610,19 @@ type FakePipeline struct {
 		result1 *algorithm.VersionsDB
 		result2 error
 	}
+	LoadVersionsDBContextStub        func(context.Context) (*algorithm.VersionsDB, error)
+	loadVersionsDBContextMutex       sync.RWMutex
+	loadVersionsDBContextArgsForCall []struct {
+		arg1 context.Context
+	}
+	loadVersionsDBContextReturns struct {
+		result1 *algorithm.VersionsDB
+		result2 error
+	}
+	loadVersionsDBContextReturnsOnCall map[int]struct {
+		result1 *algorithm.VersionsDB
+		result2 error
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -281,9 +633,10 @@ type FakePipeline struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
-	PauseStub        func() error
+	PauseStub        func(string) error
 	pauseMutex       sync.RWMutex
 	pauseArgsForCall []struct {
+		arg1 string
 	}
 	pauseReturns struct {
 		result1 error
@@ -291,6 +644,18 @@ type FakePipeline struct {
 	pauseReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PauseHistoryStub        func() ([]db.PauseEvent, error)
+	pauseHistoryMutex       sync.RWMutex
+	pauseHistoryArgsForCall []struct {
+	}
+	pauseHistoryReturns struct {
+		result1 []db.PauseEvent
+		result2 error
+	}
+	pauseHistoryReturnsOnCall map[int]struct {
+		result1 []db.PauseEvent
+		result2 error
+	}
 	PausedStub        func() bool
 	pausedMutex       sync.RWMutex
 	pausedArgsForCall []struct {
@@ -334,6 +699,19 @@ type FakePipeline struct {
 	renameReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RerunBuildStub        func(int) (db.Build, error)
+	rerunBuildMutex       sync.RWMutex
+	rerunBuildArgsForCall []struct {
+		arg1 int
+	}
+	rerunBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	rerunBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	ResourceStub        func(string) (db.Resource, bool, error)
 	resourceMutex       sync.RWMutex
 	resourceArgsForCall []struct {
@@ -364,6 +742,20 @@ type FakePipeline struct {
 		result2 bool
 		result3 error
 	}
+	ResourceReuseStatsStub        func(string, time.Time) (db.ReuseStats, error)
+	resourceReuseStatsMutex       sync.RWMutex
+	resourceReuseStatsArgsForCall []struct {
+		arg1 string
+		arg2 time.Time
+	}
+	resourceReuseStatsReturns struct {
+		result1 db.ReuseStats
+		result2 error
+	}
+	resourceReuseStatsReturnsOnCall map[int]struct {
+		result1 db.ReuseStats
+		result2 error
+	}
 	ResourceTypeStub        func(string) (db.ResourceType, bool, error)
 	resourceTypeMutex       sync.RWMutex
 	resourceTypeArgsForCall []struct {
@@ -433,6 +825,45 @@ type FakePipeline struct {
 		result1 db.Resources
 		result2 error
 	}
+	RevertConfigStub        func(db.ConfigVersion) (db.ConfigVersion, error)
+	revertConfigMutex       sync.RWMutex
+	revertConfigArgsForCall []struct {
+		arg1 db.ConfigVersion
+	}
+	revertConfigReturns struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	revertConfigReturnsOnCall map[int]struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	RunningBuildCountStub        func() (int, error)
+	runningBuildCountMutex       sync.RWMutex
+	runningBuildCountArgsForCall []struct {
+	}
+	runningBuildCountReturns struct {
+		result1 int
+		result2 error
+	}
+	runningBuildCountReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	SchedulingLeaseHolderStub        func() (string, bool, error)
+	schedulingLeaseHolderMutex       sync.RWMutex
+	schedulingLeaseHolderArgsForCall []struct {
+	}
+	schedulingLeaseHolderReturns struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	schedulingLeaseHolderReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
 	TeamIDStub        func() int
 	teamIDMutex       sync.RWMutex
 	teamIDArgsForCall []struct {
@@ -453,9 +884,20 @@ type FakePipeline struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
-	UnpauseStub        func() error
+	UnarchiveStub        func() error
+	unarchiveMutex       sync.RWMutex
+	unarchiveArgsForCall []struct {
+	}
+	unarchiveReturns struct {
+		result1 error
+	}
+	unarchiveReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UnpauseStub        func(string) error
 	unpauseMutex       sync.RWMutex
 	unpauseArgsForCall []struct {
+		arg1 string
 	}
 	unpauseReturns struct {
 		result1 error
@@ -463,16 +905,120 @@ type FakePipeline struct {
 	unpauseReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateJobStub        func(string, atc.JobConfig) (db.ConfigVersion, error)
+	updateJobMutex       sync.RWMutex
+	updateJobArgsForCall []struct {
+		arg1 string
+		arg2 atc.JobConfig
+	}
+	updateJobReturns struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	updateJobReturnsOnCall map[int]struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	UpdateResourceConfigStub        func(string, atc.Source) (db.ConfigVersion, error)
+	updateResourceConfigMutex       sync.RWMutex
+	updateResourceConfigArgsForCall []struct {
+		arg1 string
+		arg2 atc.Source
+	}
+	updateResourceConfigReturns struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	updateResourceConfigReturnsOnCall map[int]struct {
+		result1 db.ConfigVersion
+		result2 error
+	}
+	UpdateVersionMetadataStub        func(string, atc.Version, db.ResourceConfigMetadataFields) error
+	updateVersionMetadataMutex       sync.RWMutex
+	updateVersionMetadataArgsForCall []struct {
+		arg1 string
+		arg2 atc.Version
+		arg3 db.ResourceConfigMetadataFields
+	}
+	updateVersionMetadataReturns struct {
+		result1 error
+	}
+	updateVersionMetadataReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakePipeline) AcquireSchedulingLock(arg1 lager.Logger, arg2 time.Duration) (lock.Lock, bool, error) {
-	fake.acquireSchedulingLockMutex.Lock()
-	ret, specificReturn := fake.acquireSchedulingLockReturnsOnCall[len(fake.acquireSchedulingLockArgsForCall)]
-	fake.acquireSchedulingLockArgsForCall = append(fake.acquireSchedulingLockArgsForCall, struct {
-		arg1 lager.Logger
-		arg2 time.Duration
+func (fake *FakePipeline) AbortAllRunningBuilds(arg1 string) ([]int, error) {
+	fake.abortAllRunningBuildsMutex.Lock()
+	ret, specificReturn := fake.abortAllRunningBuildsReturnsOnCall[len(fake.abortAllRunningBuildsArgsForCall)]
+	fake.abortAllRunningBuildsArgsForCall = append(fake.abortAllRunningBuildsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("AbortAllRunningBuilds", []interface{}{arg1})
+	fake.abortAllRunningBuildsMutex.Unlock()
+	if fake.AbortAllRunningBuildsStub != nil {
+		return fake.AbortAllRunningBuildsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.abortAllRunningBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) AbortAllRunningBuildsCallCount() int {
+	fake.abortAllRunningBuildsMutex.RLock()
+	defer fake.abortAllRunningBuildsMutex.RUnlock()
+	return len(fake.abortAllRunningBuildsArgsForCall)
+}
+
+func (fake *FakePipeline) AbortAllRunningBuildsCalls(stub func(string) ([]int, error)) {
+	fake.abortAllRunningBuildsMutex.Lock()
+	defer fake.abortAllRunningBuildsMutex.Unlock()
+	fake.AbortAllRunningBuildsStub = stub
+}
+
+func (fake *FakePipeline) AbortAllRunningBuildsArgsForCall(i int) string {
+	fake.abortAllRunningBuildsMutex.RLock()
+	defer fake.abortAllRunningBuildsMutex.RUnlock()
+	argsForCall := fake.abortAllRunningBuildsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) AbortAllRunningBuildsReturns(result1 []int, result2 error) {
+	fake.abortAllRunningBuildsMutex.Lock()
+	defer fake.abortAllRunningBuildsMutex.Unlock()
+	fake.AbortAllRunningBuildsStub = nil
+	fake.abortAllRunningBuildsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) AbortAllRunningBuildsReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.abortAllRunningBuildsMutex.Lock()
+	defer fake.abortAllRunningBuildsMutex.Unlock()
+	fake.AbortAllRunningBuildsStub = nil
+	if fake.abortAllRunningBuildsReturnsOnCall == nil {
+		fake.abortAllRunningBuildsReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.abortAllRunningBuildsReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) AcquireSchedulingLock(arg1 lager.Logger, arg2 time.Duration) (lock.Lock, bool, error) {
+	fake.acquireSchedulingLockMutex.Lock()
+	ret, specificReturn := fake.acquireSchedulingLockReturnsOnCall[len(fake.acquireSchedulingLockArgsForCall)]
+	fake.acquireSchedulingLockArgsForCall = append(fake.acquireSchedulingLockArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 time.Duration
 	}{arg1, arg2})
 	fake.recordInvocation("AcquireSchedulingLock", []interface{}{arg1, arg2})
 	fake.acquireSchedulingLockMutex.Unlock()
@@ -534,6 +1080,249 @@ func (fake *FakePipeline) AcquireSchedulingLockReturnsOnCall(i int, result1 lock
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipeline) AcquireSerialGroupLock(arg1 lager.Logger, arg2 []string) (lock.Lock, bool, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.acquireSerialGroupLockMutex.Lock()
+	ret, specificReturn := fake.acquireSerialGroupLockReturnsOnCall[len(fake.acquireSerialGroupLockArgsForCall)]
+	fake.acquireSerialGroupLockArgsForCall = append(fake.acquireSerialGroupLockArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 []string
+	}{arg1, arg2Copy})
+	fake.recordInvocation("AcquireSerialGroupLock", []interface{}{arg1, arg2Copy})
+	fake.acquireSerialGroupLockMutex.Unlock()
+	if fake.AcquireSerialGroupLockStub != nil {
+		return fake.AcquireSerialGroupLockStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.acquireSerialGroupLockReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) AcquireSerialGroupLockCallCount() int {
+	fake.acquireSerialGroupLockMutex.RLock()
+	defer fake.acquireSerialGroupLockMutex.RUnlock()
+	return len(fake.acquireSerialGroupLockArgsForCall)
+}
+
+func (fake *FakePipeline) AcquireSerialGroupLockCalls(stub func(lager.Logger, []string) (lock.Lock, bool, error)) {
+	fake.acquireSerialGroupLockMutex.Lock()
+	defer fake.acquireSerialGroupLockMutex.Unlock()
+	fake.AcquireSerialGroupLockStub = stub
+}
+
+func (fake *FakePipeline) AcquireSerialGroupLockArgsForCall(i int) (lager.Logger, []string) {
+	fake.acquireSerialGroupLockMutex.RLock()
+	defer fake.acquireSerialGroupLockMutex.RUnlock()
+	argsForCall := fake.acquireSerialGroupLockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) AcquireSerialGroupLockReturns(result1 lock.Lock, result2 bool, result3 error) {
+	fake.acquireSerialGroupLockMutex.Lock()
+	defer fake.acquireSerialGroupLockMutex.Unlock()
+	fake.AcquireSerialGroupLockStub = nil
+	fake.acquireSerialGroupLockReturns = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) AcquireSerialGroupLockReturnsOnCall(i int, result1 lock.Lock, result2 bool, result3 error) {
+	fake.acquireSerialGroupLockMutex.Lock()
+	defer fake.acquireSerialGroupLockMutex.Unlock()
+	fake.AcquireSerialGroupLockStub = nil
+	if fake.acquireSerialGroupLockReturnsOnCall == nil {
+		fake.acquireSerialGroupLockReturnsOnCall = make(map[int]struct {
+			result1 lock.Lock
+			result2 bool
+			result3 error
+		})
+	}
+	fake.acquireSerialGroupLockReturnsOnCall[i] = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) AcquireSerialLock(arg1 lager.Logger, arg2 string) (lock.Lock, bool, error) {
+	fake.acquireSerialLockMutex.Lock()
+	ret, specificReturn := fake.acquireSerialLockReturnsOnCall[len(fake.acquireSerialLockArgsForCall)]
+	fake.acquireSerialLockArgsForCall = append(fake.acquireSerialLockArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("AcquireSerialLock", []interface{}{arg1, arg2})
+	fake.acquireSerialLockMutex.Unlock()
+	if fake.AcquireSerialLockStub != nil {
+		return fake.AcquireSerialLockStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.acquireSerialLockReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) AcquireSerialLockCallCount() int {
+	fake.acquireSerialLockMutex.RLock()
+	defer fake.acquireSerialLockMutex.RUnlock()
+	return len(fake.acquireSerialLockArgsForCall)
+}
+
+func (fake *FakePipeline) AcquireSerialLockCalls(stub func(lager.Logger, string) (lock.Lock, bool, error)) {
+	fake.acquireSerialLockMutex.Lock()
+	defer fake.acquireSerialLockMutex.Unlock()
+	fake.AcquireSerialLockStub = stub
+}
+
+func (fake *FakePipeline) AcquireSerialLockArgsForCall(i int) (lager.Logger, string) {
+	fake.acquireSerialLockMutex.RLock()
+	defer fake.acquireSerialLockMutex.RUnlock()
+	argsForCall := fake.acquireSerialLockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) AcquireSerialLockReturns(result1 lock.Lock, result2 bool, result3 error) {
+	fake.acquireSerialLockMutex.Lock()
+	defer fake.acquireSerialLockMutex.Unlock()
+	fake.AcquireSerialLockStub = nil
+	fake.acquireSerialLockReturns = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) AcquireSerialLockReturnsOnCall(i int, result1 lock.Lock, result2 bool, result3 error) {
+	fake.acquireSerialLockMutex.Lock()
+	defer fake.acquireSerialLockMutex.Unlock()
+	fake.AcquireSerialLockStub = nil
+	if fake.acquireSerialLockReturnsOnCall == nil {
+		fake.acquireSerialLockReturnsOnCall = make(map[int]struct {
+			result1 lock.Lock
+			result2 bool
+			result3 error
+		})
+	}
+	fake.acquireSerialLockReturnsOnCall[i] = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) Archive() error {
+	fake.archiveMutex.Lock()
+	ret, specificReturn := fake.archiveReturnsOnCall[len(fake.archiveArgsForCall)]
+	fake.archiveArgsForCall = append(fake.archiveArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Archive", []interface{}{})
+	fake.archiveMutex.Unlock()
+	if fake.ArchiveStub != nil {
+		return fake.ArchiveStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.archiveReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ArchiveCallCount() int {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return len(fake.archiveArgsForCall)
+}
+
+func (fake *FakePipeline) ArchiveCalls(stub func() error) {
+	fake.archiveMutex.Lock()
+	defer fake.archiveMutex.Unlock()
+	fake.ArchiveStub = stub
+}
+
+func (fake *FakePipeline) ArchiveReturns(result1 error) {
+	fake.archiveMutex.Lock()
+	defer fake.archiveMutex.Unlock()
+	fake.ArchiveStub = nil
+	fake.archiveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) ArchiveReturnsOnCall(i int, result1 error) {
+	fake.archiveMutex.Lock()
+	defer fake.archiveMutex.Unlock()
+	fake.ArchiveStub = nil
+	if fake.archiveReturnsOnCall == nil {
+		fake.archiveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.archiveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) Archived() bool {
+	fake.archivedMutex.Lock()
+	ret, specificReturn := fake.archivedReturnsOnCall[len(fake.archivedArgsForCall)]
+	fake.archivedArgsForCall = append(fake.archivedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Archived", []interface{}{})
+	fake.archivedMutex.Unlock()
+	if fake.ArchivedStub != nil {
+		return fake.ArchivedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.archivedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ArchivedCallCount() int {
+	fake.archivedMutex.RLock()
+	defer fake.archivedMutex.RUnlock()
+	return len(fake.archivedArgsForCall)
+}
+
+func (fake *FakePipeline) ArchivedCalls(stub func() bool) {
+	fake.archivedMutex.Lock()
+	defer fake.archivedMutex.Unlock()
+	fake.ArchivedStub = stub
+}
+
+func (fake *FakePipeline) ArchivedReturns(result1 bool) {
+	fake.archivedMutex.Lock()
+	defer fake.archivedMutex.Unlock()
+	fake.ArchivedStub = nil
+	fake.archivedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakePipeline) ArchivedReturnsOnCall(i int, result1 bool) {
+	fake.archivedMutex.Lock()
+	defer fake.archivedMutex.Unlock()
+	fake.ArchivedStub = nil
+	if fake.archivedReturnsOnCall == nil {
+		fake.archivedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.archivedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakePipeline) Builds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -600,6 +1389,75 @@ func (fake *FakePipeline) BuildsReturnsOnCall(i int, result1 []db.Build, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipeline) BuildsWithStatus(arg1 []db.BuildStatus, arg2 int) ([]db.Build, error) {
+	var arg1Copy []db.BuildStatus
+	if arg1 != nil {
+		arg1Copy = make([]db.BuildStatus, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.buildsWithStatusMutex.Lock()
+	ret, specificReturn := fake.buildsWithStatusReturnsOnCall[len(fake.buildsWithStatusArgsForCall)]
+	fake.buildsWithStatusArgsForCall = append(fake.buildsWithStatusArgsForCall, struct {
+		arg1 []db.BuildStatus
+		arg2 int
+	}{arg1Copy, arg2})
+	fake.recordInvocation("BuildsWithStatus", []interface{}{arg1Copy, arg2})
+	fake.buildsWithStatusMutex.Unlock()
+	if fake.BuildsWithStatusStub != nil {
+		return fake.BuildsWithStatusStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.buildsWithStatusReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) BuildsWithStatusCallCount() int {
+	fake.buildsWithStatusMutex.RLock()
+	defer fake.buildsWithStatusMutex.RUnlock()
+	return len(fake.buildsWithStatusArgsForCall)
+}
+
+func (fake *FakePipeline) BuildsWithStatusCalls(stub func([]db.BuildStatus, int) ([]db.Build, error)) {
+	fake.buildsWithStatusMutex.Lock()
+	defer fake.buildsWithStatusMutex.Unlock()
+	fake.BuildsWithStatusStub = stub
+}
+
+func (fake *FakePipeline) BuildsWithStatusArgsForCall(i int) ([]db.BuildStatus, int) {
+	fake.buildsWithStatusMutex.RLock()
+	defer fake.buildsWithStatusMutex.RUnlock()
+	argsForCall := fake.buildsWithStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) BuildsWithStatusReturns(result1 []db.Build, result2 error) {
+	fake.buildsWithStatusMutex.Lock()
+	defer fake.buildsWithStatusMutex.Unlock()
+	fake.BuildsWithStatusStub = nil
+	fake.buildsWithStatusReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) BuildsWithStatusReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.buildsWithStatusMutex.Lock()
+	defer fake.buildsWithStatusMutex.Unlock()
+	fake.BuildsWithStatusStub = nil
+	if fake.buildsWithStatusReturnsOnCall == nil {
+		fake.buildsWithStatusReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.buildsWithStatusReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) BuildsWithTime(arg1 db.Page) ([]db.Build, db.Pagination, error) {
 	fake.buildsWithTimeMutex.Lock()
 	ret, specificReturn := fake.buildsWithTimeReturnsOnCall[len(fake.buildsWithTimeArgsForCall)]
@@ -666,6 +1524,70 @@ func (fake *FakePipeline) BuildsWithTimeReturnsOnCall(i int, result1 []db.Build,
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipeline) BuildsWithTrigger(arg1 db.BuildTrigger, arg2 int) ([]db.Build, error) {
+	fake.buildsWithTriggerMutex.Lock()
+	ret, specificReturn := fake.buildsWithTriggerReturnsOnCall[len(fake.buildsWithTriggerArgsForCall)]
+	fake.buildsWithTriggerArgsForCall = append(fake.buildsWithTriggerArgsForCall, struct {
+		arg1 db.BuildTrigger
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("BuildsWithTrigger", []interface{}{arg1, arg2})
+	fake.buildsWithTriggerMutex.Unlock()
+	if fake.BuildsWithTriggerStub != nil {
+		return fake.BuildsWithTriggerStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.buildsWithTriggerReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) BuildsWithTriggerCallCount() int {
+	fake.buildsWithTriggerMutex.RLock()
+	defer fake.buildsWithTriggerMutex.RUnlock()
+	return len(fake.buildsWithTriggerArgsForCall)
+}
+
+func (fake *FakePipeline) BuildsWithTriggerCalls(stub func(db.BuildTrigger, int) ([]db.Build, error)) {
+	fake.buildsWithTriggerMutex.Lock()
+	defer fake.buildsWithTriggerMutex.Unlock()
+	fake.BuildsWithTriggerStub = stub
+}
+
+func (fake *FakePipeline) BuildsWithTriggerArgsForCall(i int) (db.BuildTrigger, int) {
+	fake.buildsWithTriggerMutex.RLock()
+	defer fake.buildsWithTriggerMutex.RUnlock()
+	argsForCall := fake.buildsWithTriggerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) BuildsWithTriggerReturns(result1 []db.Build, result2 error) {
+	fake.buildsWithTriggerMutex.Lock()
+	defer fake.buildsWithTriggerMutex.Unlock()
+	fake.BuildsWithTriggerStub = nil
+	fake.buildsWithTriggerReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) BuildsWithTriggerReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.buildsWithTriggerMutex.Lock()
+	defer fake.buildsWithTriggerMutex.Unlock()
+	fake.BuildsWithTriggerStub = nil
+	if fake.buildsWithTriggerReturnsOnCall == nil {
+		fake.buildsWithTriggerReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.buildsWithTriggerReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) Causality(arg1 int) ([]db.Cause, error) {
 	fake.causalityMutex.Lock()
 	ret, specificReturn := fake.causalityReturnsOnCall[len(fake.causalityArgsForCall)]
@@ -784,6 +1706,177 @@ func (fake *FakePipeline) CheckPausedReturnsOnCall(i int, result1 bool, result2
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) Config() (atc.Config, error) {
+	fake.configMutex.Lock()
+	ret, specificReturn := fake.configReturnsOnCall[len(fake.configArgsForCall)]
+	fake.configArgsForCall = append(fake.configArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Config", []interface{}{})
+	fake.configMutex.Unlock()
+	if fake.ConfigStub != nil {
+		return fake.ConfigStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.configReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) ConfigCallCount() int {
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	return len(fake.configArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigCalls(stub func() (atc.Config, error)) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = stub
+}
+
+func (fake *FakePipeline) ConfigReturns(result1 atc.Config, result2 error) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = nil
+	fake.configReturns = struct {
+		result1 atc.Config
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigReturnsOnCall(i int, result1 atc.Config, result2 error) {
+	fake.configMutex.Lock()
+	defer fake.configMutex.Unlock()
+	fake.ConfigStub = nil
+	if fake.configReturnsOnCall == nil {
+		fake.configReturnsOnCall = make(map[int]struct {
+			result1 atc.Config
+			result2 error
+		})
+	}
+	fake.configReturnsOnCall[i] = struct {
+		result1 atc.Config
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigDiff(arg1 db.ConfigVersion, arg2 db.ConfigVersion) (db.ConfigDiff, error) {
+	fake.configDiffMutex.Lock()
+	ret, specificReturn := fake.configDiffReturnsOnCall[len(fake.configDiffArgsForCall)]
+	fake.configDiffArgsForCall = append(fake.configDiffArgsForCall, struct {
+		arg1 db.ConfigVersion
+		arg2 db.ConfigVersion
+	}{arg1, arg2})
+	fake.recordInvocation("ConfigDiff", []interface{}{arg1, arg2})
+	fake.configDiffMutex.Unlock()
+	if fake.ConfigDiffStub != nil {
+		return fake.ConfigDiffStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.configDiffReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) ConfigDiffCallCount() int {
+	fake.configDiffMutex.RLock()
+	defer fake.configDiffMutex.RUnlock()
+	return len(fake.configDiffArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigDiffCalls(stub func(db.ConfigVersion, db.ConfigVersion) (db.ConfigDiff, error)) {
+	fake.configDiffMutex.Lock()
+	defer fake.configDiffMutex.Unlock()
+	fake.ConfigDiffStub = stub
+}
+
+func (fake *FakePipeline) ConfigDiffArgsForCall(i int) (db.ConfigVersion, db.ConfigVersion) {
+	fake.configDiffMutex.RLock()
+	defer fake.configDiffMutex.RUnlock()
+	argsForCall := fake.configDiffArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) ConfigDiffReturns(result1 db.ConfigDiff, result2 error) {
+	fake.configDiffMutex.Lock()
+	defer fake.configDiffMutex.Unlock()
+	fake.ConfigDiffStub = nil
+	fake.configDiffReturns = struct {
+		result1 db.ConfigDiff
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigDiffReturnsOnCall(i int, result1 db.ConfigDiff, result2 error) {
+	fake.configDiffMutex.Lock()
+	defer fake.configDiffMutex.Unlock()
+	fake.ConfigDiffStub = nil
+	if fake.configDiffReturnsOnCall == nil {
+		fake.configDiffReturnsOnCall = make(map[int]struct {
+			result1 db.ConfigDiff
+			result2 error
+		})
+	}
+	fake.configDiffReturnsOnCall[i] = struct {
+		result1 db.ConfigDiff
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigUpdated() time.Time {
+	fake.configUpdatedMutex.Lock()
+	ret, specificReturn := fake.configUpdatedReturnsOnCall[len(fake.configUpdatedArgsForCall)]
+	fake.configUpdatedArgsForCall = append(fake.configUpdatedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ConfigUpdated", []interface{}{})
+	fake.configUpdatedMutex.Unlock()
+	if fake.ConfigUpdatedStub != nil {
+		return fake.ConfigUpdatedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.configUpdatedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ConfigUpdatedCallCount() int {
+	fake.configUpdatedMutex.RLock()
+	defer fake.configUpdatedMutex.RUnlock()
+	return len(fake.configUpdatedArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigUpdatedCalls(stub func() time.Time) {
+	fake.configUpdatedMutex.Lock()
+	defer fake.configUpdatedMutex.Unlock()
+	fake.ConfigUpdatedStub = stub
+}
+
+func (fake *FakePipeline) ConfigUpdatedReturns(result1 time.Time) {
+	fake.configUpdatedMutex.Lock()
+	defer fake.configUpdatedMutex.Unlock()
+	fake.ConfigUpdatedStub = nil
+	fake.configUpdatedReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakePipeline) ConfigUpdatedReturnsOnCall(i int, result1 time.Time) {
+	fake.configUpdatedMutex.Lock()
+	defer fake.configUpdatedMutex.Unlock()
+	fake.ConfigUpdatedStub = nil
+	if fake.configUpdatedReturnsOnCall == nil {
+		fake.configUpdatedReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.configUpdatedReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakePipeline) ConfigVersion() db.ConfigVersion {
 	fake.configVersionMutex.Lock()
 	ret, specificReturn := fake.configVersionReturnsOnCall[len(fake.configVersionArgsForCall)]
@@ -807,33 +1900,227 @@ func (fake *FakePipeline) ConfigVersionCallCount() int {
 	return len(fake.configVersionArgsForCall)
 }
 
-func (fake *FakePipeline) ConfigVersionCalls(stub func() db.ConfigVersion) {
-	fake.configVersionMutex.Lock()
-	defer fake.configVersionMutex.Unlock()
-	fake.ConfigVersionStub = stub
+func (fake *FakePipeline) ConfigVersionCalls(stub func() db.ConfigVersion) {
+	fake.configVersionMutex.Lock()
+	defer fake.configVersionMutex.Unlock()
+	fake.ConfigVersionStub = stub
+}
+
+func (fake *FakePipeline) ConfigVersionReturns(result1 db.ConfigVersion) {
+	fake.configVersionMutex.Lock()
+	defer fake.configVersionMutex.Unlock()
+	fake.ConfigVersionStub = nil
+	fake.configVersionReturns = struct {
+		result1 db.ConfigVersion
+	}{result1}
+}
+
+func (fake *FakePipeline) ConfigVersionReturnsOnCall(i int, result1 db.ConfigVersion) {
+	fake.configVersionMutex.Lock()
+	defer fake.configVersionMutex.Unlock()
+	fake.ConfigVersionStub = nil
+	if fake.configVersionReturnsOnCall == nil {
+		fake.configVersionReturnsOnCall = make(map[int]struct {
+			result1 db.ConfigVersion
+		})
+	}
+	fake.configVersionReturnsOnCall[i] = struct {
+		result1 db.ConfigVersion
+	}{result1}
+}
+
+func (fake *FakePipeline) ConsecutiveFailures(arg1 string) (int, error) {
+	fake.consecutiveFailuresMutex.Lock()
+	ret, specificReturn := fake.consecutiveFailuresReturnsOnCall[len(fake.consecutiveFailuresArgsForCall)]
+	fake.consecutiveFailuresArgsForCall = append(fake.consecutiveFailuresArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ConsecutiveFailures", []interface{}{arg1})
+	fake.consecutiveFailuresMutex.Unlock()
+	if fake.ConsecutiveFailuresStub != nil {
+		return fake.ConsecutiveFailuresStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.consecutiveFailuresReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) ConsecutiveFailuresCallCount() int {
+	fake.consecutiveFailuresMutex.RLock()
+	defer fake.consecutiveFailuresMutex.RUnlock()
+	return len(fake.consecutiveFailuresArgsForCall)
+}
+
+func (fake *FakePipeline) ConsecutiveFailuresCalls(stub func(string) (int, error)) {
+	fake.consecutiveFailuresMutex.Lock()
+	defer fake.consecutiveFailuresMutex.Unlock()
+	fake.ConsecutiveFailuresStub = stub
+}
+
+func (fake *FakePipeline) ConsecutiveFailuresArgsForCall(i int) string {
+	fake.consecutiveFailuresMutex.RLock()
+	defer fake.consecutiveFailuresMutex.RUnlock()
+	argsForCall := fake.consecutiveFailuresArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) ConsecutiveFailuresReturns(result1 int, result2 error) {
+	fake.consecutiveFailuresMutex.Lock()
+	defer fake.consecutiveFailuresMutex.Unlock()
+	fake.ConsecutiveFailuresStub = nil
+	fake.consecutiveFailuresReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConsecutiveFailuresReturnsOnCall(i int, result1 int, result2 error) {
+	fake.consecutiveFailuresMutex.Lock()
+	defer fake.consecutiveFailuresMutex.Unlock()
+	fake.ConsecutiveFailuresStub = nil
+	if fake.consecutiveFailuresReturnsOnCall == nil {
+		fake.consecutiveFailuresReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.consecutiveFailuresReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) CreateJobBuild(arg1 string, arg2 db.BuildTrigger) (db.Build, error) {
+	fake.createJobBuildMutex.Lock()
+	ret, specificReturn := fake.createJobBuildReturnsOnCall[len(fake.createJobBuildArgsForCall)]
+	fake.createJobBuildArgsForCall = append(fake.createJobBuildArgsForCall, struct {
+		arg1 string
+		arg2 db.BuildTrigger
+	}{arg1, arg2})
+	fake.recordInvocation("CreateJobBuild", []interface{}{arg1, arg2})
+	fake.createJobBuildMutex.Unlock()
+	if fake.CreateJobBuildStub != nil {
+		return fake.CreateJobBuildStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createJobBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) CreateJobBuildCallCount() int {
+	fake.createJobBuildMutex.RLock()
+	defer fake.createJobBuildMutex.RUnlock()
+	return len(fake.createJobBuildArgsForCall)
+}
+
+func (fake *FakePipeline) CreateJobBuildCalls(stub func(string, db.BuildTrigger) (db.Build, error)) {
+	fake.createJobBuildMutex.Lock()
+	defer fake.createJobBuildMutex.Unlock()
+	fake.CreateJobBuildStub = stub
+}
+
+func (fake *FakePipeline) CreateJobBuildArgsForCall(i int) (string, db.BuildTrigger) {
+	fake.createJobBuildMutex.RLock()
+	defer fake.createJobBuildMutex.RUnlock()
+	argsForCall := fake.createJobBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) CreateJobBuildReturns(result1 db.Build, result2 error) {
+	fake.createJobBuildMutex.Lock()
+	defer fake.createJobBuildMutex.Unlock()
+	fake.CreateJobBuildStub = nil
+	fake.createJobBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) CreateJobBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createJobBuildMutex.Lock()
+	defer fake.createJobBuildMutex.Unlock()
+	fake.CreateJobBuildStub = nil
+	if fake.createJobBuildReturnsOnCall == nil {
+		fake.createJobBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createJobBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) CreateJobBuildWithKey(arg1 string, arg2 string) (db.Build, bool, error) {
+	fake.createJobBuildWithKeyMutex.Lock()
+	ret, specificReturn := fake.createJobBuildWithKeyReturnsOnCall[len(fake.createJobBuildWithKeyArgsForCall)]
+	fake.createJobBuildWithKeyArgsForCall = append(fake.createJobBuildWithKeyArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("CreateJobBuildWithKey", []interface{}{arg1, arg2})
+	fake.createJobBuildWithKeyMutex.Unlock()
+	if fake.CreateJobBuildWithKeyStub != nil {
+		return fake.CreateJobBuildWithKeyStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.createJobBuildWithKeyReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) CreateJobBuildWithKeyCallCount() int {
+	fake.createJobBuildWithKeyMutex.RLock()
+	defer fake.createJobBuildWithKeyMutex.RUnlock()
+	return len(fake.createJobBuildWithKeyArgsForCall)
+}
+
+func (fake *FakePipeline) CreateJobBuildWithKeyCalls(stub func(string, string) (db.Build, bool, error)) {
+	fake.createJobBuildWithKeyMutex.Lock()
+	defer fake.createJobBuildWithKeyMutex.Unlock()
+	fake.CreateJobBuildWithKeyStub = stub
 }
 
-func (fake *FakePipeline) ConfigVersionReturns(result1 db.ConfigVersion) {
-	fake.configVersionMutex.Lock()
-	defer fake.configVersionMutex.Unlock()
-	fake.ConfigVersionStub = nil
-	fake.configVersionReturns = struct {
-		result1 db.ConfigVersion
-	}{result1}
+func (fake *FakePipeline) CreateJobBuildWithKeyArgsForCall(i int) (string, string) {
+	fake.createJobBuildWithKeyMutex.RLock()
+	defer fake.createJobBuildWithKeyMutex.RUnlock()
+	argsForCall := fake.createJobBuildWithKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakePipeline) ConfigVersionReturnsOnCall(i int, result1 db.ConfigVersion) {
-	fake.configVersionMutex.Lock()
-	defer fake.configVersionMutex.Unlock()
-	fake.ConfigVersionStub = nil
-	if fake.configVersionReturnsOnCall == nil {
-		fake.configVersionReturnsOnCall = make(map[int]struct {
-			result1 db.ConfigVersion
+func (fake *FakePipeline) CreateJobBuildWithKeyReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.createJobBuildWithKeyMutex.Lock()
+	defer fake.createJobBuildWithKeyMutex.Unlock()
+	fake.CreateJobBuildWithKeyStub = nil
+	fake.createJobBuildWithKeyReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) CreateJobBuildWithKeyReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.createJobBuildWithKeyMutex.Lock()
+	defer fake.createJobBuildWithKeyMutex.Unlock()
+	fake.CreateJobBuildWithKeyStub = nil
+	if fake.createJobBuildWithKeyReturnsOnCall == nil {
+		fake.createJobBuildWithKeyReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
 		})
 	}
-	fake.configVersionReturnsOnCall[i] = struct {
-		result1 db.ConfigVersion
-	}{result1}
+	fake.createJobBuildWithKeyReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
 }
 
 func (fake *FakePipeline) CreateOneOffBuild() (db.Build, error) {
@@ -1233,6 +2520,138 @@ func (fake *FakePipeline) GetAllPendingBuildsReturnsOnCall(i int, result1 map[st
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) GetBuildForOutput(arg1 string, arg2 atc.Version) (db.Build, bool, error) {
+	fake.getBuildForOutputMutex.Lock()
+	ret, specificReturn := fake.getBuildForOutputReturnsOnCall[len(fake.getBuildForOutputArgsForCall)]
+	fake.getBuildForOutputArgsForCall = append(fake.getBuildForOutputArgsForCall, struct {
+		arg1 string
+		arg2 atc.Version
+	}{arg1, arg2})
+	fake.recordInvocation("GetBuildForOutput", []interface{}{arg1, arg2})
+	fake.getBuildForOutputMutex.Unlock()
+	if fake.GetBuildForOutputStub != nil {
+		return fake.GetBuildForOutputStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getBuildForOutputReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) GetBuildForOutputCallCount() int {
+	fake.getBuildForOutputMutex.RLock()
+	defer fake.getBuildForOutputMutex.RUnlock()
+	return len(fake.getBuildForOutputArgsForCall)
+}
+
+func (fake *FakePipeline) GetBuildForOutputCalls(stub func(string, atc.Version) (db.Build, bool, error)) {
+	fake.getBuildForOutputMutex.Lock()
+	defer fake.getBuildForOutputMutex.Unlock()
+	fake.GetBuildForOutputStub = stub
+}
+
+func (fake *FakePipeline) GetBuildForOutputArgsForCall(i int) (string, atc.Version) {
+	fake.getBuildForOutputMutex.RLock()
+	defer fake.getBuildForOutputMutex.RUnlock()
+	argsForCall := fake.getBuildForOutputArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) GetBuildForOutputReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.getBuildForOutputMutex.Lock()
+	defer fake.getBuildForOutputMutex.Unlock()
+	fake.GetBuildForOutputStub = nil
+	fake.getBuildForOutputReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) GetBuildForOutputReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.getBuildForOutputMutex.Lock()
+	defer fake.getBuildForOutputMutex.Unlock()
+	fake.GetBuildForOutputStub = nil
+	if fake.getBuildForOutputReturnsOnCall == nil {
+		fake.getBuildForOutputReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getBuildForOutputReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) GetBuildsForInputVersion(arg1 string, arg2 atc.Version, arg3 int) ([]db.Build, error) {
+	fake.getBuildsForInputVersionMutex.Lock()
+	ret, specificReturn := fake.getBuildsForInputVersionReturnsOnCall[len(fake.getBuildsForInputVersionArgsForCall)]
+	fake.getBuildsForInputVersionArgsForCall = append(fake.getBuildsForInputVersionArgsForCall, struct {
+		arg1 string
+		arg2 atc.Version
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetBuildsForInputVersion", []interface{}{arg1, arg2, arg3})
+	fake.getBuildsForInputVersionMutex.Unlock()
+	if fake.GetBuildsForInputVersionStub != nil {
+		return fake.GetBuildsForInputVersionStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getBuildsForInputVersionReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) GetBuildsForInputVersionCallCount() int {
+	fake.getBuildsForInputVersionMutex.RLock()
+	defer fake.getBuildsForInputVersionMutex.RUnlock()
+	return len(fake.getBuildsForInputVersionArgsForCall)
+}
+
+func (fake *FakePipeline) GetBuildsForInputVersionCalls(stub func(string, atc.Version, int) ([]db.Build, error)) {
+	fake.getBuildsForInputVersionMutex.Lock()
+	defer fake.getBuildsForInputVersionMutex.Unlock()
+	fake.GetBuildsForInputVersionStub = stub
+}
+
+func (fake *FakePipeline) GetBuildsForInputVersionArgsForCall(i int) (string, atc.Version, int) {
+	fake.getBuildsForInputVersionMutex.RLock()
+	defer fake.getBuildsForInputVersionMutex.RUnlock()
+	argsForCall := fake.getBuildsForInputVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePipeline) GetBuildsForInputVersionReturns(result1 []db.Build, result2 error) {
+	fake.getBuildsForInputVersionMutex.Lock()
+	defer fake.getBuildsForInputVersionMutex.Unlock()
+	fake.GetBuildsForInputVersionStub = nil
+	fake.getBuildsForInputVersionReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) GetBuildsForInputVersionReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.getBuildsForInputVersionMutex.Lock()
+	defer fake.getBuildsForInputVersionMutex.Unlock()
+	fake.GetBuildsForInputVersionStub = nil
+	if fake.getBuildsForInputVersionReturnsOnCall == nil {
+		fake.getBuildsForInputVersionReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.getBuildsForInputVersionReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) GetBuildsWithVersionAsInput(arg1 int, arg2 int) ([]db.Build, error) {
 	fake.getBuildsWithVersionAsInputMutex.Lock()
 	ret, specificReturn := fake.getBuildsWithVersionAsInputReturnsOnCall[len(fake.getBuildsWithVersionAsInputArgsForCall)]
@@ -1361,6 +2780,254 @@ func (fake *FakePipeline) GetBuildsWithVersionAsOutputReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) GetConfigVersion(arg1 db.ConfigVersion) (atc.Config, bool, error) {
+	fake.getConfigVersionMutex.Lock()
+	ret, specificReturn := fake.getConfigVersionReturnsOnCall[len(fake.getConfigVersionArgsForCall)]
+	fake.getConfigVersionArgsForCall = append(fake.getConfigVersionArgsForCall, struct {
+		arg1 db.ConfigVersion
+	}{arg1})
+	fake.recordInvocation("GetConfigVersion", []interface{}{arg1})
+	fake.getConfigVersionMutex.Unlock()
+	if fake.GetConfigVersionStub != nil {
+		return fake.GetConfigVersionStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getConfigVersionReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) GetConfigVersionCallCount() int {
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
+	return len(fake.getConfigVersionArgsForCall)
+}
+
+func (fake *FakePipeline) GetConfigVersionCalls(stub func(db.ConfigVersion) (atc.Config, bool, error)) {
+	fake.getConfigVersionMutex.Lock()
+	defer fake.getConfigVersionMutex.Unlock()
+	fake.GetConfigVersionStub = stub
+}
+
+func (fake *FakePipeline) GetConfigVersionArgsForCall(i int) db.ConfigVersion {
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
+	argsForCall := fake.getConfigVersionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) GetConfigVersionReturns(result1 atc.Config, result2 bool, result3 error) {
+	fake.getConfigVersionMutex.Lock()
+	defer fake.getConfigVersionMutex.Unlock()
+	fake.GetConfigVersionStub = nil
+	fake.getConfigVersionReturns = struct {
+		result1 atc.Config
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) GetConfigVersionReturnsOnCall(i int, result1 atc.Config, result2 bool, result3 error) {
+	fake.getConfigVersionMutex.Lock()
+	defer fake.getConfigVersionMutex.Unlock()
+	fake.GetConfigVersionStub = nil
+	if fake.getConfigVersionReturnsOnCall == nil {
+		fake.getConfigVersionReturnsOnCall = make(map[int]struct {
+			result1 atc.Config
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getConfigVersionReturnsOnCall[i] = struct {
+		result1 atc.Config
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) GetJobsUsingResource(arg1 string) ([]db.ResourceUsage, error) {
+	fake.getJobsUsingResourceMutex.Lock()
+	ret, specificReturn := fake.getJobsUsingResourceReturnsOnCall[len(fake.getJobsUsingResourceArgsForCall)]
+	fake.getJobsUsingResourceArgsForCall = append(fake.getJobsUsingResourceArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetJobsUsingResource", []interface{}{arg1})
+	fake.getJobsUsingResourceMutex.Unlock()
+	if fake.GetJobsUsingResourceStub != nil {
+		return fake.GetJobsUsingResourceStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getJobsUsingResourceReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) GetJobsUsingResourceCallCount() int {
+	fake.getJobsUsingResourceMutex.RLock()
+	defer fake.getJobsUsingResourceMutex.RUnlock()
+	return len(fake.getJobsUsingResourceArgsForCall)
+}
+
+func (fake *FakePipeline) GetJobsUsingResourceCalls(stub func(string) ([]db.ResourceUsage, error)) {
+	fake.getJobsUsingResourceMutex.Lock()
+	defer fake.getJobsUsingResourceMutex.Unlock()
+	fake.GetJobsUsingResourceStub = stub
+}
+
+func (fake *FakePipeline) GetJobsUsingResourceArgsForCall(i int) string {
+	fake.getJobsUsingResourceMutex.RLock()
+	defer fake.getJobsUsingResourceMutex.RUnlock()
+	argsForCall := fake.getJobsUsingResourceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) GetJobsUsingResourceReturns(result1 []db.ResourceUsage, result2 error) {
+	fake.getJobsUsingResourceMutex.Lock()
+	defer fake.getJobsUsingResourceMutex.Unlock()
+	fake.GetJobsUsingResourceStub = nil
+	fake.getJobsUsingResourceReturns = struct {
+		result1 []db.ResourceUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) GetJobsUsingResourceReturnsOnCall(i int, result1 []db.ResourceUsage, result2 error) {
+	fake.getJobsUsingResourceMutex.Lock()
+	defer fake.getJobsUsingResourceMutex.Unlock()
+	fake.GetJobsUsingResourceStub = nil
+	if fake.getJobsUsingResourceReturnsOnCall == nil {
+		fake.getJobsUsingResourceReturnsOnCall = make(map[int]struct {
+			result1 []db.ResourceUsage
+			result2 error
+		})
+	}
+	fake.getJobsUsingResourceReturnsOnCall[i] = struct {
+		result1 []db.ResourceUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) GetVersionCausality(arg1 int, arg2 int) (db.CausalityGraph, error) {
+	fake.getVersionCausalityMutex.Lock()
+	ret, specificReturn := fake.getVersionCausalityReturnsOnCall[len(fake.getVersionCausalityArgsForCall)]
+	fake.getVersionCausalityArgsForCall = append(fake.getVersionCausalityArgsForCall, struct {
+		arg1 int
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("GetVersionCausality", []interface{}{arg1, arg2})
+	fake.getVersionCausalityMutex.Unlock()
+	if fake.GetVersionCausalityStub != nil {
+		return fake.GetVersionCausalityStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getVersionCausalityReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) GetVersionCausalityCallCount() int {
+	fake.getVersionCausalityMutex.RLock()
+	defer fake.getVersionCausalityMutex.RUnlock()
+	return len(fake.getVersionCausalityArgsForCall)
+}
+
+func (fake *FakePipeline) GetVersionCausalityCalls(stub func(int, int) (db.CausalityGraph, error)) {
+	fake.getVersionCausalityMutex.Lock()
+	defer fake.getVersionCausalityMutex.Unlock()
+	fake.GetVersionCausalityStub = stub
+}
+
+func (fake *FakePipeline) GetVersionCausalityArgsForCall(i int) (int, int) {
+	fake.getVersionCausalityMutex.RLock()
+	defer fake.getVersionCausalityMutex.RUnlock()
+	argsForCall := fake.getVersionCausalityArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) GetVersionCausalityReturns(result1 db.CausalityGraph, result2 error) {
+	fake.getVersionCausalityMutex.Lock()
+	defer fake.getVersionCausalityMutex.Unlock()
+	fake.GetVersionCausalityStub = nil
+	fake.getVersionCausalityReturns = struct {
+		result1 db.CausalityGraph
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) GetVersionCausalityReturnsOnCall(i int, result1 db.CausalityGraph, result2 error) {
+	fake.getVersionCausalityMutex.Lock()
+	defer fake.getVersionCausalityMutex.Unlock()
+	fake.GetVersionCausalityStub = nil
+	if fake.getVersionCausalityReturnsOnCall == nil {
+		fake.getVersionCausalityReturnsOnCall = make(map[int]struct {
+			result1 db.CausalityGraph
+			result2 error
+		})
+	}
+	fake.getVersionCausalityReturnsOnCall[i] = struct {
+		result1 db.CausalityGraph
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) Graph() (db.PipelineGraph, error) {
+	fake.graphMutex.Lock()
+	ret, specificReturn := fake.graphReturnsOnCall[len(fake.graphArgsForCall)]
+	fake.graphArgsForCall = append(fake.graphArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Graph", []interface{}{})
+	fake.graphMutex.Unlock()
+	if fake.GraphStub != nil {
+		return fake.GraphStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.graphReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) GraphCallCount() int {
+	fake.graphMutex.RLock()
+	defer fake.graphMutex.RUnlock()
+	return len(fake.graphArgsForCall)
+}
+
+func (fake *FakePipeline) GraphCalls(stub func() (db.PipelineGraph, error)) {
+	fake.graphMutex.Lock()
+	defer fake.graphMutex.Unlock()
+	fake.GraphStub = stub
+}
+
+func (fake *FakePipeline) GraphReturns(result1 db.PipelineGraph, result2 error) {
+	fake.graphMutex.Lock()
+	defer fake.graphMutex.Unlock()
+	fake.GraphStub = nil
+	fake.graphReturns = struct {
+		result1 db.PipelineGraph
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) GraphReturnsOnCall(i int, result1 db.PipelineGraph, result2 error) {
+	fake.graphMutex.Lock()
+	defer fake.graphMutex.Unlock()
+	fake.GraphStub = nil
+	if fake.graphReturnsOnCall == nil {
+		fake.graphReturnsOnCall = make(map[int]struct {
+			result1 db.PipelineGraph
+			result2 error
+		})
+	}
+	fake.graphReturnsOnCall[i] = struct {
+		result1 db.PipelineGraph
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) Groups() atc.GroupConfigs {
 	fake.groupsMutex.Lock()
 	ret, specificReturn := fake.groupsReturnsOnCall[len(fake.groupsArgsForCall)]
@@ -1541,43 +3208,109 @@ func (fake *FakePipeline) JobCallCount() int {
 	return len(fake.jobArgsForCall)
 }
 
-func (fake *FakePipeline) JobCalls(stub func(string) (db.Job, bool, error)) {
-	fake.jobMutex.Lock()
-	defer fake.jobMutex.Unlock()
-	fake.JobStub = stub
+func (fake *FakePipeline) JobCalls(stub func(string) (db.Job, bool, error)) {
+	fake.jobMutex.Lock()
+	defer fake.jobMutex.Unlock()
+	fake.JobStub = stub
+}
+
+func (fake *FakePipeline) JobArgsForCall(i int) string {
+	fake.jobMutex.RLock()
+	defer fake.jobMutex.RUnlock()
+	argsForCall := fake.jobArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) JobReturns(result1 db.Job, result2 bool, result3 error) {
+	fake.jobMutex.Lock()
+	defer fake.jobMutex.Unlock()
+	fake.JobStub = nil
+	fake.jobReturns = struct {
+		result1 db.Job
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) JobReturnsOnCall(i int, result1 db.Job, result2 bool, result3 error) {
+	fake.jobMutex.Lock()
+	defer fake.jobMutex.Unlock()
+	fake.JobStub = nil
+	if fake.jobReturnsOnCall == nil {
+		fake.jobReturnsOnCall = make(map[int]struct {
+			result1 db.Job
+			result2 bool
+			result3 error
+		})
+	}
+	fake.jobReturnsOnCall[i] = struct {
+		result1 db.Job
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) JobStatus(arg1 string) (db.JobStatus, bool, error) {
+	fake.jobStatusMutex.Lock()
+	ret, specificReturn := fake.jobStatusReturnsOnCall[len(fake.jobStatusArgsForCall)]
+	fake.jobStatusArgsForCall = append(fake.jobStatusArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("JobStatus", []interface{}{arg1})
+	fake.jobStatusMutex.Unlock()
+	if fake.JobStatusStub != nil {
+		return fake.JobStatusStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.jobStatusReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) JobStatusCallCount() int {
+	fake.jobStatusMutex.RLock()
+	defer fake.jobStatusMutex.RUnlock()
+	return len(fake.jobStatusArgsForCall)
+}
+
+func (fake *FakePipeline) JobStatusCalls(stub func(string) (db.JobStatus, bool, error)) {
+	fake.jobStatusMutex.Lock()
+	defer fake.jobStatusMutex.Unlock()
+	fake.JobStatusStub = stub
 }
 
-func (fake *FakePipeline) JobArgsForCall(i int) string {
-	fake.jobMutex.RLock()
-	defer fake.jobMutex.RUnlock()
-	argsForCall := fake.jobArgsForCall[i]
+func (fake *FakePipeline) JobStatusArgsForCall(i int) string {
+	fake.jobStatusMutex.RLock()
+	defer fake.jobStatusMutex.RUnlock()
+	argsForCall := fake.jobStatusArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakePipeline) JobReturns(result1 db.Job, result2 bool, result3 error) {
-	fake.jobMutex.Lock()
-	defer fake.jobMutex.Unlock()
-	fake.JobStub = nil
-	fake.jobReturns = struct {
-		result1 db.Job
+func (fake *FakePipeline) JobStatusReturns(result1 db.JobStatus, result2 bool, result3 error) {
+	fake.jobStatusMutex.Lock()
+	defer fake.jobStatusMutex.Unlock()
+	fake.JobStatusStub = nil
+	fake.jobStatusReturns = struct {
+		result1 db.JobStatus
 		result2 bool
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *FakePipeline) JobReturnsOnCall(i int, result1 db.Job, result2 bool, result3 error) {
-	fake.jobMutex.Lock()
-	defer fake.jobMutex.Unlock()
-	fake.JobStub = nil
-	if fake.jobReturnsOnCall == nil {
-		fake.jobReturnsOnCall = make(map[int]struct {
-			result1 db.Job
+func (fake *FakePipeline) JobStatusReturnsOnCall(i int, result1 db.JobStatus, result2 bool, result3 error) {
+	fake.jobStatusMutex.Lock()
+	defer fake.jobStatusMutex.Unlock()
+	fake.JobStatusStub = nil
+	if fake.jobStatusReturnsOnCall == nil {
+		fake.jobStatusReturnsOnCall = make(map[int]struct {
+			result1 db.JobStatus
 			result2 bool
 			result3 error
 		})
 	}
-	fake.jobReturnsOnCall[i] = struct {
-		result1 db.Job
+	fake.jobStatusReturnsOnCall[i] = struct {
+		result1 db.JobStatus
 		result2 bool
 		result3 error
 	}{result1, result2, result3}
@@ -1638,6 +3371,309 @@ func (fake *FakePipeline) JobsReturnsOnCall(i int, result1 db.Jobs, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) LastUpdated() time.Time {
+	fake.lastUpdatedMutex.Lock()
+	ret, specificReturn := fake.lastUpdatedReturnsOnCall[len(fake.lastUpdatedArgsForCall)]
+	fake.lastUpdatedArgsForCall = append(fake.lastUpdatedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("LastUpdated", []interface{}{})
+	fake.lastUpdatedMutex.Unlock()
+	if fake.LastUpdatedStub != nil {
+		return fake.LastUpdatedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.lastUpdatedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) LastUpdatedCallCount() int {
+	fake.lastUpdatedMutex.RLock()
+	defer fake.lastUpdatedMutex.RUnlock()
+	return len(fake.lastUpdatedArgsForCall)
+}
+
+func (fake *FakePipeline) LastUpdatedCalls(stub func() time.Time) {
+	fake.lastUpdatedMutex.Lock()
+	defer fake.lastUpdatedMutex.Unlock()
+	fake.LastUpdatedStub = stub
+}
+
+func (fake *FakePipeline) LastUpdatedReturns(result1 time.Time) {
+	fake.lastUpdatedMutex.Lock()
+	defer fake.lastUpdatedMutex.Unlock()
+	fake.LastUpdatedStub = nil
+	fake.lastUpdatedReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakePipeline) LastUpdatedReturnsOnCall(i int, result1 time.Time) {
+	fake.lastUpdatedMutex.Lock()
+	defer fake.lastUpdatedMutex.Unlock()
+	fake.LastUpdatedStub = nil
+	if fake.lastUpdatedReturnsOnCall == nil {
+		fake.lastUpdatedReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.lastUpdatedReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakePipeline) LatestSuccessfulBuilds() (map[string]db.Build, error) {
+	fake.latestSuccessfulBuildsMutex.Lock()
+	ret, specificReturn := fake.latestSuccessfulBuildsReturnsOnCall[len(fake.latestSuccessfulBuildsArgsForCall)]
+	fake.latestSuccessfulBuildsArgsForCall = append(fake.latestSuccessfulBuildsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("LatestSuccessfulBuilds", []interface{}{})
+	fake.latestSuccessfulBuildsMutex.Unlock()
+	if fake.LatestSuccessfulBuildsStub != nil {
+		return fake.LatestSuccessfulBuildsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.latestSuccessfulBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) LatestSuccessfulBuildsCallCount() int {
+	fake.latestSuccessfulBuildsMutex.RLock()
+	defer fake.latestSuccessfulBuildsMutex.RUnlock()
+	return len(fake.latestSuccessfulBuildsArgsForCall)
+}
+
+func (fake *FakePipeline) LatestSuccessfulBuildsCalls(stub func() (map[string]db.Build, error)) {
+	fake.latestSuccessfulBuildsMutex.Lock()
+	defer fake.latestSuccessfulBuildsMutex.Unlock()
+	fake.LatestSuccessfulBuildsStub = stub
+}
+
+func (fake *FakePipeline) LatestSuccessfulBuildsReturns(result1 map[string]db.Build, result2 error) {
+	fake.latestSuccessfulBuildsMutex.Lock()
+	defer fake.latestSuccessfulBuildsMutex.Unlock()
+	fake.LatestSuccessfulBuildsStub = nil
+	fake.latestSuccessfulBuildsReturns = struct {
+		result1 map[string]db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) LatestSuccessfulBuildsReturnsOnCall(i int, result1 map[string]db.Build, result2 error) {
+	fake.latestSuccessfulBuildsMutex.Lock()
+	defer fake.latestSuccessfulBuildsMutex.Unlock()
+	fake.LatestSuccessfulBuildsStub = nil
+	if fake.latestSuccessfulBuildsReturnsOnCall == nil {
+		fake.latestSuccessfulBuildsReturnsOnCall = make(map[int]struct {
+			result1 map[string]db.Build
+			result2 error
+		})
+	}
+	fake.latestSuccessfulBuildsReturnsOnCall[i] = struct {
+		result1 map[string]db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) LeaseResourceChecking(arg1 string, arg2 time.Duration) (db.Lease, bool, error) {
+	fake.leaseResourceCheckingMutex.Lock()
+	ret, specificReturn := fake.leaseResourceCheckingReturnsOnCall[len(fake.leaseResourceCheckingArgsForCall)]
+	fake.leaseResourceCheckingArgsForCall = append(fake.leaseResourceCheckingArgsForCall, struct {
+		arg1 string
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("LeaseResourceChecking", []interface{}{arg1, arg2})
+	fake.leaseResourceCheckingMutex.Unlock()
+	if fake.LeaseResourceCheckingStub != nil {
+		return fake.LeaseResourceCheckingStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.leaseResourceCheckingReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) LeaseResourceCheckingCallCount() int {
+	fake.leaseResourceCheckingMutex.RLock()
+	defer fake.leaseResourceCheckingMutex.RUnlock()
+	return len(fake.leaseResourceCheckingArgsForCall)
+}
+
+func (fake *FakePipeline) LeaseResourceCheckingCalls(stub func(string, time.Duration) (db.Lease, bool, error)) {
+	fake.leaseResourceCheckingMutex.Lock()
+	defer fake.leaseResourceCheckingMutex.Unlock()
+	fake.LeaseResourceCheckingStub = stub
+}
+
+func (fake *FakePipeline) LeaseResourceCheckingArgsForCall(i int) (string, time.Duration) {
+	fake.leaseResourceCheckingMutex.RLock()
+	defer fake.leaseResourceCheckingMutex.RUnlock()
+	argsForCall := fake.leaseResourceCheckingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) LeaseResourceCheckingReturns(result1 db.Lease, result2 bool, result3 error) {
+	fake.leaseResourceCheckingMutex.Lock()
+	defer fake.leaseResourceCheckingMutex.Unlock()
+	fake.LeaseResourceCheckingStub = nil
+	fake.leaseResourceCheckingReturns = struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) LeaseResourceCheckingReturnsOnCall(i int, result1 db.Lease, result2 bool, result3 error) {
+	fake.leaseResourceCheckingMutex.Lock()
+	defer fake.leaseResourceCheckingMutex.Unlock()
+	fake.LeaseResourceCheckingStub = nil
+	if fake.leaseResourceCheckingReturnsOnCall == nil {
+		fake.leaseResourceCheckingReturnsOnCall = make(map[int]struct {
+			result1 db.Lease
+			result2 bool
+			result3 error
+		})
+	}
+	fake.leaseResourceCheckingReturnsOnCall[i] = struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) LeaseScheduling(arg1 time.Duration) (db.Lease, bool, error) {
+	fake.leaseSchedulingMutex.Lock()
+	ret, specificReturn := fake.leaseSchedulingReturnsOnCall[len(fake.leaseSchedulingArgsForCall)]
+	fake.leaseSchedulingArgsForCall = append(fake.leaseSchedulingArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("LeaseScheduling", []interface{}{arg1})
+	fake.leaseSchedulingMutex.Unlock()
+	if fake.LeaseSchedulingStub != nil {
+		return fake.LeaseSchedulingStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.leaseSchedulingReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) LeaseSchedulingCallCount() int {
+	fake.leaseSchedulingMutex.RLock()
+	defer fake.leaseSchedulingMutex.RUnlock()
+	return len(fake.leaseSchedulingArgsForCall)
+}
+
+func (fake *FakePipeline) LeaseSchedulingCalls(stub func(time.Duration) (db.Lease, bool, error)) {
+	fake.leaseSchedulingMutex.Lock()
+	defer fake.leaseSchedulingMutex.Unlock()
+	fake.LeaseSchedulingStub = stub
+}
+
+func (fake *FakePipeline) LeaseSchedulingArgsForCall(i int) time.Duration {
+	fake.leaseSchedulingMutex.RLock()
+	defer fake.leaseSchedulingMutex.RUnlock()
+	argsForCall := fake.leaseSchedulingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) LeaseSchedulingReturns(result1 db.Lease, result2 bool, result3 error) {
+	fake.leaseSchedulingMutex.Lock()
+	defer fake.leaseSchedulingMutex.Unlock()
+	fake.LeaseSchedulingStub = nil
+	fake.leaseSchedulingReturns = struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) LeaseSchedulingReturnsOnCall(i int, result1 db.Lease, result2 bool, result3 error) {
+	fake.leaseSchedulingMutex.Lock()
+	defer fake.leaseSchedulingMutex.Unlock()
+	fake.LeaseSchedulingStub = nil
+	if fake.leaseSchedulingReturnsOnCall == nil {
+		fake.leaseSchedulingReturnsOnCall = make(map[int]struct {
+			result1 db.Lease
+			result2 bool
+			result3 error
+		})
+	}
+	fake.leaseSchedulingReturnsOnCall[i] = struct {
+		result1 db.Lease
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ListConfigVersions(arg1 int) ([]db.ConfigVersion, error) {
+	fake.listConfigVersionsMutex.Lock()
+	ret, specificReturn := fake.listConfigVersionsReturnsOnCall[len(fake.listConfigVersionsArgsForCall)]
+	fake.listConfigVersionsArgsForCall = append(fake.listConfigVersionsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("ListConfigVersions", []interface{}{arg1})
+	fake.listConfigVersionsMutex.Unlock()
+	if fake.ListConfigVersionsStub != nil {
+		return fake.ListConfigVersionsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listConfigVersionsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) ListConfigVersionsCallCount() int {
+	fake.listConfigVersionsMutex.RLock()
+	defer fake.listConfigVersionsMutex.RUnlock()
+	return len(fake.listConfigVersionsArgsForCall)
+}
+
+func (fake *FakePipeline) ListConfigVersionsCalls(stub func(int) ([]db.ConfigVersion, error)) {
+	fake.listConfigVersionsMutex.Lock()
+	defer fake.listConfigVersionsMutex.Unlock()
+	fake.ListConfigVersionsStub = stub
+}
+
+func (fake *FakePipeline) ListConfigVersionsArgsForCall(i int) int {
+	fake.listConfigVersionsMutex.RLock()
+	defer fake.listConfigVersionsMutex.RUnlock()
+	argsForCall := fake.listConfigVersionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) ListConfigVersionsReturns(result1 []db.ConfigVersion, result2 error) {
+	fake.listConfigVersionsMutex.Lock()
+	defer fake.listConfigVersionsMutex.Unlock()
+	fake.ListConfigVersionsStub = nil
+	fake.listConfigVersionsReturns = struct {
+		result1 []db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ListConfigVersionsReturnsOnCall(i int, result1 []db.ConfigVersion, result2 error) {
+	fake.listConfigVersionsMutex.Lock()
+	defer fake.listConfigVersionsMutex.Unlock()
+	fake.ListConfigVersionsStub = nil
+	if fake.listConfigVersionsReturnsOnCall == nil {
+		fake.listConfigVersionsReturnsOnCall = make(map[int]struct {
+			result1 []db.ConfigVersion
+			result2 error
+		})
+	}
+	fake.listConfigVersionsReturnsOnCall[i] = struct {
+		result1 []db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	fake.loadVersionsDBMutex.Lock()
 	ret, specificReturn := fake.loadVersionsDBReturnsOnCall[len(fake.loadVersionsDBArgsForCall)]
@@ -1693,6 +3729,69 @@ func (fake *FakePipeline) LoadVersionsDBReturnsOnCall(i int, result1 *algorithm.
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) LoadVersionsDBContext(arg1 context.Context) (*algorithm.VersionsDB, error) {
+	fake.loadVersionsDBContextMutex.Lock()
+	ret, specificReturn := fake.loadVersionsDBContextReturnsOnCall[len(fake.loadVersionsDBContextArgsForCall)]
+	fake.loadVersionsDBContextArgsForCall = append(fake.loadVersionsDBContextArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("LoadVersionsDBContext", []interface{}{arg1})
+	fake.loadVersionsDBContextMutex.Unlock()
+	if fake.LoadVersionsDBContextStub != nil {
+		return fake.LoadVersionsDBContextStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.loadVersionsDBContextReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) LoadVersionsDBContextCallCount() int {
+	fake.loadVersionsDBContextMutex.RLock()
+	defer fake.loadVersionsDBContextMutex.RUnlock()
+	return len(fake.loadVersionsDBContextArgsForCall)
+}
+
+func (fake *FakePipeline) LoadVersionsDBContextCalls(stub func(context.Context) (*algorithm.VersionsDB, error)) {
+	fake.loadVersionsDBContextMutex.Lock()
+	defer fake.loadVersionsDBContextMutex.Unlock()
+	fake.LoadVersionsDBContextStub = stub
+}
+
+func (fake *FakePipeline) LoadVersionsDBContextArgsForCall(i int) context.Context {
+	fake.loadVersionsDBContextMutex.RLock()
+	defer fake.loadVersionsDBContextMutex.RUnlock()
+	argsForCall := fake.loadVersionsDBContextArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) LoadVersionsDBContextReturns(result1 *algorithm.VersionsDB, result2 error) {
+	fake.loadVersionsDBContextMutex.Lock()
+	defer fake.loadVersionsDBContextMutex.Unlock()
+	fake.LoadVersionsDBContextStub = nil
+	fake.loadVersionsDBContextReturns = struct {
+		result1 *algorithm.VersionsDB
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) LoadVersionsDBContextReturnsOnCall(i int, result1 *algorithm.VersionsDB, result2 error) {
+	fake.loadVersionsDBContextMutex.Lock()
+	defer fake.loadVersionsDBContextMutex.Unlock()
+	fake.LoadVersionsDBContextStub = nil
+	if fake.loadVersionsDBContextReturnsOnCall == nil {
+		fake.loadVersionsDBContextReturnsOnCall = make(map[int]struct {
+			result1 *algorithm.VersionsDB
+			result2 error
+		})
+	}
+	fake.loadVersionsDBContextReturnsOnCall[i] = struct {
+		result1 *algorithm.VersionsDB
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -1745,15 +3844,16 @@ func (fake *FakePipeline) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
-func (fake *FakePipeline) Pause() error {
+func (fake *FakePipeline) Pause(arg1 string) error {
 	fake.pauseMutex.Lock()
 	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
 	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
-	}{})
-	fake.recordInvocation("Pause", []interface{}{})
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Pause", []interface{}{arg1})
 	fake.pauseMutex.Unlock()
 	if fake.PauseStub != nil {
-		return fake.PauseStub()
+		return fake.PauseStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1768,12 +3868,19 @@ func (fake *FakePipeline) PauseCallCount() int {
 	return len(fake.pauseArgsForCall)
 }
 
-func (fake *FakePipeline) PauseCalls(stub func() error) {
+func (fake *FakePipeline) PauseCalls(stub func(string) error) {
 	fake.pauseMutex.Lock()
 	defer fake.pauseMutex.Unlock()
 	fake.PauseStub = stub
 }
 
+func (fake *FakePipeline) PauseArgsForCall(i int) string {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	argsForCall := fake.pauseArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakePipeline) PauseReturns(result1 error) {
 	fake.pauseMutex.Lock()
 	defer fake.pauseMutex.Unlock()
@@ -1797,6 +3904,61 @@ func (fake *FakePipeline) PauseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipeline) PauseHistory() ([]db.PauseEvent, error) {
+	fake.pauseHistoryMutex.Lock()
+	ret, specificReturn := fake.pauseHistoryReturnsOnCall[len(fake.pauseHistoryArgsForCall)]
+	fake.pauseHistoryArgsForCall = append(fake.pauseHistoryArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PauseHistory", []interface{}{})
+	fake.pauseHistoryMutex.Unlock()
+	if fake.PauseHistoryStub != nil {
+		return fake.PauseHistoryStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.pauseHistoryReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) PauseHistoryCallCount() int {
+	fake.pauseHistoryMutex.RLock()
+	defer fake.pauseHistoryMutex.RUnlock()
+	return len(fake.pauseHistoryArgsForCall)
+}
+
+func (fake *FakePipeline) PauseHistoryCalls(stub func() ([]db.PauseEvent, error)) {
+	fake.pauseHistoryMutex.Lock()
+	defer fake.pauseHistoryMutex.Unlock()
+	fake.PauseHistoryStub = stub
+}
+
+func (fake *FakePipeline) PauseHistoryReturns(result1 []db.PauseEvent, result2 error) {
+	fake.pauseHistoryMutex.Lock()
+	defer fake.pauseHistoryMutex.Unlock()
+	fake.PauseHistoryStub = nil
+	fake.pauseHistoryReturns = struct {
+		result1 []db.PauseEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) PauseHistoryReturnsOnCall(i int, result1 []db.PauseEvent, result2 error) {
+	fake.pauseHistoryMutex.Lock()
+	defer fake.pauseHistoryMutex.Unlock()
+	fake.PauseHistoryStub = nil
+	if fake.pauseHistoryReturnsOnCall == nil {
+		fake.pauseHistoryReturnsOnCall = make(map[int]struct {
+			result1 []db.PauseEvent
+			result2 error
+		})
+	}
+	fake.pauseHistoryReturnsOnCall[i] = struct {
+		result1 []db.PauseEvent
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) Paused() bool {
 	fake.pausedMutex.Lock()
 	ret, specificReturn := fake.pausedReturnsOnCall[len(fake.pausedArgsForCall)]
@@ -1986,34 +4148,97 @@ func (fake *FakePipeline) RenameCalls(stub func(string) error) {
 	fake.RenameStub = stub
 }
 
-func (fake *FakePipeline) RenameArgsForCall(i int) string {
-	fake.renameMutex.RLock()
-	defer fake.renameMutex.RUnlock()
-	argsForCall := fake.renameArgsForCall[i]
+func (fake *FakePipeline) RenameArgsForCall(i int) string {
+	fake.renameMutex.RLock()
+	defer fake.renameMutex.RUnlock()
+	argsForCall := fake.renameArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) RenameReturns(result1 error) {
+	fake.renameMutex.Lock()
+	defer fake.renameMutex.Unlock()
+	fake.RenameStub = nil
+	fake.renameReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) RenameReturnsOnCall(i int, result1 error) {
+	fake.renameMutex.Lock()
+	defer fake.renameMutex.Unlock()
+	fake.RenameStub = nil
+	if fake.renameReturnsOnCall == nil {
+		fake.renameReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.renameReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) RerunBuild(arg1 int) (db.Build, error) {
+	fake.rerunBuildMutex.Lock()
+	ret, specificReturn := fake.rerunBuildReturnsOnCall[len(fake.rerunBuildArgsForCall)]
+	fake.rerunBuildArgsForCall = append(fake.rerunBuildArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("RerunBuild", []interface{}{arg1})
+	fake.rerunBuildMutex.Unlock()
+	if fake.RerunBuildStub != nil {
+		return fake.RerunBuildStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.rerunBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) RerunBuildCallCount() int {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	return len(fake.rerunBuildArgsForCall)
+}
+
+func (fake *FakePipeline) RerunBuildCalls(stub func(int) (db.Build, error)) {
+	fake.rerunBuildMutex.Lock()
+	defer fake.rerunBuildMutex.Unlock()
+	fake.RerunBuildStub = stub
+}
+
+func (fake *FakePipeline) RerunBuildArgsForCall(i int) int {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	argsForCall := fake.rerunBuildArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakePipeline) RenameReturns(result1 error) {
-	fake.renameMutex.Lock()
-	defer fake.renameMutex.Unlock()
-	fake.RenameStub = nil
-	fake.renameReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakePipeline) RerunBuildReturns(result1 db.Build, result2 error) {
+	fake.rerunBuildMutex.Lock()
+	defer fake.rerunBuildMutex.Unlock()
+	fake.RerunBuildStub = nil
+	fake.rerunBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakePipeline) RenameReturnsOnCall(i int, result1 error) {
-	fake.renameMutex.Lock()
-	defer fake.renameMutex.Unlock()
-	fake.RenameStub = nil
-	if fake.renameReturnsOnCall == nil {
-		fake.renameReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakePipeline) RerunBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.rerunBuildMutex.Lock()
+	defer fake.rerunBuildMutex.Unlock()
+	fake.RerunBuildStub = nil
+	if fake.rerunBuildReturnsOnCall == nil {
+		fake.rerunBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
 		})
 	}
-	fake.renameReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.rerunBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakePipeline) Resource(arg1 string) (db.Resource, bool, error) {
@@ -2148,6 +4373,70 @@ func (fake *FakePipeline) ResourceByIDReturnsOnCall(i int, result1 db.Resource,
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipeline) ResourceReuseStats(arg1 string, arg2 time.Time) (db.ReuseStats, error) {
+	fake.resourceReuseStatsMutex.Lock()
+	ret, specificReturn := fake.resourceReuseStatsReturnsOnCall[len(fake.resourceReuseStatsArgsForCall)]
+	fake.resourceReuseStatsArgsForCall = append(fake.resourceReuseStatsArgsForCall, struct {
+		arg1 string
+		arg2 time.Time
+	}{arg1, arg2})
+	fake.recordInvocation("ResourceReuseStats", []interface{}{arg1, arg2})
+	fake.resourceReuseStatsMutex.Unlock()
+	if fake.ResourceReuseStatsStub != nil {
+		return fake.ResourceReuseStatsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.resourceReuseStatsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) ResourceReuseStatsCallCount() int {
+	fake.resourceReuseStatsMutex.RLock()
+	defer fake.resourceReuseStatsMutex.RUnlock()
+	return len(fake.resourceReuseStatsArgsForCall)
+}
+
+func (fake *FakePipeline) ResourceReuseStatsCalls(stub func(string, time.Time) (db.ReuseStats, error)) {
+	fake.resourceReuseStatsMutex.Lock()
+	defer fake.resourceReuseStatsMutex.Unlock()
+	fake.ResourceReuseStatsStub = stub
+}
+
+func (fake *FakePipeline) ResourceReuseStatsArgsForCall(i int) (string, time.Time) {
+	fake.resourceReuseStatsMutex.RLock()
+	defer fake.resourceReuseStatsMutex.RUnlock()
+	argsForCall := fake.resourceReuseStatsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) ResourceReuseStatsReturns(result1 db.ReuseStats, result2 error) {
+	fake.resourceReuseStatsMutex.Lock()
+	defer fake.resourceReuseStatsMutex.Unlock()
+	fake.ResourceReuseStatsStub = nil
+	fake.resourceReuseStatsReturns = struct {
+		result1 db.ReuseStats
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ResourceReuseStatsReturnsOnCall(i int, result1 db.ReuseStats, result2 error) {
+	fake.resourceReuseStatsMutex.Lock()
+	defer fake.resourceReuseStatsMutex.Unlock()
+	fake.ResourceReuseStatsStub = nil
+	if fake.resourceReuseStatsReturnsOnCall == nil {
+		fake.resourceReuseStatsReturnsOnCall = make(map[int]struct {
+			result1 db.ReuseStats
+			result2 error
+		})
+	}
+	fake.resourceReuseStatsReturnsOnCall[i] = struct {
+		result1 db.ReuseStats
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) ResourceType(arg1 string) (db.ResourceType, bool, error) {
 	fake.resourceTypeMutex.Lock()
 	ret, specificReturn := fake.resourceTypeReturnsOnCall[len(fake.resourceTypeArgsForCall)]
@@ -2456,6 +4745,182 @@ func (fake *FakePipeline) ResourcesReturnsOnCall(i int, result1 db.Resources, re
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) RevertConfig(arg1 db.ConfigVersion) (db.ConfigVersion, error) {
+	fake.revertConfigMutex.Lock()
+	ret, specificReturn := fake.revertConfigReturnsOnCall[len(fake.revertConfigArgsForCall)]
+	fake.revertConfigArgsForCall = append(fake.revertConfigArgsForCall, struct {
+		arg1 db.ConfigVersion
+	}{arg1})
+	fake.recordInvocation("RevertConfig", []interface{}{arg1})
+	fake.revertConfigMutex.Unlock()
+	if fake.RevertConfigStub != nil {
+		return fake.RevertConfigStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.revertConfigReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) RevertConfigCallCount() int {
+	fake.revertConfigMutex.RLock()
+	defer fake.revertConfigMutex.RUnlock()
+	return len(fake.revertConfigArgsForCall)
+}
+
+func (fake *FakePipeline) RevertConfigCalls(stub func(db.ConfigVersion) (db.ConfigVersion, error)) {
+	fake.revertConfigMutex.Lock()
+	defer fake.revertConfigMutex.Unlock()
+	fake.RevertConfigStub = stub
+}
+
+func (fake *FakePipeline) RevertConfigArgsForCall(i int) db.ConfigVersion {
+	fake.revertConfigMutex.RLock()
+	defer fake.revertConfigMutex.RUnlock()
+	argsForCall := fake.revertConfigArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) RevertConfigReturns(result1 db.ConfigVersion, result2 error) {
+	fake.revertConfigMutex.Lock()
+	defer fake.revertConfigMutex.Unlock()
+	fake.RevertConfigStub = nil
+	fake.revertConfigReturns = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) RevertConfigReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
+	fake.revertConfigMutex.Lock()
+	defer fake.revertConfigMutex.Unlock()
+	fake.RevertConfigStub = nil
+	if fake.revertConfigReturnsOnCall == nil {
+		fake.revertConfigReturnsOnCall = make(map[int]struct {
+			result1 db.ConfigVersion
+			result2 error
+		})
+	}
+	fake.revertConfigReturnsOnCall[i] = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) RunningBuildCount() (int, error) {
+	fake.runningBuildCountMutex.Lock()
+	ret, specificReturn := fake.runningBuildCountReturnsOnCall[len(fake.runningBuildCountArgsForCall)]
+	fake.runningBuildCountArgsForCall = append(fake.runningBuildCountArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RunningBuildCount", []interface{}{})
+	fake.runningBuildCountMutex.Unlock()
+	if fake.RunningBuildCountStub != nil {
+		return fake.RunningBuildCountStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.runningBuildCountReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) RunningBuildCountCallCount() int {
+	fake.runningBuildCountMutex.RLock()
+	defer fake.runningBuildCountMutex.RUnlock()
+	return len(fake.runningBuildCountArgsForCall)
+}
+
+func (fake *FakePipeline) RunningBuildCountCalls(stub func() (int, error)) {
+	fake.runningBuildCountMutex.Lock()
+	defer fake.runningBuildCountMutex.Unlock()
+	fake.RunningBuildCountStub = stub
+}
+
+func (fake *FakePipeline) RunningBuildCountReturns(result1 int, result2 error) {
+	fake.runningBuildCountMutex.Lock()
+	defer fake.runningBuildCountMutex.Unlock()
+	fake.RunningBuildCountStub = nil
+	fake.runningBuildCountReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) RunningBuildCountReturnsOnCall(i int, result1 int, result2 error) {
+	fake.runningBuildCountMutex.Lock()
+	defer fake.runningBuildCountMutex.Unlock()
+	fake.RunningBuildCountStub = nil
+	if fake.runningBuildCountReturnsOnCall == nil {
+		fake.runningBuildCountReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.runningBuildCountReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SchedulingLeaseHolder() (string, bool, error) {
+	fake.schedulingLeaseHolderMutex.Lock()
+	ret, specificReturn := fake.schedulingLeaseHolderReturnsOnCall[len(fake.schedulingLeaseHolderArgsForCall)]
+	fake.schedulingLeaseHolderArgsForCall = append(fake.schedulingLeaseHolderArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SchedulingLeaseHolder", []interface{}{})
+	fake.schedulingLeaseHolderMutex.Unlock()
+	if fake.SchedulingLeaseHolderStub != nil {
+		return fake.SchedulingLeaseHolderStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.schedulingLeaseHolderReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) SchedulingLeaseHolderCallCount() int {
+	fake.schedulingLeaseHolderMutex.RLock()
+	defer fake.schedulingLeaseHolderMutex.RUnlock()
+	return len(fake.schedulingLeaseHolderArgsForCall)
+}
+
+func (fake *FakePipeline) SchedulingLeaseHolderCalls(stub func() (string, bool, error)) {
+	fake.schedulingLeaseHolderMutex.Lock()
+	defer fake.schedulingLeaseHolderMutex.Unlock()
+	fake.SchedulingLeaseHolderStub = stub
+}
+
+func (fake *FakePipeline) SchedulingLeaseHolderReturns(result1 string, result2 bool, result3 error) {
+	fake.schedulingLeaseHolderMutex.Lock()
+	defer fake.schedulingLeaseHolderMutex.Unlock()
+	fake.SchedulingLeaseHolderStub = nil
+	fake.schedulingLeaseHolderReturns = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) SchedulingLeaseHolderReturnsOnCall(i int, result1 string, result2 bool, result3 error) {
+	fake.schedulingLeaseHolderMutex.Lock()
+	defer fake.schedulingLeaseHolderMutex.Unlock()
+	fake.SchedulingLeaseHolderStub = nil
+	if fake.schedulingLeaseHolderReturnsOnCall == nil {
+		fake.schedulingLeaseHolderReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+			result3 error
+		})
+	}
+	fake.schedulingLeaseHolderReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePipeline) TeamID() int {
 	fake.teamIDMutex.Lock()
 	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
@@ -2560,15 +5025,68 @@ func (fake *FakePipeline) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
-func (fake *FakePipeline) Unpause() error {
+func (fake *FakePipeline) Unarchive() error {
+	fake.unarchiveMutex.Lock()
+	ret, specificReturn := fake.unarchiveReturnsOnCall[len(fake.unarchiveArgsForCall)]
+	fake.unarchiveArgsForCall = append(fake.unarchiveArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Unarchive", []interface{}{})
+	fake.unarchiveMutex.Unlock()
+	if fake.UnarchiveStub != nil {
+		return fake.UnarchiveStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.unarchiveReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) UnarchiveCallCount() int {
+	fake.unarchiveMutex.RLock()
+	defer fake.unarchiveMutex.RUnlock()
+	return len(fake.unarchiveArgsForCall)
+}
+
+func (fake *FakePipeline) UnarchiveCalls(stub func() error) {
+	fake.unarchiveMutex.Lock()
+	defer fake.unarchiveMutex.Unlock()
+	fake.UnarchiveStub = stub
+}
+
+func (fake *FakePipeline) UnarchiveReturns(result1 error) {
+	fake.unarchiveMutex.Lock()
+	defer fake.unarchiveMutex.Unlock()
+	fake.UnarchiveStub = nil
+	fake.unarchiveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) UnarchiveReturnsOnCall(i int, result1 error) {
+	fake.unarchiveMutex.Lock()
+	defer fake.unarchiveMutex.Unlock()
+	fake.UnarchiveStub = nil
+	if fake.unarchiveReturnsOnCall == nil {
+		fake.unarchiveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unarchiveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) Unpause(arg1 string) error {
 	fake.unpauseMutex.Lock()
 	ret, specificReturn := fake.unpauseReturnsOnCall[len(fake.unpauseArgsForCall)]
 	fake.unpauseArgsForCall = append(fake.unpauseArgsForCall, struct {
-	}{})
-	fake.recordInvocation("Unpause", []interface{}{})
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Unpause", []interface{}{arg1})
 	fake.unpauseMutex.Unlock()
 	if fake.UnpauseStub != nil {
-		return fake.UnpauseStub()
+		return fake.UnpauseStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -2583,12 +5101,19 @@ func (fake *FakePipeline) UnpauseCallCount() int {
 	return len(fake.unpauseArgsForCall)
 }
 
-func (fake *FakePipeline) UnpauseCalls(stub func() error) {
+func (fake *FakePipeline) UnpauseCalls(stub func(string) error) {
 	fake.unpauseMutex.Lock()
 	defer fake.unpauseMutex.Unlock()
 	fake.UnpauseStub = stub
 }
 
+func (fake *FakePipeline) UnpauseArgsForCall(i int) string {
+	fake.unpauseMutex.RLock()
+	defer fake.unpauseMutex.RUnlock()
+	argsForCall := fake.unpauseArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakePipeline) UnpauseReturns(result1 error) {
 	fake.unpauseMutex.Lock()
 	defer fake.unpauseMutex.Unlock()
@@ -2612,21 +5137,237 @@ func (fake *FakePipeline) UnpauseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipeline) UpdateJob(arg1 string, arg2 atc.JobConfig) (db.ConfigVersion, error) {
+	fake.updateJobMutex.Lock()
+	ret, specificReturn := fake.updateJobReturnsOnCall[len(fake.updateJobArgsForCall)]
+	fake.updateJobArgsForCall = append(fake.updateJobArgsForCall, struct {
+		arg1 string
+		arg2 atc.JobConfig
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateJob", []interface{}{arg1, arg2})
+	fake.updateJobMutex.Unlock()
+	if fake.UpdateJobStub != nil {
+		return fake.UpdateJobStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateJobReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) UpdateJobCallCount() int {
+	fake.updateJobMutex.RLock()
+	defer fake.updateJobMutex.RUnlock()
+	return len(fake.updateJobArgsForCall)
+}
+
+func (fake *FakePipeline) UpdateJobCalls(stub func(string, atc.JobConfig) (db.ConfigVersion, error)) {
+	fake.updateJobMutex.Lock()
+	defer fake.updateJobMutex.Unlock()
+	fake.UpdateJobStub = stub
+}
+
+func (fake *FakePipeline) UpdateJobArgsForCall(i int) (string, atc.JobConfig) {
+	fake.updateJobMutex.RLock()
+	defer fake.updateJobMutex.RUnlock()
+	argsForCall := fake.updateJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) UpdateJobReturns(result1 db.ConfigVersion, result2 error) {
+	fake.updateJobMutex.Lock()
+	defer fake.updateJobMutex.Unlock()
+	fake.UpdateJobStub = nil
+	fake.updateJobReturns = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) UpdateJobReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
+	fake.updateJobMutex.Lock()
+	defer fake.updateJobMutex.Unlock()
+	fake.UpdateJobStub = nil
+	if fake.updateJobReturnsOnCall == nil {
+		fake.updateJobReturnsOnCall = make(map[int]struct {
+			result1 db.ConfigVersion
+			result2 error
+		})
+	}
+	fake.updateJobReturnsOnCall[i] = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) UpdateResourceConfig(arg1 string, arg2 atc.Source) (db.ConfigVersion, error) {
+	fake.updateResourceConfigMutex.Lock()
+	ret, specificReturn := fake.updateResourceConfigReturnsOnCall[len(fake.updateResourceConfigArgsForCall)]
+	fake.updateResourceConfigArgsForCall = append(fake.updateResourceConfigArgsForCall, struct {
+		arg1 string
+		arg2 atc.Source
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateResourceConfig", []interface{}{arg1, arg2})
+	fake.updateResourceConfigMutex.Unlock()
+	if fake.UpdateResourceConfigStub != nil {
+		return fake.UpdateResourceConfigStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateResourceConfigReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) UpdateResourceConfigCallCount() int {
+	fake.updateResourceConfigMutex.RLock()
+	defer fake.updateResourceConfigMutex.RUnlock()
+	return len(fake.updateResourceConfigArgsForCall)
+}
+
+func (fake *FakePipeline) UpdateResourceConfigCalls(stub func(string, atc.Source) (db.ConfigVersion, error)) {
+	fake.updateResourceConfigMutex.Lock()
+	defer fake.updateResourceConfigMutex.Unlock()
+	fake.UpdateResourceConfigStub = stub
+}
+
+func (fake *FakePipeline) UpdateResourceConfigArgsForCall(i int) (string, atc.Source) {
+	fake.updateResourceConfigMutex.RLock()
+	defer fake.updateResourceConfigMutex.RUnlock()
+	argsForCall := fake.updateResourceConfigArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) UpdateResourceConfigReturns(result1 db.ConfigVersion, result2 error) {
+	fake.updateResourceConfigMutex.Lock()
+	defer fake.updateResourceConfigMutex.Unlock()
+	fake.UpdateResourceConfigStub = nil
+	fake.updateResourceConfigReturns = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) UpdateResourceConfigReturnsOnCall(i int, result1 db.ConfigVersion, result2 error) {
+	fake.updateResourceConfigMutex.Lock()
+	defer fake.updateResourceConfigMutex.Unlock()
+	fake.UpdateResourceConfigStub = nil
+	if fake.updateResourceConfigReturnsOnCall == nil {
+		fake.updateResourceConfigReturnsOnCall = make(map[int]struct {
+			result1 db.ConfigVersion
+			result2 error
+		})
+	}
+	fake.updateResourceConfigReturnsOnCall[i] = struct {
+		result1 db.ConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) UpdateVersionMetadata(arg1 string, arg2 atc.Version, arg3 db.ResourceConfigMetadataFields) error {
+	fake.updateVersionMetadataMutex.Lock()
+	ret, specificReturn := fake.updateVersionMetadataReturnsOnCall[len(fake.updateVersionMetadataArgsForCall)]
+	fake.updateVersionMetadataArgsForCall = append(fake.updateVersionMetadataArgsForCall, struct {
+		arg1 string
+		arg2 atc.Version
+		arg3 db.ResourceConfigMetadataFields
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("UpdateVersionMetadata", []interface{}{arg1, arg2, arg3})
+	fake.updateVersionMetadataMutex.Unlock()
+	if fake.UpdateVersionMetadataStub != nil {
+		return fake.UpdateVersionMetadataStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateVersionMetadataReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) UpdateVersionMetadataCallCount() int {
+	fake.updateVersionMetadataMutex.RLock()
+	defer fake.updateVersionMetadataMutex.RUnlock()
+	return len(fake.updateVersionMetadataArgsForCall)
+}
+
+func (fake *FakePipeline) UpdateVersionMetadataCalls(stub func(string, atc.Version, db.ResourceConfigMetadataFields) error) {
+	fake.updateVersionMetadataMutex.Lock()
+	defer fake.updateVersionMetadataMutex.Unlock()
+	fake.UpdateVersionMetadataStub = stub
+}
+
+func (fake *FakePipeline) UpdateVersionMetadataArgsForCall(i int) (string, atc.Version, db.ResourceConfigMetadataFields) {
+	fake.updateVersionMetadataMutex.RLock()
+	defer fake.updateVersionMetadataMutex.RUnlock()
+	argsForCall := fake.updateVersionMetadataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePipeline) UpdateVersionMetadataReturns(result1 error) {
+	fake.updateVersionMetadataMutex.Lock()
+	defer fake.updateVersionMetadataMutex.Unlock()
+	fake.UpdateVersionMetadataStub = nil
+	fake.updateVersionMetadataReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) UpdateVersionMetadataReturnsOnCall(i int, result1 error) {
+	fake.updateVersionMetadataMutex.Lock()
+	defer fake.updateVersionMetadataMutex.Unlock()
+	fake.UpdateVersionMetadataStub = nil
+	if fake.updateVersionMetadataReturnsOnCall == nil {
+		fake.updateVersionMetadataReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateVersionMetadataReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.abortAllRunningBuildsMutex.RLock()
+	defer fake.abortAllRunningBuildsMutex.RUnlock()
 	fake.acquireSchedulingLockMutex.RLock()
 	defer fake.acquireSchedulingLockMutex.RUnlock()
+	fake.acquireSerialGroupLockMutex.RLock()
+	defer fake.acquireSerialGroupLockMutex.RUnlock()
+	fake.acquireSerialLockMutex.RLock()
+	defer fake.acquireSerialLockMutex.RUnlock()
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	fake.archivedMutex.RLock()
+	defer fake.archivedMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
+	fake.buildsWithStatusMutex.RLock()
+	defer fake.buildsWithStatusMutex.RUnlock()
 	fake.buildsWithTimeMutex.RLock()
 	defer fake.buildsWithTimeMutex.RUnlock()
+	fake.buildsWithTriggerMutex.RLock()
+	defer fake.buildsWithTriggerMutex.RUnlock()
 	fake.causalityMutex.RLock()
 	defer fake.causalityMutex.RUnlock()
 	fake.checkPausedMutex.RLock()
 	defer fake.checkPausedMutex.RUnlock()
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	fake.configDiffMutex.RLock()
+	defer fake.configDiffMutex.RUnlock()
+	fake.configUpdatedMutex.RLock()
+	defer fake.configUpdatedMutex.RUnlock()
 	fake.configVersionMutex.RLock()
 	defer fake.configVersionMutex.RUnlock()
+	fake.consecutiveFailuresMutex.RLock()
+	defer fake.consecutiveFailuresMutex.RUnlock()
+	fake.createJobBuildMutex.RLock()
+	defer fake.createJobBuildMutex.RUnlock()
+	fake.createJobBuildWithKeyMutex.RLock()
+	defer fake.createJobBuildWithKeyMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
 	defer fake.createOneOffBuildMutex.RUnlock()
 	fake.createStartedBuildMutex.RLock()
@@ -2641,10 +5382,22 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.exposeMutex.RUnlock()
 	fake.getAllPendingBuildsMutex.RLock()
 	defer fake.getAllPendingBuildsMutex.RUnlock()
+	fake.getBuildForOutputMutex.RLock()
+	defer fake.getBuildForOutputMutex.RUnlock()
+	fake.getBuildsForInputVersionMutex.RLock()
+	defer fake.getBuildsForInputVersionMutex.RUnlock()
 	fake.getBuildsWithVersionAsInputMutex.RLock()
 	defer fake.getBuildsWithVersionAsInputMutex.RUnlock()
 	fake.getBuildsWithVersionAsOutputMutex.RLock()
 	defer fake.getBuildsWithVersionAsOutputMutex.RUnlock()
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
+	fake.getJobsUsingResourceMutex.RLock()
+	defer fake.getJobsUsingResourceMutex.RUnlock()
+	fake.getVersionCausalityMutex.RLock()
+	defer fake.getVersionCausalityMutex.RUnlock()
+	fake.graphMutex.RLock()
+	defer fake.graphMutex.RUnlock()
 	fake.groupsMutex.RLock()
 	defer fake.groupsMutex.RUnlock()
 	fake.hideMutex.RLock()
@@ -2653,14 +5406,30 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.iDMutex.RUnlock()
 	fake.jobMutex.RLock()
 	defer fake.jobMutex.RUnlock()
+	fake.jobStatusMutex.RLock()
+	defer fake.jobStatusMutex.RUnlock()
 	fake.jobsMutex.RLock()
 	defer fake.jobsMutex.RUnlock()
+	fake.lastUpdatedMutex.RLock()
+	defer fake.lastUpdatedMutex.RUnlock()
+	fake.latestSuccessfulBuildsMutex.RLock()
+	defer fake.latestSuccessfulBuildsMutex.RUnlock()
+	fake.leaseResourceCheckingMutex.RLock()
+	defer fake.leaseResourceCheckingMutex.RUnlock()
+	fake.leaseSchedulingMutex.RLock()
+	defer fake.leaseSchedulingMutex.RUnlock()
+	fake.listConfigVersionsMutex.RLock()
+	defer fake.listConfigVersionsMutex.RUnlock()
 	fake.loadVersionsDBMutex.RLock()
 	defer fake.loadVersionsDBMutex.RUnlock()
+	fake.loadVersionsDBContextMutex.RLock()
+	defer fake.loadVersionsDBContextMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.pauseMutex.RLock()
 	defer fake.pauseMutex.RUnlock()
+	fake.pauseHistoryMutex.RLock()
+	defer fake.pauseHistoryMutex.RUnlock()
 	fake.pausedMutex.RLock()
 	defer fake.pausedMutex.RUnlock()
 	fake.publicMutex.RLock()
@@ -2669,10 +5438,14 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.reloadMutex.RUnlock()
 	fake.renameMutex.RLock()
 	defer fake.renameMutex.RUnlock()
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
 	fake.resourceByIDMutex.RLock()
 	defer fake.resourceByIDMutex.RUnlock()
+	fake.resourceReuseStatsMutex.RLock()
+	defer fake.resourceReuseStatsMutex.RUnlock()
 	fake.resourceTypeMutex.RLock()
 	defer fake.resourceTypeMutex.RUnlock()
 	fake.resourceTypeByIDMutex.RLock()
@@ -2683,12 +5456,26 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.resourceVersionMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
+	fake.revertConfigMutex.RLock()
+	defer fake.revertConfigMutex.RUnlock()
+	fake.runningBuildCountMutex.RLock()
+	defer fake.runningBuildCountMutex.RUnlock()
+	fake.schedulingLeaseHolderMutex.RLock()
+	defer fake.schedulingLeaseHolderMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.unarchiveMutex.RLock()
+	defer fake.unarchiveMutex.RUnlock()
 	fake.unpauseMutex.RLock()
 	defer fake.unpauseMutex.RUnlock()
+	fake.updateJobMutex.RLock()
+	defer fake.updateJobMutex.RUnlock()
+	fake.updateResourceConfigMutex.RLock()
+	defer fake.updateResourceConfigMutex.RUnlock()
+	fake.updateVersionMetadataMutex.RLock()
+	defer fake.updateVersionMetadataMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc/db"
 )
@@ -20,6 +21,57 @@ type FakePipelineFactory struct {
 		result1 []db.Pipeline
 		result2 error
 	}
+	PipelineStub        func(int) (db.Pipeline, bool, error)
+	pipelineMutex       sync.RWMutex
+	pipelineArgsForCall []struct {
+		arg1 int
+	}
+	pipelineReturns struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
+	pipelineReturnsOnCall map[int]struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
+	PipelinesModifiedSinceStub        func(time.Time) ([]db.Pipeline, error)
+	pipelinesModifiedSinceMutex       sync.RWMutex
+	pipelinesModifiedSinceArgsForCall []struct {
+		arg1 time.Time
+	}
+	pipelinesModifiedSinceReturns struct {
+		result1 []db.Pipeline
+		result2 error
+	}
+	pipelinesModifiedSinceReturnsOnCall map[int]struct {
+		result1 []db.Pipeline
+		result2 error
+	}
+	PruneConfigVersionsStub        func(int) error
+	pruneConfigVersionsMutex       sync.RWMutex
+	pruneConfigVersionsArgsForCall []struct {
+		arg1 int
+	}
+	pruneConfigVersionsReturns struct {
+		result1 error
+	}
+	pruneConfigVersionsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PublicPipelinesStub        func() ([]db.Pipeline, error)
+	publicPipelinesMutex       sync.RWMutex
+	publicPipelinesArgsForCall []struct {
+	}
+	publicPipelinesReturns struct {
+		result1 []db.Pipeline
+		result2 error
+	}
+	publicPipelinesReturnsOnCall map[int]struct {
+		result1 []db.Pipeline
+		result2 error
+	}
 	VisiblePipelinesStub        func([]string) ([]db.Pipeline, error)
 	visiblePipelinesMutex       sync.RWMutex
 	visiblePipelinesArgsForCall []struct {
@@ -92,6 +144,250 @@ func (fake *FakePipelineFactory) AllPipelinesReturnsOnCall(i int, result1 []db.P
 	}{result1, result2}
 }
 
+func (fake *FakePipelineFactory) Pipeline(arg1 int) (db.Pipeline, bool, error) {
+	fake.pipelineMutex.Lock()
+	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
+	fake.pipelineArgsForCall = append(fake.pipelineArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("Pipeline", []interface{}{arg1})
+	fake.pipelineMutex.Unlock()
+	if fake.PipelineStub != nil {
+		return fake.PipelineStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.pipelineReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipelineFactory) PipelineCallCount() int {
+	fake.pipelineMutex.RLock()
+	defer fake.pipelineMutex.RUnlock()
+	return len(fake.pipelineArgsForCall)
+}
+
+func (fake *FakePipelineFactory) PipelineCalls(stub func(int) (db.Pipeline, bool, error)) {
+	fake.pipelineMutex.Lock()
+	defer fake.pipelineMutex.Unlock()
+	fake.PipelineStub = stub
+}
+
+func (fake *FakePipelineFactory) PipelineArgsForCall(i int) int {
+	fake.pipelineMutex.RLock()
+	defer fake.pipelineMutex.RUnlock()
+	argsForCall := fake.pipelineArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipelineFactory) PipelineReturns(result1 db.Pipeline, result2 bool, result3 error) {
+	fake.pipelineMutex.Lock()
+	defer fake.pipelineMutex.Unlock()
+	fake.PipelineStub = nil
+	fake.pipelineReturns = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipelineFactory) PipelineReturnsOnCall(i int, result1 db.Pipeline, result2 bool, result3 error) {
+	fake.pipelineMutex.Lock()
+	defer fake.pipelineMutex.Unlock()
+	fake.PipelineStub = nil
+	if fake.pipelineReturnsOnCall == nil {
+		fake.pipelineReturnsOnCall = make(map[int]struct {
+			result1 db.Pipeline
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineReturnsOnCall[i] = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipelineFactory) PipelinesModifiedSince(arg1 time.Time) ([]db.Pipeline, error) {
+	fake.pipelinesModifiedSinceMutex.Lock()
+	ret, specificReturn := fake.pipelinesModifiedSinceReturnsOnCall[len(fake.pipelinesModifiedSinceArgsForCall)]
+	fake.pipelinesModifiedSinceArgsForCall = append(fake.pipelinesModifiedSinceArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	fake.recordInvocation("PipelinesModifiedSince", []interface{}{arg1})
+	fake.pipelinesModifiedSinceMutex.Unlock()
+	if fake.PipelinesModifiedSinceStub != nil {
+		return fake.PipelinesModifiedSinceStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.pipelinesModifiedSinceReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipelineFactory) PipelinesModifiedSinceCallCount() int {
+	fake.pipelinesModifiedSinceMutex.RLock()
+	defer fake.pipelinesModifiedSinceMutex.RUnlock()
+	return len(fake.pipelinesModifiedSinceArgsForCall)
+}
+
+func (fake *FakePipelineFactory) PipelinesModifiedSinceCalls(stub func(time.Time) ([]db.Pipeline, error)) {
+	fake.pipelinesModifiedSinceMutex.Lock()
+	defer fake.pipelinesModifiedSinceMutex.Unlock()
+	fake.PipelinesModifiedSinceStub = stub
+}
+
+func (fake *FakePipelineFactory) PipelinesModifiedSinceArgsForCall(i int) time.Time {
+	fake.pipelinesModifiedSinceMutex.RLock()
+	defer fake.pipelinesModifiedSinceMutex.RUnlock()
+	argsForCall := fake.pipelinesModifiedSinceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipelineFactory) PipelinesModifiedSinceReturns(result1 []db.Pipeline, result2 error) {
+	fake.pipelinesModifiedSinceMutex.Lock()
+	defer fake.pipelinesModifiedSinceMutex.Unlock()
+	fake.PipelinesModifiedSinceStub = nil
+	fake.pipelinesModifiedSinceReturns = struct {
+		result1 []db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineFactory) PipelinesModifiedSinceReturnsOnCall(i int, result1 []db.Pipeline, result2 error) {
+	fake.pipelinesModifiedSinceMutex.Lock()
+	defer fake.pipelinesModifiedSinceMutex.Unlock()
+	fake.PipelinesModifiedSinceStub = nil
+	if fake.pipelinesModifiedSinceReturnsOnCall == nil {
+		fake.pipelinesModifiedSinceReturnsOnCall = make(map[int]struct {
+			result1 []db.Pipeline
+			result2 error
+		})
+	}
+	fake.pipelinesModifiedSinceReturnsOnCall[i] = struct {
+		result1 []db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineFactory) PruneConfigVersions(arg1 int) error {
+	fake.pruneConfigVersionsMutex.Lock()
+	ret, specificReturn := fake.pruneConfigVersionsReturnsOnCall[len(fake.pruneConfigVersionsArgsForCall)]
+	fake.pruneConfigVersionsArgsForCall = append(fake.pruneConfigVersionsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("PruneConfigVersions", []interface{}{arg1})
+	fake.pruneConfigVersionsMutex.Unlock()
+	if fake.PruneConfigVersionsStub != nil {
+		return fake.PruneConfigVersionsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pruneConfigVersionsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipelineFactory) PruneConfigVersionsCallCount() int {
+	fake.pruneConfigVersionsMutex.RLock()
+	defer fake.pruneConfigVersionsMutex.RUnlock()
+	return len(fake.pruneConfigVersionsArgsForCall)
+}
+
+func (fake *FakePipelineFactory) PruneConfigVersionsCalls(stub func(int) error) {
+	fake.pruneConfigVersionsMutex.Lock()
+	defer fake.pruneConfigVersionsMutex.Unlock()
+	fake.PruneConfigVersionsStub = stub
+}
+
+func (fake *FakePipelineFactory) PruneConfigVersionsArgsForCall(i int) int {
+	fake.pruneConfigVersionsMutex.RLock()
+	defer fake.pruneConfigVersionsMutex.RUnlock()
+	argsForCall := fake.pruneConfigVersionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipelineFactory) PruneConfigVersionsReturns(result1 error) {
+	fake.pruneConfigVersionsMutex.Lock()
+	defer fake.pruneConfigVersionsMutex.Unlock()
+	fake.PruneConfigVersionsStub = nil
+	fake.pruneConfigVersionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineFactory) PruneConfigVersionsReturnsOnCall(i int, result1 error) {
+	fake.pruneConfigVersionsMutex.Lock()
+	defer fake.pruneConfigVersionsMutex.Unlock()
+	fake.PruneConfigVersionsStub = nil
+	if fake.pruneConfigVersionsReturnsOnCall == nil {
+		fake.pruneConfigVersionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pruneConfigVersionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineFactory) PublicPipelines() ([]db.Pipeline, error) {
+	fake.publicPipelinesMutex.Lock()
+	ret, specificReturn := fake.publicPipelinesReturnsOnCall[len(fake.publicPipelinesArgsForCall)]
+	fake.publicPipelinesArgsForCall = append(fake.publicPipelinesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PublicPipelines", []interface{}{})
+	fake.publicPipelinesMutex.Unlock()
+	if fake.PublicPipelinesStub != nil {
+		return fake.PublicPipelinesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.publicPipelinesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipelineFactory) PublicPipelinesCallCount() int {
+	fake.publicPipelinesMutex.RLock()
+	defer fake.publicPipelinesMutex.RUnlock()
+	return len(fake.publicPipelinesArgsForCall)
+}
+
+func (fake *FakePipelineFactory) PublicPipelinesCalls(stub func() ([]db.Pipeline, error)) {
+	fake.publicPipelinesMutex.Lock()
+	defer fake.publicPipelinesMutex.Unlock()
+	fake.PublicPipelinesStub = stub
+}
+
+func (fake *FakePipelineFactory) PublicPipelinesReturns(result1 []db.Pipeline, result2 error) {
+	fake.publicPipelinesMutex.Lock()
+	defer fake.publicPipelinesMutex.Unlock()
+	fake.PublicPipelinesStub = nil
+	fake.publicPipelinesReturns = struct {
+		result1 []db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineFactory) PublicPipelinesReturnsOnCall(i int, result1 []db.Pipeline, result2 error) {
+	fake.publicPipelinesMutex.Lock()
+	defer fake.publicPipelinesMutex.Unlock()
+	fake.PublicPipelinesStub = nil
+	if fake.publicPipelinesReturnsOnCall == nil {
+		fake.publicPipelinesReturnsOnCall = make(map[int]struct {
+			result1 []db.Pipeline
+			result2 error
+		})
+	}
+	fake.publicPipelinesReturnsOnCall[i] = struct {
+		result1 []db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineFactory) VisiblePipelines(arg1 []string) ([]db.Pipeline, error) {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -165,6 +461,14 @@ func (fake *FakePipelineFactory) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.allPipelinesMutex.RLock()
 	defer fake.allPipelinesMutex.RUnlock()
+	fake.pipelineMutex.RLock()
+	defer fake.pipelineMutex.RUnlock()
+	fake.pipelinesModifiedSinceMutex.RLock()
+	defer fake.pipelinesModifiedSinceMutex.RUnlock()
+	fake.pruneConfigVersionsMutex.RLock()
+	defer fake.pruneConfigVersionsMutex.RUnlock()
+	fake.publicPipelinesMutex.RLock()
+	defer fake.publicPipelinesMutex.RUnlock()
 	fake.visiblePipelinesMutex.RLock()
 	defer fake.visiblePipelinesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -104,6 +104,21 @@ type FakeResource struct {
 	enableVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	FindVersionsByMetadataStub        func(string, string, bool) ([]atc.ResourceVersion, error)
+	findVersionsByMetadataMutex       sync.RWMutex
+	findVersionsByMetadataArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}
+	findVersionsByMetadataReturns struct {
+		result1 []atc.ResourceVersion
+		result2 error
+	}
+	findVersionsByMetadataReturnsOnCall map[int]struct {
+		result1 []atc.ResourceVersion
+		result2 error
+	}
 	IDStub        func() int
 	iDMutex       sync.RWMutex
 	iDArgsForCall []struct {
@@ -164,6 +179,26 @@ type FakeResource struct {
 	notifyScanReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PauseStub        func() error
+	pauseMutex       sync.RWMutex
+	pauseArgsForCall []struct {
+	}
+	pauseReturns struct {
+		result1 error
+	}
+	pauseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PausedStub        func() bool
+	pausedMutex       sync.RWMutex
+	pausedArgsForCall []struct {
+	}
+	pausedReturns struct {
+		result1 bool
+	}
+	pausedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	PinCommentStub        func() string
 	pinCommentMutex       sync.RWMutex
 	pinCommentArgsForCall []struct {
@@ -355,6 +390,16 @@ type FakeResource struct {
 	typeReturnsOnCall map[int]struct {
 		result1 string
 	}
+	UnpauseStub        func() error
+	unpauseMutex       sync.RWMutex
+	unpauseArgsForCall []struct {
+	}
+	unpauseReturns struct {
+		result1 error
+	}
+	unpauseReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UnpinVersionStub        func() error
 	unpinVersionMutex       sync.RWMutex
 	unpinVersionArgsForCall []struct {
@@ -880,6 +925,71 @@ func (fake *FakeResource) EnableVersionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) FindVersionsByMetadata(arg1 string, arg2 string, arg3 bool) ([]atc.ResourceVersion, error) {
+	fake.findVersionsByMetadataMutex.Lock()
+	ret, specificReturn := fake.findVersionsByMetadataReturnsOnCall[len(fake.findVersionsByMetadataArgsForCall)]
+	fake.findVersionsByMetadataArgsForCall = append(fake.findVersionsByMetadataArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("FindVersionsByMetadata", []interface{}{arg1, arg2, arg3})
+	fake.findVersionsByMetadataMutex.Unlock()
+	if fake.FindVersionsByMetadataStub != nil {
+		return fake.FindVersionsByMetadataStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.findVersionsByMetadataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) FindVersionsByMetadataCallCount() int {
+	fake.findVersionsByMetadataMutex.RLock()
+	defer fake.findVersionsByMetadataMutex.RUnlock()
+	return len(fake.findVersionsByMetadataArgsForCall)
+}
+
+func (fake *FakeResource) FindVersionsByMetadataCalls(stub func(string, string, bool) ([]atc.ResourceVersion, error)) {
+	fake.findVersionsByMetadataMutex.Lock()
+	defer fake.findVersionsByMetadataMutex.Unlock()
+	fake.FindVersionsByMetadataStub = stub
+}
+
+func (fake *FakeResource) FindVersionsByMetadataArgsForCall(i int) (string, string, bool) {
+	fake.findVersionsByMetadataMutex.RLock()
+	defer fake.findVersionsByMetadataMutex.RUnlock()
+	argsForCall := fake.findVersionsByMetadataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeResource) FindVersionsByMetadataReturns(result1 []atc.ResourceVersion, result2 error) {
+	fake.findVersionsByMetadataMutex.Lock()
+	defer fake.findVersionsByMetadataMutex.Unlock()
+	fake.FindVersionsByMetadataStub = nil
+	fake.findVersionsByMetadataReturns = struct {
+		result1 []atc.ResourceVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) FindVersionsByMetadataReturnsOnCall(i int, result1 []atc.ResourceVersion, result2 error) {
+	fake.findVersionsByMetadataMutex.Lock()
+	defer fake.findVersionsByMetadataMutex.Unlock()
+	fake.FindVersionsByMetadataStub = nil
+	if fake.findVersionsByMetadataReturnsOnCall == nil {
+		fake.findVersionsByMetadataReturnsOnCall = make(map[int]struct {
+			result1 []atc.ResourceVersion
+			result2 error
+		})
+	}
+	fake.findVersionsByMetadataReturnsOnCall[i] = struct {
+		result1 []atc.ResourceVersion
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) ID() int {
 	fake.iDMutex.Lock()
 	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
@@ -1192,6 +1302,110 @@ func (fake *FakeResource) NotifyScanReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) Pause() error {
+	fake.pauseMutex.Lock()
+	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
+	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Pause", []interface{}{})
+	fake.pauseMutex.Unlock()
+	if fake.PauseStub != nil {
+		return fake.PauseStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pauseReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) PauseCallCount() int {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return len(fake.pauseArgsForCall)
+}
+
+func (fake *FakeResource) PauseCalls(stub func() error) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = stub
+}
+
+func (fake *FakeResource) PauseReturns(result1 error) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = nil
+	fake.pauseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) PauseReturnsOnCall(i int, result1 error) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = nil
+	if fake.pauseReturnsOnCall == nil {
+		fake.pauseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pauseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) Paused() bool {
+	fake.pausedMutex.Lock()
+	ret, specificReturn := fake.pausedReturnsOnCall[len(fake.pausedArgsForCall)]
+	fake.pausedArgsForCall = append(fake.pausedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Paused", []interface{}{})
+	fake.pausedMutex.Unlock()
+	if fake.PausedStub != nil {
+		return fake.PausedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pausedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) PausedCallCount() int {
+	fake.pausedMutex.RLock()
+	defer fake.pausedMutex.RUnlock()
+	return len(fake.pausedArgsForCall)
+}
+
+func (fake *FakeResource) PausedCalls(stub func() bool) {
+	fake.pausedMutex.Lock()
+	defer fake.pausedMutex.Unlock()
+	fake.PausedStub = stub
+}
+
+func (fake *FakeResource) PausedReturns(result1 bool) {
+	fake.pausedMutex.Lock()
+	defer fake.pausedMutex.Unlock()
+	fake.PausedStub = nil
+	fake.pausedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResource) PausedReturnsOnCall(i int, result1 bool) {
+	fake.pausedMutex.Lock()
+	defer fake.pausedMutex.Unlock()
+	fake.PausedStub = nil
+	if fake.pausedReturnsOnCall == nil {
+		fake.pausedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.pausedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeResource) PinComment() string {
 	fake.pinCommentMutex.Lock()
 	ret, specificReturn := fake.pinCommentReturnsOnCall[len(fake.pinCommentArgsForCall)]
@@ -2144,6 +2358,58 @@ func (fake *FakeResource) TypeReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) Unpause() error {
+	fake.unpauseMutex.Lock()
+	ret, specificReturn := fake.unpauseReturnsOnCall[len(fake.unpauseArgsForCall)]
+	fake.unpauseArgsForCall = append(fake.unpauseArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Unpause", []interface{}{})
+	fake.unpauseMutex.Unlock()
+	if fake.UnpauseStub != nil {
+		return fake.UnpauseStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.unpauseReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) UnpauseCallCount() int {
+	fake.unpauseMutex.RLock()
+	defer fake.unpauseMutex.RUnlock()
+	return len(fake.unpauseArgsForCall)
+}
+
+func (fake *FakeResource) UnpauseCalls(stub func() error) {
+	fake.unpauseMutex.Lock()
+	defer fake.unpauseMutex.Unlock()
+	fake.UnpauseStub = stub
+}
+
+func (fake *FakeResource) UnpauseReturns(result1 error) {
+	fake.unpauseMutex.Lock()
+	defer fake.unpauseMutex.Unlock()
+	fake.UnpauseStub = nil
+	fake.unpauseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) UnpauseReturnsOnCall(i int, result1 error) {
+	fake.unpauseMutex.Lock()
+	defer fake.unpauseMutex.Unlock()
+	fake.UnpauseStub = nil
+	if fake.unpauseReturnsOnCall == nil {
+		fake.unpauseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unpauseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) UnpinVersion() error {
 	fake.unpinVersionMutex.Lock()
 	ret, specificReturn := fake.unpinVersionReturnsOnCall[len(fake.unpinVersionArgsForCall)]
@@ -2338,6 +2604,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.disableVersionMutex.RUnlock()
 	fake.enableVersionMutex.RLock()
 	defer fake.enableVersionMutex.RUnlock()
+	fake.findVersionsByMetadataMutex.RLock()
+	defer fake.findVersionsByMetadataMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
 	fake.iconMutex.RLock()
@@ -2350,6 +2618,10 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.nameMutex.RUnlock()
 	fake.notifyScanMutex.RLock()
 	defer fake.notifyScanMutex.RUnlock()
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	fake.pausedMutex.RLock()
+	defer fake.pausedMutex.RUnlock()
 	fake.pinCommentMutex.RLock()
 	defer fake.pinCommentMutex.RUnlock()
 	fake.pinVersionMutex.RLock()
@@ -2384,6 +2656,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.teamNameMutex.RUnlock()
 	fake.typeMutex.RLock()
 	defer fake.typeMutex.RUnlock()
+	fake.unpauseMutex.RLock()
+	defer fake.unpauseMutex.RUnlock()
 	fake.unpinVersionMutex.RLock()
 	defer fake.unpinVersionMutex.RUnlock()
 	fake.versionsMutex.RLock()
@@ -47,6 +47,38 @@ type FakeTeam struct {
 		result2 db.Pagination
 		result3 error
 	}
+	BuildsBetweenStub        func(time.Time, time.Time, int) ([]db.Build, error)
+	buildsBetweenMutex       sync.RWMutex
+	buildsBetweenArgsForCall []struct {
+		arg1 time.Time
+		arg2 time.Time
+		arg3 int
+	}
+	buildsBetweenReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	buildsBetweenReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
+	BuildsWithLabelStub        func(string, string, db.Page) ([]db.Build, db.Pagination, error)
+	buildsWithLabelMutex       sync.RWMutex
+	buildsWithLabelArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 db.Page
+	}
+	buildsWithLabelReturns struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}
+	buildsWithLabelReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}
 	BuildsWithTimeStub        func(db.Page) ([]db.Build, db.Pagination, error)
 	buildsWithTimeMutex       sync.RWMutex
 	buildsWithTimeArgsForCall []struct {
@@ -62,6 +94,20 @@ type FakeTeam struct {
 		result2 db.Pagination
 		result3 error
 	}
+	ClonePipelineStub        func(string, string) (db.Pipeline, error)
+	clonePipelineMutex       sync.RWMutex
+	clonePipelineArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	clonePipelineReturns struct {
+		result1 db.Pipeline
+		result2 error
+	}
+	clonePipelineReturnsOnCall map[int]struct {
+		result1 db.Pipeline
+		result2 error
+	}
 	ContainersStub        func(lager.Logger) ([]db.Container, error)
 	containersMutex       sync.RWMutex
 	containersArgsForCall []struct {
@@ -87,6 +133,19 @@ type FakeTeam struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateOneOffBuildWithLabelsStub        func(map[string]string) (db.Build, error)
+	createOneOffBuildWithLabelsMutex       sync.RWMutex
+	createOneOffBuildWithLabelsArgsForCall []struct {
+		arg1 map[string]string
+	}
+	createOneOffBuildWithLabelsReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createOneOffBuildWithLabelsReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	CreateStartedBuildStub        func(atc.Plan) (db.Build, error)
 	createStartedBuildMutex       sync.RWMutex
 	createStartedBuildArgsForCall []struct {
@@ -100,15 +159,17 @@ type FakeTeam struct {
 		result1 db.Build
 		result2 error
 	}
-	DeleteStub        func() error
+	DeleteStub        func() (db.TeamDeletion, error)
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
 	}
 	deleteReturns struct {
-		result1 error
+		result1 db.TeamDeletion
+		result2 error
 	}
 	deleteReturnsOnCall map[int]struct {
-		result1 error
+		result1 db.TeamDeletion
+		result2 error
 	}
 	FindCheckContainersStub        func(lager.Logger, string, string, creds.Secrets) ([]db.Container, map[int]time.Time, error)
 	findCheckContainersMutex       sync.RWMutex
@@ -289,6 +350,18 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	PipelineCountStub        func() (int, error)
+	pipelineCountMutex       sync.RWMutex
+	pipelineCountArgsForCall []struct {
+	}
+	pipelineCountReturns struct {
+		result1 int
+		result2 error
+	}
+	pipelineCountReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	PipelinesStub        func() ([]db.Pipeline, error)
 	pipelinesMutex       sync.RWMutex
 	pipelinesArgsForCall []struct {
@@ -301,6 +374,18 @@ type FakeTeam struct {
 		result1 []db.Pipeline
 		result2 error
 	}
+	PipelinesIncludingArchivedStub        func() ([]db.Pipeline, error)
+	pipelinesIncludingArchivedMutex       sync.RWMutex
+	pipelinesIncludingArchivedArgsForCall []struct {
+	}
+	pipelinesIncludingArchivedReturns struct {
+		result1 []db.Pipeline
+		result2 error
+	}
+	pipelinesIncludingArchivedReturnsOnCall map[int]struct {
+		result1 []db.Pipeline
+		result2 error
+	}
 	PrivateAndPublicBuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
 	privateAndPublicBuildsMutex       sync.RWMutex
 	privateAndPublicBuildsArgsForCall []struct {
@@ -328,6 +413,18 @@ type FakeTeam struct {
 		result1 []db.Pipeline
 		result2 error
 	}
+	QuotasStub        func() (db.Quotas, error)
+	quotasMutex       sync.RWMutex
+	quotasArgsForCall []struct {
+	}
+	quotasReturns struct {
+		result1 db.Quotas
+		result2 error
+	}
+	quotasReturnsOnCall map[int]struct {
+		result1 db.Quotas
+		result2 error
+	}
 	RenameStub        func(string) error
 	renameMutex       sync.RWMutex
 	renameArgsForCall []struct {
@@ -339,6 +436,36 @@ type FakeTeam struct {
 	renameReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RunningBuildCountStub        func() (int, error)
+	runningBuildCountMutex       sync.RWMutex
+	runningBuildCountArgsForCall []struct {
+	}
+	runningBuildCountReturns struct {
+		result1 int
+		result2 error
+	}
+	runningBuildCountReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	SaveConfigCASStub        func(string, atc.Config, db.ConfigVersion, db.PipelinePausedState) (db.Pipeline, db.ConfigVersion, error)
+	saveConfigCASMutex       sync.RWMutex
+	saveConfigCASArgsForCall []struct {
+		arg1 string
+		arg2 atc.Config
+		arg3 db.ConfigVersion
+		arg4 db.PipelinePausedState
+	}
+	saveConfigCASReturns struct {
+		result1 db.Pipeline
+		result2 db.ConfigVersion
+		result3 error
+	}
+	saveConfigCASReturnsOnCall map[int]struct {
+		result1 db.Pipeline
+		result2 db.ConfigVersion
+		result3 error
+	}
 	SavePipelineStub        func(string, atc.Config, db.ConfigVersion, db.PipelinePausedState) (db.Pipeline, bool, error)
 	savePipelineMutex       sync.RWMutex
 	savePipelineArgsForCall []struct {
@@ -371,6 +498,29 @@ type FakeTeam struct {
 		result1 db.Worker
 		result2 error
 	}
+	SetQuotasStub        func(db.Quotas) error
+	setQuotasMutex       sync.RWMutex
+	setQuotasArgsForCall []struct {
+		arg1 db.Quotas
+	}
+	setQuotasReturns struct {
+		result1 error
+	}
+	setQuotasReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TransferPipelineStub        func(string, string) error
+	transferPipelineMutex       sync.RWMutex
+	transferPipelineArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	transferPipelineReturns struct {
+		result1 error
+	}
+	transferPipelineReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateProviderAuthStub        func(atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
@@ -580,6 +730,139 @@ func (fake *FakeTeam) BuildsReturnsOnCall(i int, result1 []db.Build, result2 db.
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) BuildsBetween(arg1 time.Time, arg2 time.Time, arg3 int) ([]db.Build, error) {
+	fake.buildsBetweenMutex.Lock()
+	ret, specificReturn := fake.buildsBetweenReturnsOnCall[len(fake.buildsBetweenArgsForCall)]
+	fake.buildsBetweenArgsForCall = append(fake.buildsBetweenArgsForCall, struct {
+		arg1 time.Time
+		arg2 time.Time
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("BuildsBetween", []interface{}{arg1, arg2, arg3})
+	fake.buildsBetweenMutex.Unlock()
+	if fake.BuildsBetweenStub != nil {
+		return fake.BuildsBetweenStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.buildsBetweenReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) BuildsBetweenCallCount() int {
+	fake.buildsBetweenMutex.RLock()
+	defer fake.buildsBetweenMutex.RUnlock()
+	return len(fake.buildsBetweenArgsForCall)
+}
+
+func (fake *FakeTeam) BuildsBetweenCalls(stub func(time.Time, time.Time, int) ([]db.Build, error)) {
+	fake.buildsBetweenMutex.Lock()
+	defer fake.buildsBetweenMutex.Unlock()
+	fake.BuildsBetweenStub = stub
+}
+
+func (fake *FakeTeam) BuildsBetweenArgsForCall(i int) (time.Time, time.Time, int) {
+	fake.buildsBetweenMutex.RLock()
+	defer fake.buildsBetweenMutex.RUnlock()
+	argsForCall := fake.buildsBetweenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) BuildsBetweenReturns(result1 []db.Build, result2 error) {
+	fake.buildsBetweenMutex.Lock()
+	defer fake.buildsBetweenMutex.Unlock()
+	fake.BuildsBetweenStub = nil
+	fake.buildsBetweenReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) BuildsBetweenReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.buildsBetweenMutex.Lock()
+	defer fake.buildsBetweenMutex.Unlock()
+	fake.BuildsBetweenStub = nil
+	if fake.buildsBetweenReturnsOnCall == nil {
+		fake.buildsBetweenReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.buildsBetweenReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) BuildsWithLabel(arg1 string, arg2 string, arg3 db.Page) ([]db.Build, db.Pagination, error) {
+	fake.buildsWithLabelMutex.Lock()
+	ret, specificReturn := fake.buildsWithLabelReturnsOnCall[len(fake.buildsWithLabelArgsForCall)]
+	fake.buildsWithLabelArgsForCall = append(fake.buildsWithLabelArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 db.Page
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("BuildsWithLabel", []interface{}{arg1, arg2, arg3})
+	fake.buildsWithLabelMutex.Unlock()
+	if fake.BuildsWithLabelStub != nil {
+		return fake.BuildsWithLabelStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.buildsWithLabelReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) BuildsWithLabelCallCount() int {
+	fake.buildsWithLabelMutex.RLock()
+	defer fake.buildsWithLabelMutex.RUnlock()
+	return len(fake.buildsWithLabelArgsForCall)
+}
+
+func (fake *FakeTeam) BuildsWithLabelCalls(stub func(string, string, db.Page) ([]db.Build, db.Pagination, error)) {
+	fake.buildsWithLabelMutex.Lock()
+	defer fake.buildsWithLabelMutex.Unlock()
+	fake.BuildsWithLabelStub = stub
+}
+
+func (fake *FakeTeam) BuildsWithLabelArgsForCall(i int) (string, string, db.Page) {
+	fake.buildsWithLabelMutex.RLock()
+	defer fake.buildsWithLabelMutex.RUnlock()
+	argsForCall := fake.buildsWithLabelArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) BuildsWithLabelReturns(result1 []db.Build, result2 db.Pagination, result3 error) {
+	fake.buildsWithLabelMutex.Lock()
+	defer fake.buildsWithLabelMutex.Unlock()
+	fake.BuildsWithLabelStub = nil
+	fake.buildsWithLabelReturns = struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) BuildsWithLabelReturnsOnCall(i int, result1 []db.Build, result2 db.Pagination, result3 error) {
+	fake.buildsWithLabelMutex.Lock()
+	defer fake.buildsWithLabelMutex.Unlock()
+	fake.BuildsWithLabelStub = nil
+	if fake.buildsWithLabelReturnsOnCall == nil {
+		fake.buildsWithLabelReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.buildsWithLabelReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) BuildsWithTime(arg1 db.Page) ([]db.Build, db.Pagination, error) {
 	fake.buildsWithTimeMutex.Lock()
 	ret, specificReturn := fake.buildsWithTimeReturnsOnCall[len(fake.buildsWithTimeArgsForCall)]
@@ -646,6 +929,70 @@ func (fake *FakeTeam) BuildsWithTimeReturnsOnCall(i int, result1 []db.Build, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) ClonePipeline(arg1 string, arg2 string) (db.Pipeline, error) {
+	fake.clonePipelineMutex.Lock()
+	ret, specificReturn := fake.clonePipelineReturnsOnCall[len(fake.clonePipelineArgsForCall)]
+	fake.clonePipelineArgsForCall = append(fake.clonePipelineArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ClonePipeline", []interface{}{arg1, arg2})
+	fake.clonePipelineMutex.Unlock()
+	if fake.ClonePipelineStub != nil {
+		return fake.ClonePipelineStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.clonePipelineReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ClonePipelineCallCount() int {
+	fake.clonePipelineMutex.RLock()
+	defer fake.clonePipelineMutex.RUnlock()
+	return len(fake.clonePipelineArgsForCall)
+}
+
+func (fake *FakeTeam) ClonePipelineCalls(stub func(string, string) (db.Pipeline, error)) {
+	fake.clonePipelineMutex.Lock()
+	defer fake.clonePipelineMutex.Unlock()
+	fake.ClonePipelineStub = stub
+}
+
+func (fake *FakeTeam) ClonePipelineArgsForCall(i int) (string, string) {
+	fake.clonePipelineMutex.RLock()
+	defer fake.clonePipelineMutex.RUnlock()
+	argsForCall := fake.clonePipelineArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) ClonePipelineReturns(result1 db.Pipeline, result2 error) {
+	fake.clonePipelineMutex.Lock()
+	defer fake.clonePipelineMutex.Unlock()
+	fake.ClonePipelineStub = nil
+	fake.clonePipelineReturns = struct {
+		result1 db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ClonePipelineReturnsOnCall(i int, result1 db.Pipeline, result2 error) {
+	fake.clonePipelineMutex.Lock()
+	defer fake.clonePipelineMutex.Unlock()
+	fake.ClonePipelineStub = nil
+	if fake.clonePipelineReturnsOnCall == nil {
+		fake.clonePipelineReturnsOnCall = make(map[int]struct {
+			result1 db.Pipeline
+			result2 error
+		})
+	}
+	fake.clonePipelineReturnsOnCall[i] = struct {
+		result1 db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Containers(arg1 lager.Logger) ([]db.Container, error) {
 	fake.containersMutex.Lock()
 	ret, specificReturn := fake.containersReturnsOnCall[len(fake.containersArgsForCall)]
@@ -764,6 +1111,69 @@ func (fake *FakeTeam) CreateOneOffBuildReturnsOnCall(i int, result1 db.Build, re
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateOneOffBuildWithLabels(arg1 map[string]string) (db.Build, error) {
+	fake.createOneOffBuildWithLabelsMutex.Lock()
+	ret, specificReturn := fake.createOneOffBuildWithLabelsReturnsOnCall[len(fake.createOneOffBuildWithLabelsArgsForCall)]
+	fake.createOneOffBuildWithLabelsArgsForCall = append(fake.createOneOffBuildWithLabelsArgsForCall, struct {
+		arg1 map[string]string
+	}{arg1})
+	fake.recordInvocation("CreateOneOffBuildWithLabels", []interface{}{arg1})
+	fake.createOneOffBuildWithLabelsMutex.Unlock()
+	if fake.CreateOneOffBuildWithLabelsStub != nil {
+		return fake.CreateOneOffBuildWithLabelsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createOneOffBuildWithLabelsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateOneOffBuildWithLabelsCallCount() int {
+	fake.createOneOffBuildWithLabelsMutex.RLock()
+	defer fake.createOneOffBuildWithLabelsMutex.RUnlock()
+	return len(fake.createOneOffBuildWithLabelsArgsForCall)
+}
+
+func (fake *FakeTeam) CreateOneOffBuildWithLabelsCalls(stub func(map[string]string) (db.Build, error)) {
+	fake.createOneOffBuildWithLabelsMutex.Lock()
+	defer fake.createOneOffBuildWithLabelsMutex.Unlock()
+	fake.CreateOneOffBuildWithLabelsStub = stub
+}
+
+func (fake *FakeTeam) CreateOneOffBuildWithLabelsArgsForCall(i int) map[string]string {
+	fake.createOneOffBuildWithLabelsMutex.RLock()
+	defer fake.createOneOffBuildWithLabelsMutex.RUnlock()
+	argsForCall := fake.createOneOffBuildWithLabelsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) CreateOneOffBuildWithLabelsReturns(result1 db.Build, result2 error) {
+	fake.createOneOffBuildWithLabelsMutex.Lock()
+	defer fake.createOneOffBuildWithLabelsMutex.Unlock()
+	fake.CreateOneOffBuildWithLabelsStub = nil
+	fake.createOneOffBuildWithLabelsReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateOneOffBuildWithLabelsReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createOneOffBuildWithLabelsMutex.Lock()
+	defer fake.createOneOffBuildWithLabelsMutex.Unlock()
+	fake.CreateOneOffBuildWithLabelsStub = nil
+	if fake.createOneOffBuildWithLabelsReturnsOnCall == nil {
+		fake.createOneOffBuildWithLabelsReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createOneOffBuildWithLabelsReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) CreateStartedBuild(arg1 atc.Plan) (db.Build, error) {
 	fake.createStartedBuildMutex.Lock()
 	ret, specificReturn := fake.createStartedBuildReturnsOnCall[len(fake.createStartedBuildArgsForCall)]
@@ -827,7 +1237,7 @@ func (fake *FakeTeam) CreateStartedBuildReturnsOnCall(i int, result1 db.Build, r
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) Delete() error {
+func (fake *FakeTeam) Delete() (db.TeamDeletion, error) {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
@@ -838,10 +1248,10 @@ func (fake *FakeTeam) Delete() error {
 		return fake.DeleteStub()
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
 	fakeReturns := fake.deleteReturns
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeTeam) DeleteCallCount() int {
@@ -850,33 +1260,36 @@ func (fake *FakeTeam) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *FakeTeam) DeleteCalls(stub func() error) {
+func (fake *FakeTeam) DeleteCalls(stub func() (db.TeamDeletion, error)) {
 	fake.deleteMutex.Lock()
 	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = stub
 }
 
-func (fake *FakeTeam) DeleteReturns(result1 error) {
+func (fake *FakeTeam) DeleteReturns(result1 db.TeamDeletion, result2 error) {
 	fake.deleteMutex.Lock()
 	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = nil
 	fake.deleteReturns = struct {
-		result1 error
-	}{result1}
+		result1 db.TeamDeletion
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeTeam) DeleteReturnsOnCall(i int, result1 error) {
+func (fake *FakeTeam) DeleteReturnsOnCall(i int, result1 db.TeamDeletion, result2 error) {
 	fake.deleteMutex.Lock()
 	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = nil
 	if fake.deleteReturnsOnCall == nil {
 		fake.deleteReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 db.TeamDeletion
+			result2 error
 		})
 	}
 	fake.deleteReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 db.TeamDeletion
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeTeam) FindCheckContainers(arg1 lager.Logger, arg2 string, arg3 string, arg4 creds.Secrets) ([]db.Container, map[int]time.Time, error) {
@@ -1703,6 +2116,61 @@ func (fake *FakeTeam) PipelineReturnsOnCall(i int, result1 db.Pipeline, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) PipelineCount() (int, error) {
+	fake.pipelineCountMutex.Lock()
+	ret, specificReturn := fake.pipelineCountReturnsOnCall[len(fake.pipelineCountArgsForCall)]
+	fake.pipelineCountArgsForCall = append(fake.pipelineCountArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineCount", []interface{}{})
+	fake.pipelineCountMutex.Unlock()
+	if fake.PipelineCountStub != nil {
+		return fake.PipelineCountStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.pipelineCountReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) PipelineCountCallCount() int {
+	fake.pipelineCountMutex.RLock()
+	defer fake.pipelineCountMutex.RUnlock()
+	return len(fake.pipelineCountArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineCountCalls(stub func() (int, error)) {
+	fake.pipelineCountMutex.Lock()
+	defer fake.pipelineCountMutex.Unlock()
+	fake.PipelineCountStub = stub
+}
+
+func (fake *FakeTeam) PipelineCountReturns(result1 int, result2 error) {
+	fake.pipelineCountMutex.Lock()
+	defer fake.pipelineCountMutex.Unlock()
+	fake.PipelineCountStub = nil
+	fake.pipelineCountReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PipelineCountReturnsOnCall(i int, result1 int, result2 error) {
+	fake.pipelineCountMutex.Lock()
+	defer fake.pipelineCountMutex.Unlock()
+	fake.PipelineCountStub = nil
+	if fake.pipelineCountReturnsOnCall == nil {
+		fake.pipelineCountReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.pipelineCountReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Pipelines() ([]db.Pipeline, error) {
 	fake.pipelinesMutex.Lock()
 	ret, specificReturn := fake.pipelinesReturnsOnCall[len(fake.pipelinesArgsForCall)]
@@ -1758,6 +2226,61 @@ func (fake *FakeTeam) PipelinesReturnsOnCall(i int, result1 []db.Pipeline, resul
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) PipelinesIncludingArchived() ([]db.Pipeline, error) {
+	fake.pipelinesIncludingArchivedMutex.Lock()
+	ret, specificReturn := fake.pipelinesIncludingArchivedReturnsOnCall[len(fake.pipelinesIncludingArchivedArgsForCall)]
+	fake.pipelinesIncludingArchivedArgsForCall = append(fake.pipelinesIncludingArchivedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelinesIncludingArchived", []interface{}{})
+	fake.pipelinesIncludingArchivedMutex.Unlock()
+	if fake.PipelinesIncludingArchivedStub != nil {
+		return fake.PipelinesIncludingArchivedStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.pipelinesIncludingArchivedReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) PipelinesIncludingArchivedCallCount() int {
+	fake.pipelinesIncludingArchivedMutex.RLock()
+	defer fake.pipelinesIncludingArchivedMutex.RUnlock()
+	return len(fake.pipelinesIncludingArchivedArgsForCall)
+}
+
+func (fake *FakeTeam) PipelinesIncludingArchivedCalls(stub func() ([]db.Pipeline, error)) {
+	fake.pipelinesIncludingArchivedMutex.Lock()
+	defer fake.pipelinesIncludingArchivedMutex.Unlock()
+	fake.PipelinesIncludingArchivedStub = stub
+}
+
+func (fake *FakeTeam) PipelinesIncludingArchivedReturns(result1 []db.Pipeline, result2 error) {
+	fake.pipelinesIncludingArchivedMutex.Lock()
+	defer fake.pipelinesIncludingArchivedMutex.Unlock()
+	fake.PipelinesIncludingArchivedStub = nil
+	fake.pipelinesIncludingArchivedReturns = struct {
+		result1 []db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PipelinesIncludingArchivedReturnsOnCall(i int, result1 []db.Pipeline, result2 error) {
+	fake.pipelinesIncludingArchivedMutex.Lock()
+	defer fake.pipelinesIncludingArchivedMutex.Unlock()
+	fake.PipelinesIncludingArchivedStub = nil
+	if fake.pipelinesIncludingArchivedReturnsOnCall == nil {
+		fake.pipelinesIncludingArchivedReturnsOnCall = make(map[int]struct {
+			result1 []db.Pipeline
+			result2 error
+		})
+	}
+	fake.pipelinesIncludingArchivedReturnsOnCall[i] = struct {
+		result1 []db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) PrivateAndPublicBuilds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
 	fake.privateAndPublicBuildsMutex.Lock()
 	ret, specificReturn := fake.privateAndPublicBuildsReturnsOnCall[len(fake.privateAndPublicBuildsArgsForCall)]
@@ -1879,6 +2402,61 @@ func (fake *FakeTeam) PublicPipelinesReturnsOnCall(i int, result1 []db.Pipeline,
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) Quotas() (db.Quotas, error) {
+	fake.quotasMutex.Lock()
+	ret, specificReturn := fake.quotasReturnsOnCall[len(fake.quotasArgsForCall)]
+	fake.quotasArgsForCall = append(fake.quotasArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Quotas", []interface{}{})
+	fake.quotasMutex.Unlock()
+	if fake.QuotasStub != nil {
+		return fake.QuotasStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.quotasReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) QuotasCallCount() int {
+	fake.quotasMutex.RLock()
+	defer fake.quotasMutex.RUnlock()
+	return len(fake.quotasArgsForCall)
+}
+
+func (fake *FakeTeam) QuotasCalls(stub func() (db.Quotas, error)) {
+	fake.quotasMutex.Lock()
+	defer fake.quotasMutex.Unlock()
+	fake.QuotasStub = stub
+}
+
+func (fake *FakeTeam) QuotasReturns(result1 db.Quotas, result2 error) {
+	fake.quotasMutex.Lock()
+	defer fake.quotasMutex.Unlock()
+	fake.QuotasStub = nil
+	fake.quotasReturns = struct {
+		result1 db.Quotas
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) QuotasReturnsOnCall(i int, result1 db.Quotas, result2 error) {
+	fake.quotasMutex.Lock()
+	defer fake.quotasMutex.Unlock()
+	fake.QuotasStub = nil
+	if fake.quotasReturnsOnCall == nil {
+		fake.quotasReturnsOnCall = make(map[int]struct {
+			result1 db.Quotas
+			result2 error
+		})
+	}
+	fake.quotasReturnsOnCall[i] = struct {
+		result1 db.Quotas
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Rename(arg1 string) error {
 	fake.renameMutex.Lock()
 	ret, specificReturn := fake.renameReturnsOnCall[len(fake.renameArgsForCall)]
@@ -1939,6 +2517,130 @@ func (fake *FakeTeam) RenameReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) RunningBuildCount() (int, error) {
+	fake.runningBuildCountMutex.Lock()
+	ret, specificReturn := fake.runningBuildCountReturnsOnCall[len(fake.runningBuildCountArgsForCall)]
+	fake.runningBuildCountArgsForCall = append(fake.runningBuildCountArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RunningBuildCount", []interface{}{})
+	fake.runningBuildCountMutex.Unlock()
+	if fake.RunningBuildCountStub != nil {
+		return fake.RunningBuildCountStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.runningBuildCountReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RunningBuildCountCallCount() int {
+	fake.runningBuildCountMutex.RLock()
+	defer fake.runningBuildCountMutex.RUnlock()
+	return len(fake.runningBuildCountArgsForCall)
+}
+
+func (fake *FakeTeam) RunningBuildCountCalls(stub func() (int, error)) {
+	fake.runningBuildCountMutex.Lock()
+	defer fake.runningBuildCountMutex.Unlock()
+	fake.RunningBuildCountStub = stub
+}
+
+func (fake *FakeTeam) RunningBuildCountReturns(result1 int, result2 error) {
+	fake.runningBuildCountMutex.Lock()
+	defer fake.runningBuildCountMutex.Unlock()
+	fake.RunningBuildCountStub = nil
+	fake.runningBuildCountReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RunningBuildCountReturnsOnCall(i int, result1 int, result2 error) {
+	fake.runningBuildCountMutex.Lock()
+	defer fake.runningBuildCountMutex.Unlock()
+	fake.RunningBuildCountStub = nil
+	if fake.runningBuildCountReturnsOnCall == nil {
+		fake.runningBuildCountReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.runningBuildCountReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SaveConfigCAS(arg1 string, arg2 atc.Config, arg3 db.ConfigVersion, arg4 db.PipelinePausedState) (db.Pipeline, db.ConfigVersion, error) {
+	fake.saveConfigCASMutex.Lock()
+	ret, specificReturn := fake.saveConfigCASReturnsOnCall[len(fake.saveConfigCASArgsForCall)]
+	fake.saveConfigCASArgsForCall = append(fake.saveConfigCASArgsForCall, struct {
+		arg1 string
+		arg2 atc.Config
+		arg3 db.ConfigVersion
+		arg4 db.PipelinePausedState
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("SaveConfigCAS", []interface{}{arg1, arg2, arg3, arg4})
+	fake.saveConfigCASMutex.Unlock()
+	if fake.SaveConfigCASStub != nil {
+		return fake.SaveConfigCASStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.saveConfigCASReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) SaveConfigCASCallCount() int {
+	fake.saveConfigCASMutex.RLock()
+	defer fake.saveConfigCASMutex.RUnlock()
+	return len(fake.saveConfigCASArgsForCall)
+}
+
+func (fake *FakeTeam) SaveConfigCASCalls(stub func(string, atc.Config, db.ConfigVersion, db.PipelinePausedState) (db.Pipeline, db.ConfigVersion, error)) {
+	fake.saveConfigCASMutex.Lock()
+	defer fake.saveConfigCASMutex.Unlock()
+	fake.SaveConfigCASStub = stub
+}
+
+func (fake *FakeTeam) SaveConfigCASArgsForCall(i int) (string, atc.Config, db.ConfigVersion, db.PipelinePausedState) {
+	fake.saveConfigCASMutex.RLock()
+	defer fake.saveConfigCASMutex.RUnlock()
+	argsForCall := fake.saveConfigCASArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTeam) SaveConfigCASReturns(result1 db.Pipeline, result2 db.ConfigVersion, result3 error) {
+	fake.saveConfigCASMutex.Lock()
+	defer fake.saveConfigCASMutex.Unlock()
+	fake.SaveConfigCASStub = nil
+	fake.saveConfigCASReturns = struct {
+		result1 db.Pipeline
+		result2 db.ConfigVersion
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SaveConfigCASReturnsOnCall(i int, result1 db.Pipeline, result2 db.ConfigVersion, result3 error) {
+	fake.saveConfigCASMutex.Lock()
+	defer fake.saveConfigCASMutex.Unlock()
+	fake.SaveConfigCASStub = nil
+	if fake.saveConfigCASReturnsOnCall == nil {
+		fake.saveConfigCASReturnsOnCall = make(map[int]struct {
+			result1 db.Pipeline
+			result2 db.ConfigVersion
+			result3 error
+		})
+	}
+	fake.saveConfigCASReturnsOnCall[i] = struct {
+		result1 db.Pipeline
+		result2 db.ConfigVersion
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) SavePipeline(arg1 string, arg2 atc.Config, arg3 db.ConfigVersion, arg4 db.PipelinePausedState) (db.Pipeline, bool, error) {
 	fake.savePipelineMutex.Lock()
 	ret, specificReturn := fake.savePipelineReturnsOnCall[len(fake.savePipelineArgsForCall)]
@@ -2072,6 +2774,127 @@ func (fake *FakeTeam) SaveWorkerReturnsOnCall(i int, result1 db.Worker, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SetQuotas(arg1 db.Quotas) error {
+	fake.setQuotasMutex.Lock()
+	ret, specificReturn := fake.setQuotasReturnsOnCall[len(fake.setQuotasArgsForCall)]
+	fake.setQuotasArgsForCall = append(fake.setQuotasArgsForCall, struct {
+		arg1 db.Quotas
+	}{arg1})
+	fake.recordInvocation("SetQuotas", []interface{}{arg1})
+	fake.setQuotasMutex.Unlock()
+	if fake.SetQuotasStub != nil {
+		return fake.SetQuotasStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setQuotasReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) SetQuotasCallCount() int {
+	fake.setQuotasMutex.RLock()
+	defer fake.setQuotasMutex.RUnlock()
+	return len(fake.setQuotasArgsForCall)
+}
+
+func (fake *FakeTeam) SetQuotasCalls(stub func(db.Quotas) error) {
+	fake.setQuotasMutex.Lock()
+	defer fake.setQuotasMutex.Unlock()
+	fake.SetQuotasStub = stub
+}
+
+func (fake *FakeTeam) SetQuotasArgsForCall(i int) db.Quotas {
+	fake.setQuotasMutex.RLock()
+	defer fake.setQuotasMutex.RUnlock()
+	argsForCall := fake.setQuotasArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SetQuotasReturns(result1 error) {
+	fake.setQuotasMutex.Lock()
+	defer fake.setQuotasMutex.Unlock()
+	fake.SetQuotasStub = nil
+	fake.setQuotasReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SetQuotasReturnsOnCall(i int, result1 error) {
+	fake.setQuotasMutex.Lock()
+	defer fake.setQuotasMutex.Unlock()
+	fake.SetQuotasStub = nil
+	if fake.setQuotasReturnsOnCall == nil {
+		fake.setQuotasReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setQuotasReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) TransferPipeline(arg1 string, arg2 string) error {
+	fake.transferPipelineMutex.Lock()
+	ret, specificReturn := fake.transferPipelineReturnsOnCall[len(fake.transferPipelineArgsForCall)]
+	fake.transferPipelineArgsForCall = append(fake.transferPipelineArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("TransferPipeline", []interface{}{arg1, arg2})
+	fake.transferPipelineMutex.Unlock()
+	if fake.TransferPipelineStub != nil {
+		return fake.TransferPipelineStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.transferPipelineReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) TransferPipelineCallCount() int {
+	fake.transferPipelineMutex.RLock()
+	defer fake.transferPipelineMutex.RUnlock()
+	return len(fake.transferPipelineArgsForCall)
+}
+
+func (fake *FakeTeam) TransferPipelineCalls(stub func(string, string) error) {
+	fake.transferPipelineMutex.Lock()
+	defer fake.transferPipelineMutex.Unlock()
+	fake.TransferPipelineStub = stub
+}
+
+func (fake *FakeTeam) TransferPipelineArgsForCall(i int) (string, string) {
+	fake.transferPipelineMutex.RLock()
+	defer fake.transferPipelineMutex.RUnlock()
+	argsForCall := fake.transferPipelineArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) TransferPipelineReturns(result1 error) {
+	fake.transferPipelineMutex.Lock()
+	defer fake.transferPipelineMutex.Unlock()
+	fake.TransferPipelineStub = nil
+	fake.transferPipelineReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) TransferPipelineReturnsOnCall(i int, result1 error) {
+	fake.transferPipelineMutex.Lock()
+	defer fake.transferPipelineMutex.Unlock()
+	fake.TransferPipelineStub = nil
+	if fake.transferPipelineReturnsOnCall == nil {
+		fake.transferPipelineReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.transferPipelineReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) UpdateProviderAuth(arg1 atc.TeamAuth) error {
 	fake.updateProviderAuthMutex.Lock()
 	ret, specificReturn := fake.updateProviderAuthReturnsOnCall[len(fake.updateProviderAuthArgsForCall)]
@@ -2251,12 +3074,20 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.authMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
+	fake.buildsBetweenMutex.RLock()
+	defer fake.buildsBetweenMutex.RUnlock()
+	fake.buildsWithLabelMutex.RLock()
+	defer fake.buildsWithLabelMutex.RUnlock()
 	fake.buildsWithTimeMutex.RLock()
 	defer fake.buildsWithTimeMutex.RUnlock()
+	fake.clonePipelineMutex.RLock()
+	defer fake.clonePipelineMutex.RUnlock()
 	fake.containersMutex.RLock()
 	defer fake.containersMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
 	defer fake.createOneOffBuildMutex.RUnlock()
+	fake.createOneOffBuildWithLabelsMutex.RLock()
+	defer fake.createOneOffBuildWithLabelsMutex.RUnlock()
 	fake.createStartedBuildMutex.RLock()
 	defer fake.createStartedBuildMutex.RUnlock()
 	fake.deleteMutex.RLock()
@@ -2287,18 +3118,32 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.orderPipelinesMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
+	fake.pipelineCountMutex.RLock()
+	defer fake.pipelineCountMutex.RUnlock()
 	fake.pipelinesMutex.RLock()
 	defer fake.pipelinesMutex.RUnlock()
+	fake.pipelinesIncludingArchivedMutex.RLock()
+	defer fake.pipelinesIncludingArchivedMutex.RUnlock()
 	fake.privateAndPublicBuildsMutex.RLock()
 	defer fake.privateAndPublicBuildsMutex.RUnlock()
 	fake.publicPipelinesMutex.RLock()
 	defer fake.publicPipelinesMutex.RUnlock()
+	fake.quotasMutex.RLock()
+	defer fake.quotasMutex.RUnlock()
 	fake.renameMutex.RLock()
 	defer fake.renameMutex.RUnlock()
+	fake.runningBuildCountMutex.RLock()
+	defer fake.runningBuildCountMutex.RUnlock()
+	fake.saveConfigCASMutex.RLock()
+	defer fake.saveConfigCASMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.setQuotasMutex.RLock()
+	defer fake.setQuotasMutex.RUnlock()
+	fake.transferPipelineMutex.RLock()
+	defer fake.transferPipelineMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
 	fake.visiblePipelinesMutex.RLock()
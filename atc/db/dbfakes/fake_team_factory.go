@@ -60,6 +60,18 @@ type FakeTeamFactory struct {
 	getByIDReturnsOnCall map[int]struct {
 		result1 db.Team
 	}
+	GetTeamSummariesStub        func() ([]db.TeamSummary, error)
+	getTeamSummariesMutex       sync.RWMutex
+	getTeamSummariesArgsForCall []struct {
+	}
+	getTeamSummariesReturns struct {
+		result1 []db.TeamSummary
+		result2 error
+	}
+	getTeamSummariesReturnsOnCall map[int]struct {
+		result1 []db.TeamSummary
+		result2 error
+	}
 	GetTeamsStub        func() ([]db.Team, error)
 	getTeamsMutex       sync.RWMutex
 	getTeamsArgsForCall []struct {
@@ -320,6 +332,61 @@ func (fake *FakeTeamFactory) GetByIDReturnsOnCall(i int, result1 db.Team) {
 	}{result1}
 }
 
+func (fake *FakeTeamFactory) GetTeamSummaries() ([]db.TeamSummary, error) {
+	fake.getTeamSummariesMutex.Lock()
+	ret, specificReturn := fake.getTeamSummariesReturnsOnCall[len(fake.getTeamSummariesArgsForCall)]
+	fake.getTeamSummariesArgsForCall = append(fake.getTeamSummariesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetTeamSummaries", []interface{}{})
+	fake.getTeamSummariesMutex.Unlock()
+	if fake.GetTeamSummariesStub != nil {
+		return fake.GetTeamSummariesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getTeamSummariesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeamFactory) GetTeamSummariesCallCount() int {
+	fake.getTeamSummariesMutex.RLock()
+	defer fake.getTeamSummariesMutex.RUnlock()
+	return len(fake.getTeamSummariesArgsForCall)
+}
+
+func (fake *FakeTeamFactory) GetTeamSummariesCalls(stub func() ([]db.TeamSummary, error)) {
+	fake.getTeamSummariesMutex.Lock()
+	defer fake.getTeamSummariesMutex.Unlock()
+	fake.GetTeamSummariesStub = stub
+}
+
+func (fake *FakeTeamFactory) GetTeamSummariesReturns(result1 []db.TeamSummary, result2 error) {
+	fake.getTeamSummariesMutex.Lock()
+	defer fake.getTeamSummariesMutex.Unlock()
+	fake.GetTeamSummariesStub = nil
+	fake.getTeamSummariesReturns = struct {
+		result1 []db.TeamSummary
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamFactory) GetTeamSummariesReturnsOnCall(i int, result1 []db.TeamSummary, result2 error) {
+	fake.getTeamSummariesMutex.Lock()
+	defer fake.getTeamSummariesMutex.Unlock()
+	fake.GetTeamSummariesStub = nil
+	if fake.getTeamSummariesReturnsOnCall == nil {
+		fake.getTeamSummariesReturnsOnCall = make(map[int]struct {
+			result1 []db.TeamSummary
+			result2 error
+		})
+	}
+	fake.getTeamSummariesReturnsOnCall[i] = struct {
+		result1 []db.TeamSummary
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeamFactory) GetTeams() ([]db.Team, error) {
 	fake.getTeamsMutex.Lock()
 	ret, specificReturn := fake.getTeamsReturnsOnCall[len(fake.getTeamsArgsForCall)]
@@ -386,6 +453,8 @@ func (fake *FakeTeamFactory) Invocations() map[string][][]interface{} {
 	defer fake.findTeamMutex.RUnlock()
 	fake.getByIDMutex.RLock()
 	defer fake.getByIDMutex.RUnlock()
+	fake.getTeamSummariesMutex.RLock()
+	defer fake.getTeamSummariesMutex.RUnlock()
 	fake.getTeamsMutex.RLock()
 	defer fake.getTeamsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
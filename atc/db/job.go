@@ -32,13 +32,14 @@ type Job interface {
 	Pause() error
 	Unpause() error
 
-	CreateBuild() (Build, error)
+	CreateBuild(trigger BuildTrigger) (Build, error)
+	CreateBuildOverridingPause() (Build, error)
 	Builds(page Page) ([]Build, Pagination, error)
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
 	Build(name string) (Build, bool, error)
 	FinishedAndNextBuild() (Build, Build, error)
 	UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error
-	EnsurePendingBuildExists() error
+	EnsurePendingBuildExists(trigger BuildTrigger) error
 	GetPendingBuilds() ([]Build, error)
 
 	GetIndependentBuildInputs() ([]BuildInput, error)
@@ -425,53 +426,55 @@ func (j *job) DeleteNextInputMapping() error {
 	return tx.Commit()
 }
 
-func (j *job) EnsurePendingBuildExists() error {
-	tx, err := j.conn.Begin()
-	if err != nil {
-		return err
-	}
-
-	defer Rollback(tx)
-
-	buildName, err := j.getNewBuildName(tx)
-	if err != nil {
-		return err
-	}
+// EnsurePendingBuildExists creates a pending build of the job, recording what
+// triggered it, unless the job already has one.
+func (j *job) EnsurePendingBuildExists(trigger BuildTrigger) error {
+	return j.conn.TxRetry(func(tx Tx) error {
+		buildName, err := j.getNewBuildName(tx)
+		if err != nil {
+			return err
+		}
 
-	rows, err := tx.Query(`
-		INSERT INTO builds (name, job_id, pipeline_id, team_id, status)
-		SELECT $1, $2, $3, $4, 'pending'
-		WHERE NOT EXISTS
-			(SELECT id FROM builds WHERE job_id = $2 AND status = 'pending')
-		RETURNING id
-	`, buildName, j.id, j.pipelineID, j.teamID)
-	if err != nil {
-		return err
-	}
+		err = checkRunningBuildsQuota(tx, j.teamID)
+		if err != nil {
+			if _, ok := err.(ErrQuotaExceeded); ok {
+				// try again once some of the team's builds have finished
+				return nil
+			}
 
-	defer Close(rows)
+			return err
+		}
 
-	if rows.Next() {
-		var buildID int
-		err := rows.Scan(&buildID)
+		rows, err := tx.Query(`
+			INSERT INTO builds (name, job_id, pipeline_id, team_id, status, trigger_source)
+			SELECT $1, $2, $3, $4, 'pending', $5
+			WHERE NOT EXISTS
+				(SELECT id FROM builds WHERE job_id = $2 AND status = 'pending')
+			RETURNING id
+		`, buildName, j.id, j.pipelineID, j.teamID, trigger)
 		if err != nil {
 			return err
 		}
 
-		err = rows.Close()
+		defer Close(rows)
+
+		if !rows.Next() {
+			return rows.Err()
+		}
+
+		var buildID int
+		err = rows.Scan(&buildID)
 		if err != nil {
 			return err
 		}
 
-		err = createBuildEventSeq(tx, buildID)
+		err = rows.Close()
 		if err != nil {
 			return err
 		}
 
-		return tx.Commit()
-	}
-
-	return nil
+		return createBuildEventSeq(tx, buildID)
+	})
 }
 
 func (j *job) GetPendingBuilds() ([]Build, error) {
@@ -516,43 +519,111 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 	return builds, nil
 }
 
-func (j *job) CreateBuild() (Build, error) {
-	tx, err := j.conn.Begin()
+func (j *job) CreateBuild(trigger BuildTrigger) (Build, error) {
+	return j.createBuild(trigger, map[string]interface{}{})
+}
+
+// CreateBuildOverridingPause creates a build like CreateBuild which the
+// scheduler will start even while the job is paused, for deliberately running
+// a job that has been paused without unpausing it.
+func (j *job) CreateBuildOverridingPause() (Build, error) {
+	return j.createBuild(BuildTriggerManual, map[string]interface{}{
+		"overrides_pause": true,
+	})
+}
+
+// createBuildWithKey creates a build like CreateBuild, unless the job already
+// has a build created with the same key, in which case that build is returned
+// along with false.
+func (j *job) createBuildWithKey(trigger BuildTrigger, key string) (Build, bool, error) {
+	existing, found, err := j.buildWithKey(key)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	defer Rollback(tx)
+	if found {
+		return existing, false, nil
+	}
 
-	buildName, err := j.getNewBuildName(tx)
+	build, err := j.createBuild(trigger, map[string]interface{}{
+		"idempotency_key": key,
+	})
 	if err != nil {
-		return nil, err
+		// a concurrent create with the same key won the race
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			existing, found, err := j.buildWithKey(key)
+			if err != nil {
+				return nil, false, err
+			}
+
+			if found {
+				return existing, false, nil
+			}
+		}
+
+		return nil, false, err
+	}
+
+	return build, true, nil
+}
+
+func (j *job) buildWithKey(key string) (Build, bool, error) {
+	row := buildsQuery.Where(sq.Eq{
+		"b.job_id":          j.id,
+		"b.idempotency_key": key,
+	}).
+		RunWith(j.conn).
+		QueryRow()
+
+	build := &build{conn: j.conn, lockFactory: j.lockFactory}
+	err := scanBuild(build, row, j.conn.EncryptionStrategy())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
 	}
 
+	return build, true, nil
+}
+
+// createBuild creates a pending build of the job with the given values,
+// recording what triggered it.
+func (j *job) createBuild(trigger BuildTrigger, vals map[string]interface{}) (Build, error) {
 	build := &build{conn: j.conn, lockFactory: j.lockFactory}
-	err = createBuild(tx, build, map[string]interface{}{
-		"name":               buildName,
-		"job_id":             j.id,
-		"pipeline_id":        j.pipelineID,
-		"team_id":            j.teamID,
-		"status":             BuildStatusPending,
-		"manually_triggered": true,
+
+	err := j.conn.TxRetry(func(tx Tx) error {
+		return j.createBuildTx(tx, build, trigger, vals)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	err = updateNextBuildForJob(tx, j.id)
+	return build, nil
+}
+
+// createBuildTx is createBuild within the given transaction, scanning the new
+// build into build.
+func (j *job) createBuildTx(tx Tx, build *build, trigger BuildTrigger, vals map[string]interface{}) error {
+	buildName, err := j.getNewBuildName(tx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	err = tx.Commit()
+	vals["name"] = buildName
+	vals["job_id"] = j.id
+	vals["pipeline_id"] = j.pipelineID
+	vals["team_id"] = j.teamID
+	vals["status"] = BuildStatusPending
+	vals["manually_triggered"] = trigger != BuildTriggerResource
+	vals["trigger_source"] = trigger
+
+	err = createBuild(tx, build, vals)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return build, nil
+	return updateNextBuildForJob(tx, j.id)
 }
 
 func (j *job) ClearTaskCache(stepName string, cachePath string) (int64, error) {
@@ -640,7 +711,14 @@ func (j *job) updatePausedJob(pause bool) error {
 		return nonOneRowAffectedError{rowsAffected}
 	}
 
-	return nil
+	return j.conn.Bus().Notify(JobPausedChannel(j.pipelineID))
+}
+
+// JobPausedChannel is the channel notified whenever a job in the given
+// pipeline is paused or unpaused, so that the pipeline's scheduler on every
+// ATC picks up the change without waiting for its next interval.
+func JobPausedChannel(pipelineID int) string {
+	return fmt.Sprintf("job_paused_%d", pipelineID)
 }
 
 func (j *job) getBuildInputs(table string) ([]BuildInput, error) {
@@ -49,13 +49,13 @@ var _ = Describe("Job Factory", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			transitionBuild, err := job.CreateBuild()
+			transitionBuild, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = transitionBuild.Finish(db.BuildStatusSucceeded)
 			Expect(err).ToNot(HaveOccurred())
 
-			finishedBuild, err := job.CreateBuild()
+			finishedBuild, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = finishedBuild.Finish(db.BuildStatusSucceeded)
@@ -65,7 +65,7 @@ var _ = Describe("Job Factory", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			nextBuild, err := job.CreateBuild()
+			nextBuild, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 
 			visibleJobs, err := jobFactory.VisibleJobs([]string{"default-team"})
@@ -149,6 +149,23 @@ var _ = Describe("Job", func() {
 
 			Expect(job.Paused()).To(BeFalse())
 		})
+
+		It("notifies other ATCs of the change", func() {
+			otherConn := postgresRunner.OpenConn()
+			defer otherConn.Close()
+
+			channel := db.JobPausedChannel(job.PipelineID())
+
+			notified, err := otherConn.Bus().Listen(channel)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer otherConn.Bus().Unlisten(channel, notified)
+
+			err = job.Pause()
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(notified).Should(Receive())
+		})
 	})
 
 	Describe("FinishedAndNextBuild", func() {
@@ -179,13 +196,13 @@ var _ = Describe("Job", func() {
 			Expect(next).To(BeNil())
 			Expect(finished).To(BeNil())
 
-			finishedBuild, err := job.CreateBuild()
+			finishedBuild, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
 			err = finishedBuild.Finish(db.BuildStatusSucceeded)
 			Expect(err).NotTo(HaveOccurred())
 
-			otherFinishedBuild, err := otherJob.CreateBuild()
+			otherFinishedBuild, err := otherJob.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
 			err = otherFinishedBuild.Finish(db.BuildStatusSucceeded)
@@ -197,14 +214,14 @@ var _ = Describe("Job", func() {
 			Expect(next).To(BeNil())
 			Expect(finished.ID()).To(Equal(finishedBuild.ID()))
 
-			nextBuild, err := job.CreateBuild()
+			nextBuild, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
 			started, err := nextBuild.Start(atc.Plan{})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(started).To(BeTrue())
 
-			otherNextBuild, err := otherJob.CreateBuild()
+			otherNextBuild, err := otherJob.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
 			otherStarted, err := otherNextBuild.Start(atc.Plan{})
@@ -217,7 +234,7 @@ var _ = Describe("Job", func() {
 			Expect(next.ID()).To(Equal(nextBuild.ID()))
 			Expect(finished.ID()).To(Equal(finishedBuild.ID()))
 
-			anotherRunningBuild, err := job.CreateBuild()
+			anotherRunningBuild, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
 			finished, next, err = job.FinishedAndNextBuild()
@@ -294,10 +311,10 @@ var _ = Describe("Job", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err := someJob.CreateBuild()
+				build, err := someJob.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err = someOtherJob.CreateBuild()
+				_, err = someOtherJob.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
 				builds[i] = build
@@ -362,6 +379,23 @@ var _ = Describe("Job", func() {
 				Expect(pagination.Next).To(Equal(&db.Page{Since: builds[8].ID(), Limit: 2}))
 			})
 		})
+
+		Context("when builds are created while paging through the history", func() {
+			It("continues from the cursor without skipping or repeating builds", func() {
+				_, pagination, err := someJob.Builds(db.Page{Limit: 2})
+				Expect(err).ToNot(HaveOccurred())
+
+				for i := 0; i < 3; i++ {
+					_, err = someJob.CreateBuild(db.BuildTriggerManual)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				buildsPage, pagination, err := someJob.Builds(*pagination.Next)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(buildsPage).To(Equal([]db.Build{builds[7], builds[6]}))
+				Expect(pagination.Next).To(Equal(&db.Page{Since: builds[6].ID(), Limit: 2}))
+			})
+		})
 	})
 
 	Describe("BuildsWithTime", func() {
@@ -396,7 +430,7 @@ var _ = Describe("Job", func() {
 			Expect(found).To(BeTrue())
 
 			for i := range builds {
-				builds[i], err = job.CreateBuild()
+				builds[i], err = job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 
 				buildStart := time.Date(2020, 11, i+1, 0, 0, 0, 0, time.UTC)
@@ -475,12 +509,12 @@ var _ = Describe("Job", func() {
 		Context("when a build exists", func() {
 			BeforeEach(func() {
 				var err error
-				firstBuild, err = job.CreateBuild()
+				firstBuild, err = job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			It("finds the latest build", func() {
-				secondBuild, err := job.CreateBuild()
+				secondBuild, err := job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
 				build, found, err := job.Build("latest")
@@ -522,17 +556,17 @@ var _ = Describe("Job", func() {
 
 			BeforeEach(func() {
 				var err error
-				_, err = job.CreateBuild()
+				_, err = job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
-				startedBuild, err = job.CreateBuild()
+				startedBuild, err = job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 				_, err = startedBuild.Schedule()
 				Expect(err).NotTo(HaveOccurred())
 				_, err = startedBuild.Start(atc.Plan{})
 				Expect(err).NotTo(HaveOccurred())
 
-				scheduledBuild, err = job.CreateBuild()
+				scheduledBuild, err = job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
 				scheduled, err := scheduledBuild.Schedule()
@@ -540,7 +574,7 @@ var _ = Describe("Job", func() {
 				Expect(scheduled).To(BeTrue())
 
 				for _, s := range []db.BuildStatus{db.BuildStatusSucceeded, db.BuildStatusFailed, db.BuildStatusErrored, db.BuildStatusAborted} {
-					finishedBuild, err := job.CreateBuild()
+					finishedBuild, err := job.CreateBuild(db.BuildTriggerManual)
 					Expect(err).NotTo(HaveOccurred())
 
 					scheduled, err = finishedBuild.Schedule()
@@ -555,7 +589,7 @@ var _ = Describe("Job", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				_, err = otherJob.CreateBuild()
+				_, err = otherJob.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -577,14 +611,14 @@ var _ = Describe("Job", func() {
 
 			BeforeEach(func() {
 				var err error
-				_, err = job.CreateBuild()
+				_, err = job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
 				otherSerialJob, found, err := pipeline.Job("other-serial-group-job")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				serialGroupBuild, err = otherSerialJob.CreateBuild()
+				serialGroupBuild, err = otherSerialJob.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
 				scheduled, err := serialGroupBuild.Schedule()
@@ -595,7 +629,7 @@ var _ = Describe("Job", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				differentSerialGroupBuild, err := differentSerialJob.CreateBuild()
+				differentSerialGroupBuild, err := differentSerialJob.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
 				scheduled, err = differentSerialGroupBuild.Schedule()
@@ -632,10 +666,10 @@ var _ = Describe("Job", func() {
 			var actualBuild db.Build
 
 			BeforeEach(func() {
-				_, err := job1.CreateBuild()
+				_, err := job1.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
-				actualBuild, err = job2.CreateBuild()
+				actualBuild, err = job2.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
 				err = job2.SaveNextInputMapping(nil)
@@ -651,13 +685,13 @@ var _ = Describe("Job", func() {
 		})
 
 		It("should return the next most pending build in a group of jobs", func() {
-			buildOne, err := job1.CreateBuild()
+			buildOne, err := job1.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
-			buildTwo, err := job1.CreateBuild()
+			buildTwo, err := job1.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
-			buildThree, err := job2.CreateBuild()
+			buildThree, err := job2.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 
 			err = job1.SaveNextInputMapping(nil)
@@ -1124,7 +1158,7 @@ var _ = Describe("Job", func() {
 			otherPipeline, _, err = team.SavePipeline("some-other-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
-			build1DB, err = job.CreateBuild()
+			build1DB, err = job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(build1DB.ID()).NotTo(BeZero())
@@ -1157,7 +1191,7 @@ var _ = Describe("Job", func() {
 
 		Context("and another build for a different pipeline is created with the same job name", func() {
 			BeforeEach(func() {
-				otherBuild, err := otherJob.CreateBuild()
+				otherBuild, err := otherJob.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(otherBuild.ID()).NotTo(BeZero())
@@ -1249,7 +1283,7 @@ var _ = Describe("Job", func() {
 
 			BeforeEach(func() {
 				var err error
-				build2DB, err = job.CreateBuild()
+				build2DB, err = job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(build2DB.ID()).NotTo(BeZero())
@@ -1277,10 +1311,45 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("CreateBuild", func() {
+		It("creates a pending build with the given trigger", func() {
+			build, err := job.CreateBuild(db.BuildTriggerResource)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.Status()).To(Equal(db.BuildStatusPending))
+			Expect(build.TriggerSource()).To(Equal(db.BuildTriggerResource))
+			Expect(build.IsManuallyTriggered()).To(BeFalse())
+
+			build, err = job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.TriggerSource()).To(Equal(db.BuildTriggerManual))
+			Expect(build.IsManuallyTriggered()).To(BeTrue())
+		})
+	})
+
+	Describe("CreateBuildOverridingPause", func() {
+		It("creates a manually triggered build which overrides the pause", func() {
+			build, err := job.CreateBuildOverridingPause()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.IsManuallyTriggered()).To(BeTrue())
+			Expect(build.OverridesPause()).To(BeTrue())
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(1))
+			Expect(pendingBuilds[0].OverridesPause()).To(BeTrue())
+		})
+
+		It("is not set on builds created with CreateBuild", func() {
+			build, err := job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.OverridesPause()).To(BeFalse())
+		})
+	})
+
 	Describe("EnsurePendingBuildExists", func() {
 		Context("when only a started build exists", func() {
 			BeforeEach(func() {
-				build1, err := job.CreateBuild()
+				build1, err := job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).NotTo(HaveOccurred())
 
 				started, err := build1.Start(atc.Plan{})
@@ -1289,7 +1358,7 @@ var _ = Describe("Job", func() {
 			})
 
 			It("creates a build", func() {
-				err := job.EnsurePendingBuildExists()
+				err := job.EnsurePendingBuildExists(db.BuildTriggerResource)
 				Expect(err).NotTo(HaveOccurred())
 
 				pendingBuilds, err := job.GetPendingBuilds()
@@ -1298,10 +1367,10 @@ var _ = Describe("Job", func() {
 			})
 
 			It("doesn't create another build the second time it's called", func() {
-				err := job.EnsurePendingBuildExists()
+				err := job.EnsurePendingBuildExists(db.BuildTriggerResource)
 				Expect(err).NotTo(HaveOccurred())
 
-				err = job.EnsurePendingBuildExists()
+				err = job.EnsurePendingBuildExists(db.BuildTriggerResource)
 				Expect(err).NotTo(HaveOccurred())
 
 				builds2, err := job.GetPendingBuilds()
@@ -0,0 +1,143 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	uuid "github.com/nu7hatch/gouuid"
+)
+
+// ErrLeaseLost is returned when renewing or breaking a lease that has expired
+// and been taken over by someone else in the meantime.
+var ErrLeaseLost = errors.New("lease lost")
+
+//go:generate counterfeiter . Lease
+
+// Lease is a time-bounded claim on some work recorded in the database, so
+// that it is honoured by every ATC in a cluster. Unlike an advisory lock, a
+// lease outlives the connection that acquired it and only goes away once it
+// is broken or it expires.
+type Lease interface {
+	Break() error
+	Renew() error
+}
+
+// leaseHolder identifies this process as the holder of the leases it
+// acquires, so that operators can tell which ATC is doing the work.
+var leaseHolder = func() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}()
+
+type lease struct {
+	conn     Conn
+	name     string
+	token    string
+	interval time.Duration
+}
+
+// acquireLease grants the named lease for the given interval if nobody holds
+// it, or if the previous holder let it expire.
+func acquireLease(conn Conn, name string, interval time.Duration) (Lease, bool, error) {
+	token, err := uuid.NewV4()
+	if err != nil {
+		return nil, false, err
+	}
+
+	result, err := conn.Exec(`
+		INSERT INTO leases (name, token, holder, expires_at)
+		VALUES ($1, $2, $3, now() + ($4 || ' SECONDS')::INTERVAL)
+		ON CONFLICT (name) DO UPDATE SET
+			token = EXCLUDED.token,
+			holder = EXCLUDED.holder,
+			expires_at = EXCLUDED.expires_at
+		WHERE leases.expires_at <= now()
+	`, name, token.String(), leaseHolder, interval.Seconds())
+	if err != nil {
+		return nil, false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if rows == 0 {
+		return nil, false, nil
+	}
+
+	return &lease{
+		conn:     conn,
+		name:     name,
+		token:    token.String(),
+		interval: interval,
+	}, true, nil
+}
+
+// currentLeaseHolder returns the holder of the named lease, if it is held and
+// has not expired.
+func currentLeaseHolder(conn Conn, name string) (string, bool, error) {
+	var holder string
+	err := psql.Select("holder").
+		From("leases").
+		Where(sq.Eq{"name": name}).
+		Where(sq.Expr("expires_at > now()")).
+		RunWith(conn).
+		QueryRow().
+		Scan(&holder)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	return holder, true, nil
+}
+
+// Renew extends the lease by its interval, starting from now. It returns
+// ErrLeaseLost if the lease is no longer held.
+func (l *lease) Renew() error {
+	return l.exec(`
+		UPDATE leases
+		SET expires_at = now() + ($3 || ' SECONDS')::INTERVAL
+		WHERE name = $1
+			AND token = $2
+	`, l.name, l.token, l.interval.Seconds())
+}
+
+// Break gives up the lease before it expires. It returns ErrLeaseLost if the
+// lease is no longer held.
+func (l *lease) Break() error {
+	return l.exec(`
+		DELETE FROM leases
+		WHERE name = $1
+			AND token = $2
+	`, l.name, l.token)
+}
+
+func (l *lease) exec(query string, params ...interface{}) error {
+	result, err := l.conn.Exec(query, params...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrLeaseLost
+	}
+
+	return nil
+}
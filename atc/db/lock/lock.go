@@ -20,6 +20,8 @@ const (
 	LockTypeVolumeCreating
 	LockTypeContainerCreating
 	LockTypeDatabaseMigration
+	LockTypeJobSerial
+	LockTypeSerialGroup
 )
 
 var ErrLostLock = errors.New("lock was lost while held, possibly due to connection breakage")
@@ -52,6 +54,16 @@ func NewDatabaseMigrationLockID() LockID {
 	return LockID{LockTypeDatabaseMigration}
 }
 
+func NewJobSerialLockID(jobID int) LockID {
+	return LockID{LockTypeJobSerial, jobID}
+}
+
+// NewSerialGroupLockID returns the ID for a serial group of a pipeline. Groups
+// are only shared within a pipeline, so the pipeline is part of the hash.
+func NewSerialGroupLockID(pipelineID int, group string) LockID {
+	return LockID{LockTypeSerialGroup, lockIDFromString(fmt.Sprintf("%d/%s", pipelineID, group))}
+}
+
 //go:generate counterfeiter . LockFactory
 
 type LockFactory interface {
@@ -1,8 +1,10 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/Masterminds/squirrel"
@@ -36,6 +38,146 @@ func (c *logConn) Exec(query string, args ...interface{}) (sql.Result, error) {
 	return c.Conn.Exec(query, args...)
 }
 
+func (c *logConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	c.logger.Debug("query", lager.Data{"query": c.strip(query)})
+	return c.Conn.QueryContext(ctx, query, args...)
+}
+
+func (c *logConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) squirrel.RowScanner {
+	c.logger.Debug("query-row", lager.Data{"query": c.strip(query)})
+	return c.Conn.QueryRowContext(ctx, query, args...)
+}
+
+func (c *logConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	c.logger.Debug("exec", lager.Data{"query": c.strip(query)})
+	return c.Conn.ExecContext(ctx, query, args...)
+}
+
+func (c *logConn) ReadReplica() Conn {
+	return Log(c.logger, c.Conn.ReadReplica())
+}
+
+func (c *logConn) TxRetry(fn func(Tx) error) error {
+	return RetryTx(c, fn)
+}
+
 func (c *logConn) strip(query string) string {
 	return strings.Join(strings.Fields(query), " ")
 }
+
+// LogSlowQueries logs any query, in or out of a transaction, that takes longer
+// than the threshold to return.
+func LogSlowQueries(logger lager.Logger, conn Conn, threshold time.Duration) Conn {
+	return &slowQueryConn{
+		Conn:      conn,
+		logger:    logger,
+		threshold: threshold,
+	}
+}
+
+type slowQueryConn struct {
+	Conn
+
+	logger    lager.Logger
+	threshold time.Duration
+}
+
+func (c *slowQueryConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	defer logSlowQuery(c.logger, c.threshold, query, time.Now())
+	return c.Conn.Query(query, args...)
+}
+
+func (c *slowQueryConn) QueryRow(query string, args ...interface{}) squirrel.RowScanner {
+	defer logSlowQuery(c.logger, c.threshold, query, time.Now())
+	return c.Conn.QueryRow(query, args...)
+}
+
+func (c *slowQueryConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	defer logSlowQuery(c.logger, c.threshold, query, time.Now())
+	return c.Conn.Exec(query, args...)
+}
+
+func (c *slowQueryConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	defer logSlowQuery(c.logger, c.threshold, query, time.Now())
+	return c.Conn.QueryContext(ctx, query, args...)
+}
+
+func (c *slowQueryConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) squirrel.RowScanner {
+	defer logSlowQuery(c.logger, c.threshold, query, time.Now())
+	return c.Conn.QueryRowContext(ctx, query, args...)
+}
+
+func (c *slowQueryConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer logSlowQuery(c.logger, c.threshold, query, time.Now())
+	return c.Conn.ExecContext(ctx, query, args...)
+}
+
+func (c *slowQueryConn) Begin() (Tx, error) {
+	tx, err := c.Conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	return &slowQueryTx{
+		Tx:        tx,
+		logger:    c.logger,
+		threshold: c.threshold,
+	}, nil
+}
+
+func (c *slowQueryConn) ReadReplica() Conn {
+	return LogSlowQueries(c.logger, c.Conn.ReadReplica(), c.threshold)
+}
+
+func (c *slowQueryConn) TxRetry(fn func(Tx) error) error {
+	return RetryTx(c, fn)
+}
+
+type slowQueryTx struct {
+	Tx
+
+	logger    lager.Logger
+	threshold time.Duration
+}
+
+func (tx *slowQueryTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	defer logSlowQuery(tx.logger, tx.threshold, query, time.Now())
+	return tx.Tx.Query(query, args...)
+}
+
+func (tx *slowQueryTx) QueryRow(query string, args ...interface{}) squirrel.RowScanner {
+	defer logSlowQuery(tx.logger, tx.threshold, query, time.Now())
+	return tx.Tx.QueryRow(query, args...)
+}
+
+func (tx *slowQueryTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	defer logSlowQuery(tx.logger, tx.threshold, query, time.Now())
+	return tx.Tx.Exec(query, args...)
+}
+
+func (tx *slowQueryTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	defer logSlowQuery(tx.logger, tx.threshold, query, time.Now())
+	return tx.Tx.QueryContext(ctx, query, args...)
+}
+
+func (tx *slowQueryTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) squirrel.RowScanner {
+	defer logSlowQuery(tx.logger, tx.threshold, query, time.Now())
+	return tx.Tx.QueryRowContext(ctx, query, args...)
+}
+
+func (tx *slowQueryTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer logSlowQuery(tx.logger, tx.threshold, query, time.Now())
+	return tx.Tx.ExecContext(ctx, query, args...)
+}
+
+func logSlowQuery(logger lager.Logger, threshold time.Duration, query string, start time.Time) {
+	duration := time.Since(start)
+	if duration < threshold {
+		return
+	}
+
+	logger.Info("slow-query", lager.Data{
+		"query":    strings.Join(strings.Fields(query), " "),
+		"duration": duration.String(),
+	})
+}
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE build_events
+    ADD COLUMN compressed boolean;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN deadline timestamp with time zone;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN abort_reason text;
+
+COMMIT;
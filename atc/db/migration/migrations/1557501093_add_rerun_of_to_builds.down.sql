@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE builds
+    DROP COLUMN rerun_of;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN rerun_of integer REFERENCES builds (id) ON DELETE SET NULL;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+  DROP INDEX builds_pipeline_id_status_start_time;
+
+COMMIT;
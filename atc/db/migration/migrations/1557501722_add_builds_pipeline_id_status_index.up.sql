@@ -0,0 +1,5 @@
+BEGIN;
+
+  CREATE INDEX builds_pipeline_id_status_start_time ON builds USING btree (pipeline_id, status, start_time DESC);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE resources
+    ADD COLUMN paused boolean NOT NULL DEFAULT false;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+  DROP TABLE pipeline_pause_events;
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+  CREATE TABLE pipeline_pause_events (
+    id serial PRIMARY KEY,
+    pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+    paused boolean NOT NULL,
+    actor text,
+    time timestamp with time zone NOT NULL DEFAULT now()
+  );
+
+  CREATE INDEX pipeline_pause_events_pipeline_id ON pipeline_pause_events USING btree (pipeline_id);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE pipelines
+    DROP COLUMN archived;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE pipelines
+    ADD COLUMN archived boolean NOT NULL DEFAULT false;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+  ALTER TABLE builds
+    ADD COLUMN labels jsonb;
+
+  CREATE INDEX builds_labels_idx ON builds USING gin (labels);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+  ALTER TABLE teams
+    DROP COLUMN max_pipelines,
+    DROP COLUMN max_running_builds;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+  ALTER TABLE teams
+    ADD COLUMN max_pipelines integer NOT NULL DEFAULT 0,
+    ADD COLUMN max_running_builds integer NOT NULL DEFAULT 0;
+
+COMMIT;
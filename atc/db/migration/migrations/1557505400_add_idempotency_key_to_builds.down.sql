@@ -0,0 +1,7 @@
+BEGIN;
+
+  DROP INDEX builds_job_id_idempotency_key_uniq;
+
+  ALTER TABLE builds DROP COLUMN idempotency_key;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+  ALTER TABLE builds ADD COLUMN idempotency_key text;
+
+  CREATE UNIQUE INDEX builds_job_id_idempotency_key_uniq ON builds (job_id, idempotency_key);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE builds DROP COLUMN error_category;
+
+COMMIT;
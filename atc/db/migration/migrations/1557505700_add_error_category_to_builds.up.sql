@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE builds ADD COLUMN error_category text;
+
+COMMIT;
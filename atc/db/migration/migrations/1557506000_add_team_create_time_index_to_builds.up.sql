@@ -0,0 +1,5 @@
+BEGIN;
+
+  CREATE INDEX builds_team_id_create_time_idx ON builds (team_id, create_time);
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+  CREATE TABLE leases (
+    name text PRIMARY KEY,
+    token text NOT NULL,
+    expires_at timestamp with time zone NOT NULL
+  );
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE leases DROP COLUMN holder;
+
+COMMIT;
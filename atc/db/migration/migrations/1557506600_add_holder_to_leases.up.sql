@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE leases ADD COLUMN holder text NOT NULL DEFAULT '';
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE builds DROP COLUMN last_tracked;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE builds ADD COLUMN last_tracked timestamp with time zone;
+
+COMMIT;
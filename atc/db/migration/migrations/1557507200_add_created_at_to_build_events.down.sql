@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE build_events DROP COLUMN created_at;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+  ALTER TABLE build_events ADD COLUMN created_at timestamp with time zone;
+
+  ALTER TABLE build_events ALTER COLUMN created_at SET DEFAULT now();
+
+COMMIT;
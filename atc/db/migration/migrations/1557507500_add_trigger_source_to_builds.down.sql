@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE builds DROP COLUMN trigger_source;
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+  CREATE TABLE build_comments (
+    id serial PRIMARY KEY,
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    author text NOT NULL,
+    text text NOT NULL,
+    time timestamp with time zone NOT NULL DEFAULT now()
+  );
+
+  CREATE INDEX build_comments_build_id ON build_comments USING btree (build_id);
+
+COMMIT;
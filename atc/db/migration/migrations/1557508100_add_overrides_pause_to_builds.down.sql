@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE builds DROP COLUMN overrides_pause;
+
+COMMIT;
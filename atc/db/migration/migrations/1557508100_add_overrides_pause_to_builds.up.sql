@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE builds ADD COLUMN overrides_pause boolean NOT NULL DEFAULT false;
+
+COMMIT;
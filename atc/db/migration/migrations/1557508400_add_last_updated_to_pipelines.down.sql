@@ -0,0 +1,7 @@
+BEGIN;
+
+  ALTER TABLE pipelines
+    DROP COLUMN last_updated,
+    DROP COLUMN config_updated;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+  ALTER TABLE pipelines
+    ADD COLUMN last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    ADD COLUMN config_updated timestamp with time zone NOT NULL DEFAULT now();
+
+  CREATE INDEX pipelines_last_updated ON pipelines USING btree (last_updated);
+
+COMMIT;
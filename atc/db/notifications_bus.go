@@ -3,31 +3,95 @@ package db
 import (
 	"database/sql"
 	"sync"
+	"sync/atomic"
 
 	"github.com/lib/pq"
 )
 
+//go:generate counterfeiter . NotificationsBus
+
 type NotificationsBus interface {
 	Notify(channel string) error
+	NotifyWithPayload(channel string, payload string) error
+	LastPayload(channel string) (string, bool)
 	Listen(channel string) (chan bool, error)
 	Unlisten(channel string, notify chan bool) error
+	Stats() BusStats
+
+	// Done is closed once the bus has been closed, after which nothing will
+	// be notified anymore.
+	Done() <-chan struct{}
 	Close() error
 }
 
+// BusStats describes the channels currently being listened on through the
+// bus's single listener connection, and what has been delivered over it.
+type BusStats struct {
+	// Channels is the number of channels with at least one subscriber.
+	Channels int
+
+	// Subscribers is the total number of subscriptions across all channels.
+	Subscribers int
+
+	// Dispatched is the total number of notifications handed to subscribers.
+	Dispatched int
+
+	// Dropped is the total number of notifications that were not handed to a
+	// subscriber because it already had one queued up.
+	Dropped int
+
+	// Connected is false while the listener is re-establishing its connection.
+	Connected bool
+}
+
+// listenerConnection tracks whether a listener is connected from the events
+// it reports, so that it can be checked without waiting on the connection the
+// way pinging the listener does.
+type listenerConnection struct {
+	connected int32
+}
+
+func (c *listenerConnection) update(event pq.ListenerEventType) {
+	switch event {
+	case pq.ListenerEventConnected, pq.ListenerEventReconnected:
+		atomic.StoreInt32(&c.connected, 1)
+	case pq.ListenerEventDisconnected:
+		atomic.StoreInt32(&c.connected, 0)
+	}
+}
+
+func (c *listenerConnection) isConnected() bool {
+	return atomic.LoadInt32(&c.connected) == 1
+}
+
 type notificationsBus struct {
-	listener *pq.Listener
-	conn     *sql.DB
+	listener   *pq.Listener
+	connection *listenerConnection
+	conn       *sql.DB
 
 	notifications  map[string]map[chan bool]struct{}
+	payloads       map[string]string
+	dispatched     int
+	dropped        int
 	notificationsL sync.Mutex
+
+	done      chan struct{}
+	closeOnce sync.Once
+	closeErr  error
 }
 
-func NewNotificationsBus(listener *pq.Listener, conn *sql.DB) NotificationsBus {
+// newNotificationsBus returns a bus notifying through conn and listening
+// through listener, whose events must be passed to connection's update.
+func newNotificationsBus(listener *pq.Listener, connection *listenerConnection, conn *sql.DB) NotificationsBus {
 	bus := &notificationsBus{
-		listener: listener,
-		conn:     conn,
+		listener:   listener,
+		connection: connection,
+		conn:       conn,
 
 		notifications: make(map[string]map[chan bool]struct{}),
+		payloads:      make(map[string]string),
+
+		done: make(chan struct{}),
 	}
 
 	go bus.wait()
@@ -35,8 +99,19 @@ func NewNotificationsBus(listener *pq.Listener, conn *sql.DB) NotificationsBus {
 	return bus
 }
 
+// Close closes the bus's listener connection and then Done. Closing the bus
+// more than once has no further effect.
 func (bus *notificationsBus) Close() error {
-	return bus.listener.Close()
+	bus.closeOnce.Do(func() {
+		bus.closeErr = bus.listener.Close()
+		close(bus.done)
+	})
+
+	return bus.closeErr
+}
+
+func (bus *notificationsBus) Done() <-chan struct{} {
+	return bus.done
 }
 
 func (bus *notificationsBus) Notify(channel string) error {
@@ -44,6 +119,25 @@ func (bus *notificationsBus) Notify(channel string) error {
 	return err
 }
 
+// NotifyWithPayload notifies the channel, attaching a payload that listeners
+// can look up with LastPayload once they've been woken up.
+func (bus *notificationsBus) NotifyWithPayload(channel string, payload string) error {
+	_, err := bus.conn.Exec("SELECT pg_notify($1, $2)", channel, payload)
+	return err
+}
+
+// LastPayload returns the payload of the most recent notification received on
+// the channel. Nothing is returned if that notification had no payload, or if
+// the connection has been re-established since, as notifications may have
+// been missed in the meantime.
+func (bus *notificationsBus) LastPayload(channel string) (string, bool) {
+	bus.notificationsL.Lock()
+	defer bus.notificationsL.Unlock()
+
+	payload, found := bus.payloads[channel]
+	return payload, found
+}
+
 func (bus *notificationsBus) Listen(channel string) (chan bool, error) {
 	bus.notificationsL.Lock()
 	defer bus.notificationsL.Unlock()
@@ -72,15 +166,41 @@ func (bus *notificationsBus) Unlisten(channel string, notify chan bool) error {
 	bus.notificationsL.Lock()
 	defer bus.notificationsL.Unlock()
 
-	delete(bus.notifications[channel], notify)
+	sinks, found := bus.notifications[channel]
+	if !found {
+		return nil
+	}
+
+	delete(sinks, notify)
 
-	if len(bus.notifications[channel]) == 0 {
+	if len(sinks) == 0 {
+		// drop the channel entirely so that finished builds don't accumulate
+		delete(bus.notifications, channel)
+		delete(bus.payloads, channel)
 		return bus.listener.Unlisten(channel)
 	}
 
 	return nil
 }
 
+func (bus *notificationsBus) Stats() BusStats {
+	bus.notificationsL.Lock()
+	defer bus.notificationsL.Unlock()
+
+	stats := BusStats{
+		Channels:   len(bus.notifications),
+		Dispatched: bus.dispatched,
+		Dropped:    bus.dropped,
+		Connected:  bus.connection.isConnected(),
+	}
+
+	for _, sinks := range bus.notifications {
+		stats.Subscribers += len(sinks)
+	}
+
+	return stats
+}
+
 func (bus *notificationsBus) wait() {
 	for {
 
@@ -92,17 +212,29 @@ func (bus *notificationsBus) wait() {
 		bus.notificationsL.Lock()
 
 		if notification != nil {
+			if _, listening := bus.notifications[notification.Channel]; listening {
+				if notification.Extra != "" {
+					bus.payloads[notification.Channel] = notification.Extra
+				} else {
+					delete(bus.payloads, notification.Channel)
+				}
+			}
+
 			// alert any relevant listeners of notification being received
 			// (nonblocking)
 			for sink := range bus.notifications[notification.Channel] {
 				select {
 				case sink <- true:
 					// notified of message being received (or queued up)
+					bus.dispatched++
 				default:
 					// already had notification queued up; no need to handle it twice
+					bus.dropped++
 				}
 			}
 		} else {
+			bus.payloads = make(map[string]string)
+
 			// alert all listeners of connection break so they can check for things
 			// they may have missed
 			for _, sinks := range bus.notifications {
@@ -111,9 +243,11 @@ func (bus *notificationsBus) wait() {
 					case sink <- false:
 						// notify that connection was lost, so listener can check for
 						// things that may have changed while connection was lost
+						bus.dispatched++
 					default:
 						// already had notification queued up; no need to check for
 						// anything missed since something will be notified anyway
+						bus.dropped++
 					}
 				}
 			}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"errors"
@@ -24,15 +25,34 @@ type Conn interface {
 	Bus() NotificationsBus
 	EncryptionStrategy() encryption.Strategy
 
+	// CompressBuildEvents returns whether build event payloads should be
+	// compressed before they are stored. It is false unless the connection
+	// was wrapped with WithCompressedBuildEvents.
+	CompressBuildEvents() bool
+
+	// ReadReplica returns the connection to use for reads that don't need to
+	// see the latest writes. It is the connection itself unless a replica has
+	// been configured with WithReadReplica.
+	ReadReplica() Conn
+
 	Ping() error
 	Driver() driver.Driver
 
 	Begin() (Tx, error)
+
+	// TxRetry runs fn in a transaction and commits it, retrying the whole
+	// transaction if it fails to serialize or deadlocks. See RetryTx.
+	TxRetry(fn func(Tx) error) error
+
 	Exec(query string, args ...interface{}) (sql.Result, error)
 	Prepare(query string) (*sql.Stmt, error)
 	Query(query string, args ...interface{}) (*sql.Rows, error)
 	QueryRow(query string, args ...interface{}) squirrel.RowScanner
 
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) squirrel.RowScanner
+
 	SetMaxIdleConns(n int)
 	SetMaxOpenConns(n int)
 	Stats() sql.DBStats
@@ -49,6 +69,9 @@ type Tx interface {
 	Prepare(query string) (*sql.Stmt, error)
 	Query(query string, args ...interface{}) (*sql.Rows, error)
 	QueryRow(query string, args ...interface{}) squirrel.RowScanner
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) squirrel.RowScanner
 	Rollback() error
 	Stmt(stmt *sql.Stmt) *sql.Stmt
 }
@@ -90,18 +113,38 @@ func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *e
 			}
 		}
 
-		listener := pq.NewDialListener(keepAliveDialer{}, sqlDataSource, time.Second, time.Minute, nil)
+		connection := new(listenerConnection)
+		listener := pq.NewDialListener(keepAliveDialer{}, sqlDataSource, time.Second, time.Minute, listenerEventCallback(logger.Session("listener"), connection))
 
 		return &db{
 			DB: sqlDb,
 
-			bus:        NewNotificationsBus(listener, sqlDb),
+			bus:        newNotificationsBus(listener, connection, sqlDb),
 			encryption: strategy,
 			name:       connectionName,
 		}, nil
 	}
 }
 
+// listenerEventCallback logs the connection state of the notifications
+// listener and records it for the bus's stats. The listener re-establishes its
+// connection and LISTENs on its own; the notifications bus tells subscribers
+// about the reconnect so they can catch up on anything they missed.
+func listenerEventCallback(logger lager.Logger, connection *listenerConnection) pq.EventCallbackType {
+	return func(event pq.ListenerEventType, err error) {
+		connection.update(event)
+
+		switch event {
+		case pq.ListenerEventDisconnected:
+			logger.Error("disconnected", err)
+		case pq.ListenerEventReconnected:
+			logger.Info("reconnected")
+		case pq.ListenerEventConnectionAttemptFailed:
+			logger.Error("failed-to-reconnect", err)
+		}
+	}
+}
+
 func shouldRetry(err error) bool {
 	if strings.Contains(err.Error(), "dial ") {
 		return true
@@ -125,6 +168,7 @@ var encryptedColumns = []encryptedColumn{
 	{"resources", "config", "id"},
 	{"jobs", "config", "id"},
 	{"resource_types", "config", "id"},
+	{"pipeline_configs", "config", "id"},
 	{"builds", "private_plan", "id"},
 	{"cert_cache", "cert", "domain"},
 }
@@ -350,10 +394,18 @@ func (db *db) Bus() NotificationsBus {
 	return db.bus
 }
 
+func (db *db) ReadReplica() Conn {
+	return db
+}
+
 func (db *db) EncryptionStrategy() encryption.Strategy {
 	return db.encryption
 }
 
+func (db *db) CompressBuildEvents() bool {
+	return false
+}
+
 func (db *db) Close() error {
 	var errs error
 	dbErr := db.DB.Close()
@@ -384,6 +436,10 @@ func (db *db) Begin() (Tx, error) {
 	return &dbTx{tx, GlobalConnectionTracker.Track()}, nil
 }
 
+func (db *db) TxRetry(fn func(Tx) error) error {
+	return RetryTx(db, fn)
+}
+
 func (db *db) Exec(query string, args ...interface{}) (sql.Result, error) {
 	defer GlobalConnectionTracker.Track().Release()
 	return db.DB.Exec(query, args...)
@@ -405,6 +461,22 @@ func (db *db) QueryRow(query string, args ...interface{}) squirrel.RowScanner {
 	return db.DB.QueryRow(query, args...)
 }
 
+func (db *db) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer GlobalConnectionTracker.Track().Release()
+	return db.DB.ExecContext(ctx, query, args...)
+}
+
+func (db *db) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	defer GlobalConnectionTracker.Track().Release()
+	return db.DB.QueryContext(ctx, query, args...)
+}
+
+// to conform to squirrel.RunnerContext interface
+func (db *db) QueryRowContext(ctx context.Context, query string, args ...interface{}) squirrel.RowScanner {
+	defer GlobalConnectionTracker.Track().Release()
+	return db.DB.QueryRowContext(ctx, query, args...)
+}
+
 type dbTx struct {
 	*sql.Tx
 
@@ -416,6 +488,11 @@ func (tx *dbTx) QueryRow(query string, args ...interface{}) squirrel.RowScanner
 	return tx.Tx.QueryRow(query, args...)
 }
 
+// to conform to squirrel.RunnerContext interface
+func (tx *dbTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) squirrel.RowScanner {
+	return tx.Tx.QueryRowContext(ctx, query, args...)
+}
+
 func (tx *dbTx) Commit() error {
 	defer tx.session.Release()
 	return tx.Tx.Commit()
@@ -1,9 +1,11 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +16,7 @@ import (
 	"github.com/concourse/concourse/atc/db/algorithm"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/event"
+	"github.com/lib/pq"
 )
 
 type ErrResourceNotFound struct {
@@ -24,6 +27,40 @@ func (e ErrResourceNotFound) Error() string {
 	return fmt.Sprintf("resource '%s' not found", e.Name)
 }
 
+type ErrJobNotFound struct {
+	Name string
+}
+
+func (e ErrJobNotFound) Error() string {
+	return fmt.Sprintf("job '%s' not found", e.Name)
+}
+
+type ErrPipelineNameTaken struct {
+	Name string
+}
+
+func (e ErrPipelineNameTaken) Error() string {
+	return fmt.Sprintf("pipeline '%s' already exists", e.Name)
+}
+
+type ErrConfigVersionNotFound struct {
+	Version ConfigVersion
+}
+
+func (e ErrConfigVersionNotFound) Error() string {
+	return fmt.Sprintf("config version %d not found", e.Version)
+}
+
+// ErrInvalidConfig carries every problem found when validating a config, so
+// that they can all be reported at once.
+type ErrInvalidConfig struct {
+	Errors []string
+}
+
+func (e ErrInvalidConfig) Error() string {
+	return fmt.Sprintf("invalid config:\n%s", strings.Join(e.Errors, "\n"))
+}
+
 //go:generate counterfeiter . Pipeline
 
 type Cause struct {
@@ -31,37 +68,74 @@ type Cause struct {
 	BuildID           int `json:"build_id"`
 }
 
+type PauseEvent struct {
+	Paused bool
+	Actor  string
+	Time   time.Time
+}
+
 type Pipeline interface {
 	ID() int
 	Name() string
 	TeamID() int
 	TeamName() string
 	Groups() atc.GroupConfigs
+	Config() (atc.Config, error)
+	GetConfigVersion(ConfigVersion) (atc.Config, bool, error)
+	ListConfigVersions(limit int) ([]ConfigVersion, error)
+	ConfigDiff(from ConfigVersion, to ConfigVersion) (ConfigDiff, error)
+	Graph() (PipelineGraph, error)
+	GetJobsUsingResource(resource string) ([]ResourceUsage, error)
+	RevertConfig(to ConfigVersion) (ConfigVersion, error)
+	UpdateResourceConfig(name string, source atc.Source) (ConfigVersion, error)
+	UpdateJob(name string, config atc.JobConfig) (ConfigVersion, error)
 	ConfigVersion() ConfigVersion
 	Public() bool
 	Paused() bool
+	Archived() bool
+	LastUpdated() time.Time
+	ConfigUpdated() time.Time
 
 	CheckPaused() (bool, error)
 	Reload() (bool, error)
 
 	Causality(versionedResourceID int) ([]Cause, error)
+	GetVersionCausality(resourceConfigVersionID int, maxDepth int) (CausalityGraph, error)
 	ResourceVersion(resourceConfigVersionID int) (atc.ResourceVersion, bool, error)
+	ResourceReuseStats(resource string, since time.Time) (ReuseStats, error)
 
 	GetBuildsWithVersionAsInput(int, int) ([]Build, error)
 	GetBuildsWithVersionAsOutput(int, int) ([]Build, error)
+	GetBuildForOutput(resource string, version atc.Version) (Build, bool, error)
+	GetBuildsForInputVersion(resource string, version atc.Version, limit int) ([]Build, error)
+	UpdateVersionMetadata(resource string, version atc.Version, metadata ResourceConfigMetadataFields) error
 	Builds(page Page) ([]Build, Pagination, error)
 
 	CreateOneOffBuild() (Build, error)
 	CreateStartedBuild(plan atc.Plan) (Build, error)
+	CreateJobBuild(jobName string, trigger BuildTrigger) (Build, error)
+	CreateJobBuildWithKey(jobName string, key string) (Build, bool, error)
+	ConsecutiveFailures(jobName string) (int, error)
+	RerunBuild(buildID int) (Build, error)
 
 	GetAllPendingBuilds() (map[string][]Build, error)
+	BuildsWithStatus(statuses []BuildStatus, limit int) ([]Build, error)
+	BuildsWithTrigger(trigger BuildTrigger, limit int) ([]Build, error)
+	RunningBuildCount() (int, error)
+	AbortAllRunningBuilds(reason string) ([]int, error)
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
 
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
 
 	AcquireSchedulingLock(lager.Logger, time.Duration) (lock.Lock, bool, error)
+	AcquireSerialLock(logger lager.Logger, jobName string) (lock.Lock, bool, error)
+	AcquireSerialGroupLock(logger lager.Logger, groups []string) (lock.Lock, bool, error)
+	LeaseResourceChecking(resource string, interval time.Duration) (Lease, bool, error)
+	LeaseScheduling(interval time.Duration) (Lease, bool, error)
+	SchedulingLeaseHolder() (string, bool, error)
 
 	LoadVersionsDB() (*algorithm.VersionsDB, error)
+	LoadVersionsDBContext(ctx context.Context) (*algorithm.VersionsDB, error)
 
 	Resource(name string) (Resource, bool, error)
 	ResourceByID(id int) (Resource, bool, error)
@@ -74,12 +148,18 @@ type Pipeline interface {
 	Job(name string) (Job, bool, error)
 	Jobs() (Jobs, error)
 	Dashboard() (Dashboard, error)
+	LatestSuccessfulBuilds() (map[string]Build, error)
+	JobStatus(jobName string) (JobStatus, bool, error)
 
 	Expose() error
 	Hide() error
 
-	Pause() error
-	Unpause() error
+	Pause(actor string) error
+	Unpause(actor string) error
+	PauseHistory() ([]PauseEvent, error)
+
+	Archive() error
+	Unarchive() error
 
 	Destroy() error
 	Rename(string) error
@@ -94,6 +174,9 @@ type pipeline struct {
 	configVersion ConfigVersion
 	paused        bool
 	public        bool
+	archived      bool
+	lastUpdated   time.Time
+	configUpdated time.Time
 
 	cacheIndex int
 	versionsDB *algorithm.VersionsDB
@@ -115,7 +198,10 @@ var pipelinesQuery = psql.Select(`
 		p.team_id,
 		t.name,
 		p.paused,
-		p.public
+		p.public,
+		p.archived,
+		p.last_updated,
+		p.config_updated
 	`).
 	From("pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id")
@@ -157,6 +243,12 @@ func (p *pipeline) Groups() atc.GroupConfigs     { return p.groups }
 func (p *pipeline) ConfigVersion() ConfigVersion { return p.configVersion }
 func (p *pipeline) Public() bool                 { return p.public }
 func (p *pipeline) Paused() bool                 { return p.paused }
+func (p *pipeline) Archived() bool               { return p.archived }
+
+// LastUpdated returns when the pipeline's config or state (e.g. whether it is
+// paused) last changed, while ConfigUpdated only tracks changes to its config.
+func (p *pipeline) LastUpdated() time.Time   { return p.lastUpdated }
+func (p *pipeline) ConfigUpdated() time.Time { return p.configUpdated }
 
 // IMPORTANT: This method is broken with the new resource config versions changes
 func (p *pipeline) Causality(versionedResourceID int) ([]Cause, error) {
@@ -238,53 +330,138 @@ func (p *pipeline) Reload() (bool, error) {
 	return true, nil
 }
 
-func (p *pipeline) CreateJobBuild(jobName string) (Build, error) {
-	tx, err := p.conn.Begin()
+// CreateJobBuild creates a pending build of the job, recording what
+// triggered it. It returns ErrJobNotFound if the pipeline has no such job.
+func (p *pipeline) CreateJobBuild(jobName string, trigger BuildTrigger) (Build, error) {
+	job, found, err := p.job(jobName)
 	if err != nil {
 		return nil, err
 	}
 
-	defer Rollback(tx)
+	if !found {
+		return nil, ErrJobNotFound{jobName}
+	}
+
+	return job.createBuild(trigger, map[string]interface{}{})
+}
+
+// BuildsWithStatus returns up to limit builds of the pipeline in any of the
+// given statuses, most recently started first.
+func (p *pipeline) BuildsWithStatus(statuses []BuildStatus, limit int) ([]Build, error) {
+	query := buildsQuery.
+		Where(sq.Eq{
+			"b.pipeline_id": p.id,
+			"b.status":      statuses,
+		}).
+		OrderBy("b.start_time DESC NULLS LAST", "b.id DESC").
+		Limit(uint64(limit))
+
+	return getBuilds(query, p.conn, p.lockFactory)
+}
 
-	buildName, jobID, err := getNewBuildNameForJob(tx, jobName, p.id)
+// BuildsWithTrigger returns up to limit builds of the pipeline that were
+// created by the given trigger, newest first.
+func (p *pipeline) BuildsWithTrigger(trigger BuildTrigger, limit int) ([]Build, error) {
+	query := buildsQuery.
+		Where(sq.Eq{
+			"b.pipeline_id":    p.id,
+			"b.trigger_source": trigger,
+		}).
+		OrderBy("b.id DESC").
+		Limit(uint64(limit))
+
+	return getBuilds(query, p.conn, p.lockFactory)
+}
+
+// RunningBuildCount returns how many of the pipeline's builds have started and
+// not yet finished. Pending builds are not counted.
+func (p *pipeline) RunningBuildCount() (int, error) {
+	var count int
+	err := psql.Select("COUNT(*)").
+		From("builds").
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+			"status":      BuildStatusStarted,
+		}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&count)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	var buildID int
-	err = psql.Insert("builds").
-		Columns("name", "job_id", "team_id", "status", "manually_triggered").
-		Values(buildName, jobID, p.teamID, "pending", true).
-		Suffix("RETURNING id").
-		RunWith(tx).
-		QueryRow().
-		Scan(&buildID)
+	return count, nil
+}
+
+// AbortAllRunningBuilds marks every pending or started build of the pipeline
+// as aborted in a single transaction, e.g. before the pipeline is destroyed,
+// and returns the ids of the builds it aborted. Each build is notified of its
+// abort like with Build.AbortWithReason once the transaction has committed.
+func (p *pipeline) AbortAllRunningBuilds(reason string) ([]int, error) {
+	tx, err := p.conn.Begin()
 	if err != nil {
 		return nil, err
 	}
 
-	build := &build{conn: p.conn, lockFactory: p.lockFactory}
-	err = scanBuild(build, buildsQuery.
-		Where(sq.Eq{"b.id": buildID}).
+	defer Rollback(tx)
+
+	rows, err := buildsQuery.
+		Where(sq.Eq{
+			"b.pipeline_id": p.id,
+			"b.status":      []BuildStatus{BuildStatusPending, BuildStatusStarted},
+			"b.aborted":     false,
+		}).
+		OrderBy("b.id ASC").
+		Suffix("FOR UPDATE OF b").
 		RunWith(tx).
-		QueryRow(),
-		p.conn.EncryptionStrategy(),
-	)
+		Query()
 	if err != nil {
 		return nil, err
 	}
 
-	err = createBuildEventSeq(tx, buildID)
+	defer Close(rows)
+
+	builds := []*build{}
+	for rows.Next() {
+		build := &build{conn: p.conn, lockFactory: p.lockFactory}
+		err = scanBuild(build, rows, p.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		builds = append(builds, build)
+	}
+
+	err = rows.Close()
 	if err != nil {
 		return nil, err
 	}
 
+	abortedIDs := []int{}
+	eventIDs := []int{}
+	for _, build := range builds {
+		eventID, err := build.abort(tx, reason)
+		if err != nil {
+			return nil, err
+		}
+
+		abortedIDs = append(abortedIDs, build.id)
+		eventIDs = append(eventIDs, eventID)
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
 
-	return build, nil
+	for i, build := range builds {
+		err = build.notifyAborted(reason, eventIDs[i])
+		if err != nil {
+			return abortedIDs, err
+		}
+	}
+
+	return abortedIDs, nil
 }
 
 func (p *pipeline) GetAllPendingBuilds() (map[string][]Build, error) {
@@ -424,6 +601,130 @@ func (p *pipeline) GetBuildsWithVersionAsOutput(resourceID, resourceConfigVersio
 	return builds, err
 }
 
+// GetBuildForOutput returns the latest build that put the given version of
+// the named resource. It returns ErrResourceNotFound if the pipeline has no
+// such resource.
+func (p *pipeline) GetBuildForOutput(resourceName string, version atc.Version) (Build, bool, error) {
+	resource, found, err := p.Resource(resourceName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, ErrResourceNotFound{resourceName}
+	}
+
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return nil, false, err
+	}
+
+	row := buildsQuery.
+		Join("build_resource_config_version_outputs bo ON bo.build_id = b.id").
+		Where(sq.Eq{"bo.resource_id": resource.ID()}).
+		Where(sq.Expr("bo.version_md5 = md5(?)", versionJSON)).
+		OrderBy("b.id DESC").
+		Limit(1).
+		RunWith(p.conn).
+		QueryRow()
+
+	build := &build{conn: p.conn, lockFactory: p.lockFactory}
+	err = scanBuild(build, row, p.conn.EncryptionStrategy())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return build, true, nil
+}
+
+// GetBuildsForInputVersion returns the builds that used the given version of
+// the named resource as an input, newest first. A limit of zero returns all
+// of them. It returns ErrResourceNotFound if the pipeline has no such
+// resource.
+func (p *pipeline) GetBuildsForInputVersion(resourceName string, version atc.Version, limit int) ([]Build, error) {
+	resource, found, err := p.Resource(resourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ErrResourceNotFound{resourceName}
+	}
+
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return nil, err
+	}
+
+	query := buildsQuery.
+		Where(sq.Expr(`EXISTS (
+			SELECT 1
+			FROM build_resource_config_version_inputs bi
+			WHERE bi.build_id = b.id
+			AND bi.resource_id = ?
+			AND bi.version_md5 = md5(?)
+		)`, resource.ID(), versionJSON)).
+		OrderBy("b.id DESC")
+
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+
+	return getBuilds(query, p.conn, p.lockFactory)
+}
+
+// UpdateVersionMetadata replaces the metadata of an existing version of the
+// named resource, e.g. when a re-check finds that it has changed. Builds which
+// used the version see the new metadata. It returns ErrResourceNotFound if the
+// pipeline has no such resource, and ErrVersionNotFound if the resource has no
+// such version.
+func (p *pipeline) UpdateVersionMetadata(resourceName string, version atc.Version, metadata ResourceConfigMetadataFields) error {
+	resource, found, err := p.Resource(resourceName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return ErrResourceNotFound{resourceName}
+	}
+
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	result, err := p.conn.Exec(`
+		UPDATE resource_config_versions v
+		SET metadata = $3
+		FROM resources r
+		WHERE r.id = $1
+		AND v.resource_config_scope_id = r.resource_config_scope_id
+		AND v.version_md5 = md5($2)
+	`, resource.ID(), string(versionJSON), string(metadataJSON))
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrVersionNotFound
+	}
+
+	return nil
+}
+
 func (p *pipeline) Resource(name string) (Resource, bool, error) {
 	return p.resource(sq.Eq{
 		"r.pipeline_id": p.id,
@@ -467,15 +768,333 @@ func (p *pipeline) BuildsWithTime(page Page) ([]Build, Pagination, error) {
 		buildsQuery.Where(sq.Eq{"b.pipeline_id": p.id}), minMaxIdQuery, page, p.conn, p.lockFactory)
 }
 
+// Config reassembles the pipeline's current config from its active jobs,
+// resources and resource types.
+func (p *pipeline) Config() (atc.Config, error) {
+	return p.config(p.conn)
+}
+
+// config assembles the pipeline's config from its groups and its jobs,
+// resources and resource types, reading the latter through the given runner
+// so that it can be read within a transaction.
+func (p *pipeline) config(runner sq.BaseRunner) (atc.Config, error) {
+	jobs, err := p.jobs(runner)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	resources, err := p.resources(runner)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	resourceTypes, err := p.resourceTypes(runner)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	return atc.Config{
+		Groups:        p.Groups(),
+		Resources:     resources.Configs(),
+		ResourceTypes: resourceTypes.Configs(),
+		Jobs:          jobs.Configs(),
+	}, nil
+}
+
+// GetConfigVersion returns the config the pipeline had at the given version,
+// as long as it has not been pruned from the config history.
+func (p *pipeline) GetConfigVersion(version ConfigVersion) (atc.Config, bool, error) {
+	var (
+		configBlob []byte
+		nonce      sql.NullString
+	)
+
+	err := psql.Select("config", "nonce").
+		From("pipeline_configs").
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+			"version":     version,
+		}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&configBlob, &nonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// pipelines saved before config history was kept have no entry for
+			// their current version
+			if version == p.configVersion {
+				config, err := p.Config()
+				if err != nil {
+					return atc.Config{}, false, err
+				}
+
+				return config, true, nil
+			}
+
+			return atc.Config{}, false, nil
+		}
+
+		return atc.Config{}, false, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decryptedConfig, err := p.conn.EncryptionStrategy().Decrypt(string(configBlob), noncense)
+	if err != nil {
+		return atc.Config{}, false, err
+	}
+
+	var config atc.Config
+	err = json.Unmarshal(decryptedConfig, &config)
+	if err != nil {
+		return atc.Config{}, false, err
+	}
+
+	return config, true, nil
+}
+
+// ListConfigVersions returns the versions kept in the pipeline's config
+// history, newest first.
+func (p *pipeline) ListConfigVersions(limit int) ([]ConfigVersion, error) {
+	query := psql.Select("version").
+		From("pipeline_configs").
+		Where(sq.Eq{"pipeline_id": p.id}).
+		OrderBy("version DESC")
+
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+
+	rows, err := query.RunWith(p.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	versions := []ConfigVersion{}
+	for rows.Next() {
+		var version ConfigVersion
+		err = rows.Scan(&version)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (p *pipeline) ConfigDiff(from ConfigVersion, to ConfigVersion) (ConfigDiff, error) {
+	fromConfig, found, err := p.GetConfigVersion(from)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+
+	if !found {
+		return ConfigDiff{}, ErrConfigVersionNotFound{from}
+	}
+
+	toConfig, found, err := p.GetConfigVersion(to)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+
+	if !found {
+		return ConfigDiff{}, ErrConfigVersionNotFound{to}
+	}
+
+	return DiffConfigs(fromConfig, toConfig), nil
+}
+
+// Graph returns the job and resource edges of the pipeline's current config.
+func (p *pipeline) Graph() (PipelineGraph, error) {
+	config, err := p.Config()
+	if err != nil {
+		return PipelineGraph{}, err
+	}
+
+	return BuildPipelineGraph(config), nil
+}
+
+// GetJobsUsingResource returns the jobs that get or put to the named resource
+// according to the pipeline's current config. It returns ErrResourceNotFound
+// if the pipeline has no such resource.
+func (p *pipeline) GetJobsUsingResource(resource string) ([]ResourceUsage, error) {
+	config, err := p.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, found := config.Resources.Lookup(resource); !found {
+		return nil, ErrResourceNotFound{resource}
+	}
+
+	return BuildPipelineGraph(config).JobsUsingResource(resource), nil
+}
+
+// RevertConfig saves the config of an older version as a new version of the
+// pipeline and returns the new version. The config is validated the same way
+// the API validates a config before saving it.
+func (p *pipeline) RevertConfig(to ConfigVersion) (ConfigVersion, error) {
+	config, found, err := p.GetConfigVersion(to)
+	if err != nil {
+		return 0, err
+	}
+
+	if !found {
+		return 0, ErrConfigVersionNotFound{to}
+	}
+
+	return p.saveConfig(func(atc.Config) (atc.Config, error) {
+		return config, nil
+	})
+}
+
+// UpdateResourceConfig replaces the source of a single resource in the
+// pipeline's latest config, saving the result as a new config version.
+func (p *pipeline) UpdateResourceConfig(name string, source atc.Source) (ConfigVersion, error) {
+	return p.saveConfig(func(config atc.Config) (atc.Config, error) {
+		for i, resource := range config.Resources {
+			if resource.Name == name {
+				config.Resources[i].Source = source
+				return config, nil
+			}
+		}
+
+		return atc.Config{}, ErrResourceNotFound{name}
+	})
+}
+
+// UpdateJob replaces the config of a single job in the pipeline's latest
+// config, saving the result as a new config version.
+func (p *pipeline) UpdateJob(name string, jobConfig atc.JobConfig) (ConfigVersion, error) {
+	return p.saveConfig(func(config atc.Config) (atc.Config, error) {
+		for i, job := range config.Jobs {
+			if job.Name == name {
+				config.Jobs[i] = jobConfig
+				return config, nil
+			}
+		}
+
+		return atc.Config{}, ErrJobNotFound{name}
+	})
+}
+
+// saveConfig validates and saves the config returned by update as a new
+// version of the pipeline's config. update is given the pipeline's latest
+// config, which is read along with its version while holding a lock on the
+// pipeline, so that no other save can happen in between.
+func (p *pipeline) saveConfig(update func(atc.Config) (atc.Config, error)) (ConfigVersion, error) {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	latest := newPipeline(p.conn, p.lockFactory)
+
+	err = scanPipeline(latest, pipelinesQuery.
+		Where(sq.Eq{"p.id": p.id}).
+		Suffix("FOR UPDATE OF p").
+		RunWith(tx).
+		QueryRow(),
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrPipelineNotFound
+		}
+
+		return 0, err
+	}
+
+	config, err := latest.config(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	config, err = update(config)
+	if err != nil {
+		return 0, err
+	}
+
+	// a config from the history was valid when it was saved, but validation
+	// may have become stricter since
+	_, errorMessages := config.Validate()
+	if len(errorMessages) > 0 {
+		return 0, ErrInvalidConfig{errorMessages}
+	}
+
+	team := &team{
+		id:          p.teamID,
+		conn:        p.conn,
+		lockFactory: p.lockFactory,
+	}
+
+	saved, _, err := team.savePipeline(tx, latest.name, config, latest.configVersion, PipelineNoChange)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	p.configVersion = saved.ConfigVersion()
+
+	return p.configVersion, nil
+}
+
 func (p *pipeline) Resources() (Resources, error) {
-	return resources(p.id, p.conn, p.lockFactory)
+	return p.resources(p.conn)
+}
+
+func (p *pipeline) resources(runner sq.BaseRunner) (Resources, error) {
+	rows, err := resourcesQuery.
+		Where(sq.Eq{"r.pipeline_id": p.id}).
+		OrderBy("r.name").
+		RunWith(runner).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+	defer Close(rows)
+
+	var resources Resources
+
+	for rows.Next() {
+		newResource := &resource{conn: p.conn, lockFactory: p.lockFactory}
+		err := scanResource(newResource, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, newResource)
+	}
+
+	return resources, nil
 }
 
 func (p *pipeline) ResourceTypes() (ResourceTypes, error) {
+	return p.resourceTypes(p.conn)
+}
+
+func (p *pipeline) resourceTypes(runner sq.BaseRunner) (ResourceTypes, error) {
 	rows, err := resourceTypesQuery.
 		Where(sq.Eq{"r.pipeline_id": p.id}).
 		OrderBy("r.name").
-		RunWith(p.conn).
+		RunWith(runner).
 		Query()
 	if err != nil {
 		return nil, err
@@ -531,34 +1150,97 @@ func (p *pipeline) resourceType(where map[string]interface{}) (ResourceType, boo
 }
 
 func (p *pipeline) Job(name string) (Job, bool, error) {
+	job, found, err := p.job(name)
+	if err != nil || !found {
+		return nil, found, err
+	}
+
+	return job, true, nil
+}
+
+func (p *pipeline) job(name string) (*job, bool, error) {
 	row := jobsQuery.Where(sq.Eq{
 		"j.name":        name,
 		"j.active":      true,
 		"j.pipeline_id": p.id,
 	}).RunWith(p.conn).QueryRow()
 
-	job := &job{conn: p.conn, lockFactory: p.lockFactory}
-	err := scanJob(job, row)
+	job := &job{conn: p.conn, lockFactory: p.lockFactory}
+	err := scanJob(job, row)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return job, true, nil
+}
+
+// CreateJobBuildWithKey creates a pending build of the job, unless a build of
+// the job was already created with the same key, in which case that build is
+// returned and created is false. This makes it safe to retry a create whose
+// outcome is unknown.
+func (p *pipeline) CreateJobBuildWithKey(jobName string, key string) (Build, bool, error) {
+	job, found, err := p.job(jobName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, ErrJobNotFound{jobName}
+	}
+
+	return job.createBuildWithKey(BuildTriggerManual, key)
+}
 
+// ConsecutiveFailures returns how many of the named job's builds have
+// finished without succeeding since its latest successful build, or how many
+// have finished at all if it has never succeeded. Builds that are still
+// running are not counted. It returns ErrJobNotFound if the pipeline has no
+// such job.
+func (p *pipeline) ConsecutiveFailures(jobName string) (int, error) {
+	job, found, err := p.job(jobName)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, false, nil
-		}
+		return 0, err
+	}
 
-		return nil, false, err
+	if !found {
+		return 0, ErrJobNotFound{jobName}
 	}
 
-	return job, true, nil
+	var failures int
+	err = p.conn.QueryRow(`
+		SELECT COUNT(*)
+		FROM (
+			SELECT id, MAX(id) FILTER (WHERE status = 'succeeded') OVER () AS last_success
+			FROM builds
+			WHERE job_id = $1
+			AND completed
+		) b
+		WHERE b.id > COALESCE(b.last_success, 0)
+	`, job.ID()).Scan(&failures)
+	if err != nil {
+		return 0, err
+	}
+
+	return failures, nil
 }
 
 func (p *pipeline) Jobs() (Jobs, error) {
+	return p.jobs(p.conn)
+}
+
+func (p *pipeline) jobs(runner sq.BaseRunner) (Jobs, error) {
 	rows, err := jobsQuery.
 		Where(sq.Eq{
 			"pipeline_id": p.id,
 			"active":      true,
 		}).
 		OrderBy("j.id ASC").
-		RunWith(p.conn).
+		RunWith(runner).
 		Query()
 	if err != nil {
 		return nil, err
@@ -617,33 +1299,137 @@ func (p *pipeline) Dashboard() (Dashboard, error) {
 	return dashboard, nil
 }
 
-func (p *pipeline) Pause() error {
-	_, err := psql.Update("pipelines").
-		Set("paused", true).
+func (p *pipeline) Pause(actor string) error {
+	return p.updatePaused(true, actor)
+}
+
+func (p *pipeline) Unpause(actor string) error {
+	return p.updatePaused(false, actor)
+}
+
+// updatePaused sets the paused state of the pipeline, recording who changed
+// it and when if the state actually changed.
+func (p *pipeline) updatePaused(paused bool, actor string) error {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Update("pipelines").
+		Set("paused", paused).
+		Set("last_updated", sq.Expr("clock_timestamp()")).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
-		RunWith(p.conn).
+		Where(sq.NotEq{
+			"paused": paused,
+		}).
+		RunWith(tx).
 		Exec()
+	if err != nil {
+		return err
+	}
 
-	return err
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 1 {
+		_, err = psql.Insert("pipeline_pause_events").
+			Columns("pipeline_id", "paused", "actor").
+			Values(p.id, paused, sq.Expr("NULLIF(?, '')", actor)).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
-func (p *pipeline) Unpause() error {
-	_, err := psql.Update("pipelines").
-		Set("paused", false).
+// Archive stops the pipeline from being scheduled and hides it from pipeline
+// listings, while keeping its builds and resource versions around.
+func (p *pipeline) Archive() error {
+	return p.updateArchived(true)
+}
+
+func (p *pipeline) Unarchive() error {
+	return p.updateArchived(false)
+}
+
+func (p *pipeline) updateArchived(archived bool) error {
+	result, err := psql.Update("pipelines").
+		Set("archived", archived).
+		Set("last_updated", sq.Expr("clock_timestamp()")).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
 		RunWith(p.conn).
 		Exec()
+	if err != nil {
+		return err
+	}
 
-	return err
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	p.archived = archived
+
+	return nil
+}
+
+// PauseHistory returns every change of the pipeline's paused state, oldest
+// first.
+func (p *pipeline) PauseHistory() ([]PauseEvent, error) {
+	rows, err := psql.Select("paused", "actor", "time").
+		From("pipeline_pause_events").
+		Where(sq.Eq{"pipeline_id": p.id}).
+		OrderBy("id ASC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	events := []PauseEvent{}
+	for rows.Next() {
+		var event PauseEvent
+		var actor sql.NullString
+
+		err = rows.Scan(&event.Paused, &actor, &event.Time)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Actor = actor.String
+
+		events = append(events, event)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
 }
 
 func (p *pipeline) Hide() error {
 	_, err := psql.Update("pipelines").
 		Set("public", false).
+		Set("last_updated", sq.Expr("clock_timestamp()")).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
@@ -656,6 +1442,7 @@ func (p *pipeline) Hide() error {
 func (p *pipeline) Expose() error {
 	_, err := psql.Update("pipelines").
 		Set("public", true).
+		Set("last_updated", sq.Expr("clock_timestamp()")).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
@@ -665,16 +1452,29 @@ func (p *pipeline) Expose() error {
 	return err
 }
 
+// Rename changes the name of the pipeline in place, so its builds, resource
+// versions and build events are kept. It returns ErrPipelineNameTaken if
+// the team already has a pipeline with the new name.
 func (p *pipeline) Rename(name string) error {
 	_, err := psql.Update("pipelines").
 		Set("name", name).
+		Set("last_updated", sq.Expr("clock_timestamp()")).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
 		RunWith(p.conn).
 		Exec()
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return ErrPipelineNameTaken{Name: name}
+		}
 
-	return err
+		return err
+	}
+
+	p.name = name
+
+	return nil
 }
 
 func (p *pipeline) Destroy() error {
@@ -689,12 +1489,18 @@ func (p *pipeline) Destroy() error {
 }
 
 func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
+	return p.LoadVersionsDBContext(context.Background())
+}
+
+// LoadVersionsDBContext loads the versions used for scheduling, cancelling the
+// queries involved if the context is done first.
+func (p *pipeline) LoadVersionsDBContext(ctx context.Context) (*algorithm.VersionsDB, error) {
 	var cacheIndex int
 	err := psql.Select("cache_index").
 		From("pipelines").
 		Where(sq.Eq{"id": p.id}).
 		RunWith(p.conn).
-		QueryRow().
+		QueryRowContext(ctx).
 		Scan(&cacheIndex)
 	if err != nil {
 		return nil, err
@@ -727,7 +1533,7 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 			"r.pipeline_id": p.id,
 		}).
 		RunWith(p.conn).
-		Query()
+		QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -760,7 +1566,7 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 			"r.pipeline_id": p.id,
 		}).
 		RunWith(p.conn).
-		Query()
+		QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -803,7 +1609,7 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 			"d.version_md5": nil,
 		}).
 		RunWith(p.conn).
-		Query()
+		QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -824,7 +1630,7 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		From("jobs j").
 		Where(sq.Eq{"j.pipeline_id": p.id}).
 		RunWith(p.conn).
-		Query()
+		QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -846,7 +1652,7 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		From("resources r").
 		Where(sq.Eq{"r.pipeline_id": p.id}).
 		RunWith(p.conn).
-		Query()
+		QueryContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -913,6 +1719,75 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	return err
 }
 
+// AcquireSerialLock takes the advisory lock of a serial job, which is held
+// while one of its builds is being started so that two ATCs can't both decide
+// that no other build is running. It returns ErrJobNotFound if the pipeline
+// has no such job.
+func (p *pipeline) AcquireSerialLock(logger lager.Logger, jobName string) (lock.Lock, bool, error) {
+	job, found, err := p.job(jobName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, ErrJobNotFound{jobName}
+	}
+
+	return p.lockFactory.Acquire(
+		logger.Session("serial-lock", lager.Data{
+			"pipeline": p.name,
+			"job":      jobName,
+		}),
+		lock.NewJobSerialLockID(job.id),
+	)
+}
+
+// AcquireSerialGroupLock takes the advisory locks of all of the given serial
+// groups, so that jobs sharing any of them can't start builds at the same
+// time. Either all of the locks are acquired or none are.
+func (p *pipeline) AcquireSerialGroupLock(logger lager.Logger, groups []string) (lock.Lock, bool, error) {
+	sorted := make([]string, len(groups))
+	copy(sorted, groups)
+	sort.Strings(sorted)
+
+	held := serialGroupLock{}
+	for _, group := range sorted {
+		groupLock, acquired, err := p.lockFactory.Acquire(
+			logger.Session("serial-group-lock", lager.Data{
+				"pipeline":     p.name,
+				"serial-group": group,
+			}),
+			lock.NewSerialGroupLockID(p.id, group),
+		)
+		if err != nil || !acquired {
+			releaseErr := held.Release()
+			if releaseErr != nil {
+				logger.Error("failed-to-release-serial-group-lock", releaseErr)
+			}
+
+			return nil, false, err
+		}
+
+		held = append(held, groupLock)
+	}
+
+	return held, true, nil
+}
+
+type serialGroupLock []lock.Lock
+
+func (locks serialGroupLock) Release() error {
+	var releaseErr error
+	for _, l := range locks {
+		err := l.Release()
+		if err != nil && releaseErr == nil {
+			releaseErr = err
+		}
+	}
+
+	return releaseErr
+}
+
 func (p *pipeline) AcquireSchedulingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error) {
 	lock, acquired, err := p.lockFactory.Acquire(
 		logger.Session("lock", lager.Data{
@@ -962,6 +1837,39 @@ func (p *pipeline) AcquireSchedulingLock(logger lager.Logger, interval time.Dura
 	return lock, true, nil
 }
 
+// LeaseResourceChecking leases checking the named resource for the given
+// interval, so that only one ATC checks it in the meantime. It returns
+// ErrResourceNotFound if the pipeline has no such resource.
+func (p *pipeline) LeaseResourceChecking(resource string, interval time.Duration) (Lease, bool, error) {
+	r, found, err := p.Resource(resource)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, ErrResourceNotFound{resource}
+	}
+
+	return acquireLease(p.conn, fmt.Sprintf("resource-checking:%d", r.ID()), interval)
+}
+
+// LeaseScheduling leases scheduling the pipeline for the given interval, so
+// that only one ATC schedules it in the meantime. If the holder goes away
+// without breaking the lease, another ATC can take over once it expires.
+func (p *pipeline) LeaseScheduling(interval time.Duration) (Lease, bool, error) {
+	return acquireLease(p.conn, p.schedulingLeaseName(), interval)
+}
+
+// SchedulingLeaseHolder returns which ATC currently holds the pipeline's
+// scheduling lease, if any.
+func (p *pipeline) SchedulingLeaseHolder() (string, bool, error) {
+	return currentLeaseHolder(p.conn, p.schedulingLeaseName())
+}
+
+func (p *pipeline) schedulingLeaseName() string {
+	return fmt.Sprintf("pipeline-scheduling:%d", p.id)
+}
+
 func (p *pipeline) CreateOneOffBuild() (Build, error) {
 	tx, err := p.conn.Begin()
 	if err != nil {
@@ -972,15 +1880,79 @@ func (p *pipeline) CreateOneOffBuild() (Build, error) {
 
 	build := &build{conn: p.conn, lockFactory: p.lockFactory}
 	err = createBuild(tx, build, map[string]interface{}{
-		"name":        sq.Expr("nextval('one_off_name')"),
-		"pipeline_id": p.id,
-		"team_id":     p.teamID,
-		"status":      BuildStatusPending,
+		"name":           sq.Expr("nextval('one_off_name')"),
+		"pipeline_id":    p.id,
+		"team_id":        p.teamID,
+		"status":         BuildStatusPending,
+		"trigger_source": BuildTriggerManual,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+// RerunBuild creates a new build of the same job as the given build, using
+// the exact input versions the given build ran with.
+func (p *pipeline) RerunBuild(buildID int) (Build, error) {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	job := &job{conn: p.conn, lockFactory: p.lockFactory}
+	err = scanJob(job, jobsQuery.
+		Where(sq.Expr("j.id = (SELECT job_id FROM builds WHERE id = ? AND pipeline_id = ?)", buildID, p.id)).
+		RunWith(tx).
+		QueryRow(),
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrBuildNotFound
+		}
+		return nil, err
+	}
+
+	build := &build{conn: p.conn, lockFactory: p.lockFactory}
+	err = job.createBuildTx(tx, build, BuildTriggerRerun, map[string]interface{}{
+		"rerun_of": buildID,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	result, err := tx.Exec(`
+		INSERT INTO build_resource_config_version_inputs (build_id, resource_id, version_md5, name)
+		SELECT $1, resource_id, version_md5, name
+		FROM build_resource_config_version_inputs
+		WHERE build_id = $2
+	`, build.id, buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	copied, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if copied == 0 {
+		return nil, ErrBuildHasNoInputs
+	}
+
+	err = bumpCacheIndex(tx, p.id)
+	if err != nil {
+		return nil, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -1009,21 +1981,22 @@ func (p *pipeline) CreateStartedBuild(plan atc.Plan) (Build, error) {
 
 	build := &build{conn: p.conn, lockFactory: p.lockFactory}
 	err = createBuild(tx, build, map[string]interface{}{
-		"name":         sq.Expr("nextval('one_off_name')"),
-		"pipeline_id":  p.id,
-		"team_id":      p.teamID,
-		"status":       BuildStatusStarted,
-		"start_time":   sq.Expr("now()"),
-		"schema":       schema,
-		"private_plan": encryptedPlan,
-		"public_plan":  plan.Public(),
-		"nonce":        nonce,
+		"name":           sq.Expr("nextval('one_off_name')"),
+		"pipeline_id":    p.id,
+		"team_id":        p.teamID,
+		"status":         BuildStatusStarted,
+		"start_time":     sq.Expr("now()"),
+		"schema":         schema,
+		"private_plan":   encryptedPlan,
+		"public_plan":    plan.Public(),
+		"nonce":          nonce,
+		"trigger_source": BuildTriggerManual,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	err = build.saveEvent(tx, event.Status{
+	eventID, err := build.saveEvent(tx, event.Status{
 		Status: atc.StatusStarted,
 		Time:   build.StartTime().Unix(),
 	})
@@ -1040,7 +2013,7 @@ func (p *pipeline) CreateStartedBuild(plan atc.Plan) (Build, error) {
 		return nil, err
 	}
 
-	if err = p.conn.Bus().Notify(buildEventsChannel(build.id)); err != nil {
+	if err = build.notifyEvents(eventID); err != nil {
 		return nil, err
 	}
 
@@ -1066,13 +2039,108 @@ func (p *pipeline) incrementCheckOrderWhenNewerVersion(tx Tx, resourceID int, re
 	return err
 }
 
-func (p *pipeline) getBuildsFrom(col string) (map[string]Build, error) {
+// JobStatus returns the next, latest finished and transition builds of a job.
+// The builds are read along with the job in a single query, so that they are
+// consistent with each other.
+func (p *pipeline) JobStatus(jobName string) (JobStatus, bool, error) {
 	rows, err := buildsQuery.
+		Columns("js.next_build_id", "js.latest_completed_build_id", "js.transition_build_id").
+		Join("jobs js ON b.id IN (js.next_build_id, js.latest_completed_build_id, js.transition_build_id)").
+		Where(sq.Eq{
+			"js.pipeline_id": p.id,
+			"js.name":        jobName,
+			"js.active":      true,
+		}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return JobStatus{}, false, err
+	}
+
+	defer Close(rows)
+
+	status := JobStatus{}
+	found := false
+	for rows.Next() {
+		var nextBuildID, finishedBuildID, transitionBuildID sql.NullInt64
+
+		build := &build{conn: p.conn, lockFactory: p.lockFactory}
+		err := scanBuild(build, withTrailingColumns{rows, []interface{}{&nextBuildID, &finishedBuildID, &transitionBuildID}}, p.conn.EncryptionStrategy())
+		if err != nil {
+			return JobStatus{}, false, err
+		}
+
+		found = true
+
+		id := int64(build.ID())
+
+		if nextBuildID.Valid && nextBuildID.Int64 == id {
+			status.NextBuild = build
+		}
+
+		if finishedBuildID.Valid && finishedBuildID.Int64 == id {
+			status.FinishedBuild = build
+		}
+
+		if transitionBuildID.Valid && transitionBuildID.Int64 == id {
+			status.TransitionBuild = build
+		}
+	}
+
+	if found {
+		return status, true, nil
+	}
+
+	// the job has none of the builds, so there is nothing to be inconsistent
+	// with; it only remains to tell whether it exists
+	_, found, err = p.job(jobName)
+	if err != nil {
+		return JobStatus{}, false, err
+	}
+
+	return status, found, nil
+}
+
+// withTrailingColumns scans a row selected with extra columns after the ones
+// expected by the scanner it is passed to, e.g. scanBuild.
+type withTrailingColumns struct {
+	row      scannable
+	trailing []interface{}
+}
+
+func (s withTrailingColumns) Scan(dest ...interface{}) error {
+	return s.row.Scan(append(dest, s.trailing...)...)
+}
+
+// LatestSuccessfulBuilds returns the most recent succeeded build of each of
+// the pipeline's jobs, keyed by job name. Jobs that have never succeeded are
+// not included.
+func (p *pipeline) LatestSuccessfulBuilds() (map[string]Build, error) {
+	return p.buildsByJobName(buildsQuery.
+		Where(sq.Eq{
+			"b.pipeline_id": p.id,
+			"j.active":      true,
+		}).
+		Where(sq.Expr(`b.id IN (
+			SELECT MAX(id)
+			FROM builds
+			WHERE pipeline_id = ?
+			AND job_id IS NOT NULL
+			AND status = ?
+			GROUP BY job_id
+		)`, p.id, BuildStatusSucceeded)))
+}
+
+func (p *pipeline) getBuildsFrom(col string) (map[string]Build, error) {
+	return p.buildsByJobName(buildsQuery.
 		Where(sq.Eq{
 			"b.pipeline_id": p.id,
 		}).
-		Where(sq.Expr("j." + col + " = b.id")).
-		RunWith(p.conn).Query()
+		Where(sq.Expr("j." + col + " = b.id")))
+}
+
+func (p *pipeline) buildsByJobName(query sq.SelectBuilder) (map[string]Build, error) {
+	rows, err := query.RunWith(p.conn).Query()
 	if err != nil {
 		return nil, err
 	}
@@ -1115,40 +2183,3 @@ func bumpCacheIndex(tx Tx, pipelineID int) error {
 	return nil
 }
 
-func getNewBuildNameForJob(tx Tx, jobName string, pipelineID int) (string, int, error) {
-	var buildName string
-	var jobID int
-	err := tx.QueryRow(`
-		UPDATE jobs
-		SET build_number_seq = build_number_seq + 1
-		WHERE name = $1 AND pipeline_id = $2
-		RETURNING build_number_seq, id
-	`, jobName, pipelineID).Scan(&buildName, &jobID)
-	return buildName, jobID, err
-}
-
-func resources(pipelineID int, conn Conn, lockFactory lock.LockFactory) (Resources, error) {
-	rows, err := resourcesQuery.
-		Where(sq.Eq{"r.pipeline_id": pipelineID}).
-		OrderBy("r.name").
-		RunWith(conn).
-		Query()
-	if err != nil {
-		return nil, err
-	}
-	defer Close(rows)
-
-	var resources Resources
-
-	for rows.Next() {
-		newResource := &resource{conn: conn, lockFactory: lockFactory}
-		err := scanResource(newResource, rows)
-		if err != nil {
-			return nil, err
-		}
-
-		resources = append(resources, newResource)
-	}
-
-	return resources, nil
-}
@@ -1,15 +1,28 @@
 package db
 
 import (
+	"database/sql"
+	"time"
+
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc/db/lock"
 )
 
+// pipelinesModifiedOverlap is how far before the given time
+// PipelinesModifiedSince also looks. Pipelines are timestamped when they are
+// written, not when the write commits, so a change committing just after a
+// poller has looked can carry a time from before it looked.
+const pipelinesModifiedOverlap = time.Minute
+
 //go:generate counterfeiter . PipelineFactory
 
 type PipelineFactory interface {
+	Pipeline(pipelineID int) (Pipeline, bool, error)
 	VisiblePipelines([]string) ([]Pipeline, error)
+	PublicPipelines() ([]Pipeline, error)
+	PipelinesModifiedSince(since time.Time) ([]Pipeline, error)
 	AllPipelines() ([]Pipeline, error)
+	PruneConfigVersions(keep int) error
 }
 
 type pipelineFactory struct {
@@ -24,9 +37,33 @@ func NewPipelineFactory(conn Conn, lockFactory lock.LockFactory) PipelineFactory
 	}
 }
 
+// Pipeline looks up a pipeline of any team by its id.
+func (f *pipelineFactory) Pipeline(pipelineID int) (Pipeline, bool, error) {
+	pipeline := newPipeline(f.conn, f.lockFactory)
+
+	err := scanPipeline(
+		pipeline,
+		pipelinesQuery.
+			Where(sq.Eq{"p.id": pipelineID}).
+			RunWith(f.conn).
+			QueryRow(),
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return pipeline, true, nil
+}
+
 func (f *pipelineFactory) VisiblePipelines(teamNames []string) ([]Pipeline, error) {
 	rows, err := pipelinesQuery.
-		Where(sq.Eq{"t.name": teamNames}).
+		Where(sq.Eq{
+			"t.name":     teamNames,
+			"p.archived": false,
+		}).
 		OrderBy("team_id ASC", "ordering ASC").
 		RunWith(f.conn).
 		Query()
@@ -41,7 +78,10 @@ func (f *pipelineFactory) VisiblePipelines(teamNames []string) ([]Pipeline, erro
 
 	rows, err = pipelinesQuery.
 		Where(sq.NotEq{"t.name": teamNames}).
-		Where(sq.Eq{"public": true}).
+		Where(sq.Eq{
+			"public":     true,
+			"p.archived": false,
+		}).
 		OrderBy("team_id ASC", "ordering ASC").
 		RunWith(f.conn).
 		Query()
@@ -57,6 +97,45 @@ func (f *pipelineFactory) VisiblePipelines(teamNames []string) ([]Pipeline, erro
 	return append(currentTeamPipelines, otherTeamPublicPipelines...), nil
 }
 
+// PublicPipelines returns the exposed pipelines of every team, e.g. for a
+// status page shown to users who are not logged in.
+func (f *pipelineFactory) PublicPipelines() ([]Pipeline, error) {
+	rows, err := pipelinesQuery.
+		Where(sq.Eq{
+			"public":     true,
+			"p.archived": false,
+		}).
+		OrderBy("team_id ASC", "ordering ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPipelines(f.conn, f.lockFactory, rows)
+}
+
+// PipelinesModifiedSince returns the pipelines of every team whose config or
+// state changed after the given time, least recently changed first, so that
+// a poller only has to look at what changed since it last looked. Whether the
+// config itself changed can be told with Pipeline.ConfigUpdated.
+//
+// To not miss changes which were still being committed when the poller last
+// looked, pipelines changed up to a minute before the given time are returned
+// too, so pollers may see the same change more than once.
+func (f *pipelineFactory) PipelinesModifiedSince(since time.Time) ([]Pipeline, error) {
+	rows, err := pipelinesQuery.
+		Where(sq.Gt{"p.last_updated": since.Add(-pipelinesModifiedOverlap)}).
+		OrderBy("p.last_updated ASC", "p.id ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanPipelines(f.conn, f.lockFactory, rows)
+}
+
 func (f *pipelineFactory) AllPipelines() ([]Pipeline, error) {
 	rows, err := pipelinesQuery.
 		OrderBy("ordering").
@@ -68,3 +147,18 @@ func (f *pipelineFactory) AllPipelines() ([]Pipeline, error) {
 
 	return scanPipelines(f.conn, f.lockFactory, rows)
 }
+
+// PruneConfigVersions removes all but the newest keep entries from each
+// pipeline's config history.
+func (f *pipelineFactory) PruneConfigVersions(keep int) error {
+	_, err := f.conn.Exec(`
+		DELETE FROM pipeline_configs pc
+		USING (
+			SELECT id, row_number() OVER (PARTITION BY pipeline_id ORDER BY version DESC) AS rank
+			FROM pipeline_configs
+		) ranked
+		WHERE pc.id = ranked.id
+		AND ranked.rank > $1
+	`, keep)
+	return err
+}
@@ -1,6 +1,9 @@
 package db_test
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
@@ -14,6 +17,22 @@ var _ = Describe("Pipeline Factory", func() {
 		pipelineFactory = db.NewPipelineFactory(dbConn, lockFactory)
 	})
 
+	Describe("Pipeline", func() {
+		It("returns the pipeline with the given id", func() {
+			pipeline, found, err := pipelineFactory.Pipeline(defaultPipeline.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pipeline.Name()).To(Equal(defaultPipeline.Name()))
+			Expect(pipeline.TeamID()).To(Equal(defaultPipeline.TeamID()))
+		})
+
+		It("returns false when the pipeline does not exist", func() {
+			_, found, err := pipelineFactory.Pipeline(defaultPipeline.ID() + 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
 	Describe("VisiblePipelines", func() {
 		var (
 			pipeline1 db.Pipeline
@@ -84,6 +103,146 @@ var _ = Describe("Pipeline Factory", func() {
 		})
 	})
 
+	Describe("PublicPipelines", func() {
+		var publicPipeline db.Pipeline
+
+		BeforeEach(func() {
+			team, err := teamFactory.CreateTeam(atc.Team{Name: "some-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = team.SavePipeline("private-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "job-name"},
+				},
+			}, db.ConfigVersion(1), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			publicPipeline, _, err = team.SavePipeline("public-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "job-name"},
+				},
+			}, db.ConfigVersion(1), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(publicPipeline.Expose()).To(Succeed())
+
+			Expect(defaultPipeline.Expose()).To(Succeed())
+		})
+
+		It("returns the exposed pipelines of every team", func() {
+			pipelines, err := pipelineFactory.PublicPipelines()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelines).To(HaveLen(2))
+			Expect(pipelines[0].Name()).To(Equal(defaultPipeline.Name()))
+			Expect(pipelines[1].Name()).To(Equal(publicPipeline.Name()))
+		})
+
+		It("does not return pipelines once they are hidden", func() {
+			Expect(defaultPipeline.Hide()).To(Succeed())
+
+			pipelines, err := pipelineFactory.PublicPipelines()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelines).To(HaveLen(1))
+			Expect(pipelines[0].Name()).To(Equal(publicPipeline.Name()))
+		})
+	})
+
+	Describe("PipelinesModifiedSince", func() {
+		var since time.Time
+
+		backdate := func() {
+			_, err := dbConn.Exec(`UPDATE pipelines SET last_updated = now() - '1 hour'::interval, config_updated = now() - '1 hour'::interval`)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = dbConn.QueryRow("SELECT now()").Scan(&since)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			backdate()
+		})
+
+		It("does not return pipelines left untouched since then", func() {
+			pipelines, err := pipelineFactory.PipelinesModifiedSince(since)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelines).To(BeEmpty())
+		})
+
+		It("returns pipelines whose state changed without bumping their config time", func() {
+			err := defaultPipeline.Pause("")
+			Expect(err).ToNot(HaveOccurred())
+
+			pipelines, err := pipelineFactory.PipelinesModifiedSince(since)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelines).To(HaveLen(1))
+			Expect(pipelines[0].ID()).To(Equal(defaultPipeline.ID()))
+			Expect(pipelines[0].Paused()).To(BeTrue())
+			Expect(pipelines[0].LastUpdated()).To(BeTemporally(">", since))
+			Expect(pipelines[0].ConfigUpdated()).ToNot(BeTemporally(">", since))
+		})
+
+		It("returns pipelines whose config changed", func() {
+			_, _, err := defaultTeam.SavePipeline(defaultPipeline.Name(), atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "some-job"},
+				},
+			}, defaultPipeline.ConfigVersion(), db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+
+			pipelines, err := pipelineFactory.PipelinesModifiedSince(since)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelines).To(HaveLen(1))
+			Expect(pipelines[0].ID()).To(Equal(defaultPipeline.ID()))
+			Expect(pipelines[0].ConfigUpdated()).To(BeTemporally(">", since))
+		})
+
+		It("returns pipelines changed shortly before then, as their change may have committed after", func() {
+			_, err := dbConn.Exec(`UPDATE pipelines SET last_updated = $1`, since.Add(-10*time.Second))
+			Expect(err).ToNot(HaveOccurred())
+
+			pipelines, err := pipelineFactory.PipelinesModifiedSince(since)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelines).To(HaveLen(1))
+			Expect(pipelines[0].ID()).To(Equal(defaultPipeline.ID()))
+		})
+
+		It("returns pipelines which were renamed", func() {
+			err := defaultPipeline.Rename("renamed-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+
+			pipelines, err := pipelineFactory.PipelinesModifiedSince(since)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelines).To(HaveLen(1))
+			Expect(pipelines[0].Name()).To(Equal("renamed-pipeline"))
+		})
+
+		It("returns pipelines which were moved to another team", func() {
+			_, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = defaultTeam.TransferPipeline(defaultPipeline.Name(), "some-other-team")
+			Expect(err).ToNot(HaveOccurred())
+
+			pipelines, err := pipelineFactory.PipelinesModifiedSince(since)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelines).To(HaveLen(1))
+			Expect(pipelines[0].TeamName()).To(Equal("some-other-team"))
+		})
+
+		It("returns pipelines which were reordered", func() {
+			otherPipeline, _, err := defaultTeam.SavePipeline("other-pipeline", atc.Config{}, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			backdate()
+
+			err = defaultTeam.OrderPipelines([]string{otherPipeline.Name(), defaultPipeline.Name()})
+			Expect(err).ToNot(HaveOccurred())
+
+			pipelines, err := pipelineFactory.PipelinesModifiedSince(since)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelines).To(HaveLen(2))
+		})
+	})
+
 	Describe("AllPipelines", func() {
 		var (
 			pipeline1 db.Pipeline
@@ -134,4 +293,28 @@ var _ = Describe("Pipeline Factory", func() {
 			Expect(pipelines[2].Name()).To(Equal(pipeline3.Name()))
 		})
 	})
+
+	Describe("PruneConfigVersions", func() {
+		It("keeps only the newest versions of each pipeline", func() {
+			var versions []db.ConfigVersion
+			for i := 0; i < 3; i++ {
+				versions = append(versions, defaultPipeline.ConfigVersion())
+
+				var err error
+				defaultPipeline, _, err = defaultTeam.SavePipeline(defaultPipeline.Name(), atc.Config{
+					Jobs: atc.JobConfigs{
+						{Name: fmt.Sprintf("job-%d", i)},
+					},
+				}, defaultPipeline.ConfigVersion(), db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			err := pipelineFactory.PruneConfigVersions(2)
+			Expect(err).ToNot(HaveOccurred())
+
+			kept, err := defaultPipeline.ListConfigVersions(0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kept).To(Equal([]db.ConfigVersion{defaultPipeline.ConfigVersion(), versions[2]}))
+		})
+	})
 })
@@ -0,0 +1,129 @@
+package db
+
+import "github.com/concourse/concourse/atc"
+
+// PipelineGraph describes the edges between the jobs and resources of a
+// pipeline, as needed to render it as a DAG.
+type PipelineGraph struct {
+	Inputs   []GraphInput
+	Outputs  []GraphOutput
+	JobEdges []JobEdge
+}
+
+// GraphInput is a resource -> job edge for a get step.
+type GraphInput struct {
+	Job      string
+	Name     string
+	Resource string
+	Trigger  bool
+	Passed   []string
+}
+
+// GraphOutput is a job -> resource edge for a put step.
+type GraphOutput struct {
+	Job      string
+	Name     string
+	Resource string
+}
+
+// JobEdge is a job -> job edge resulting from a passed constraint: versions of
+// Resource flow from the From job into the To job.
+type JobEdge struct {
+	From     string
+	To       string
+	Resource string
+}
+
+// BuildPipelineGraph computes the graph of a pipeline config. Edges are
+// returned in config order, and a job -> job edge is only returned once per
+// resource even if several inputs of the job carry the same constraint.
+func BuildPipelineGraph(config atc.Config) PipelineGraph {
+	graph := PipelineGraph{
+		Inputs:   []GraphInput{},
+		Outputs:  []GraphOutput{},
+		JobEdges: []JobEdge{},
+	}
+
+	seenEdges := map[JobEdge]bool{}
+
+	for _, job := range config.Jobs {
+		for _, input := range job.Inputs() {
+			graph.Inputs = append(graph.Inputs, GraphInput{
+				Job:      job.Name,
+				Name:     input.Name,
+				Resource: input.Resource,
+				Trigger:  input.Trigger,
+				Passed:   input.Passed,
+			})
+
+			for _, upstream := range input.Passed {
+				edge := JobEdge{
+					From:     upstream,
+					To:       job.Name,
+					Resource: input.Resource,
+				}
+
+				if seenEdges[edge] {
+					continue
+				}
+
+				seenEdges[edge] = true
+				graph.JobEdges = append(graph.JobEdges, edge)
+			}
+		}
+
+		for _, output := range job.Outputs() {
+			graph.Outputs = append(graph.Outputs, GraphOutput{
+				Job:      job.Name,
+				Name:     output.Name,
+				Resource: output.Resource,
+			})
+		}
+	}
+
+	return graph
+}
+
+type ResourceUsageKind string
+
+const (
+	ResourceUsageGet ResourceUsageKind = "get"
+	ResourceUsagePut ResourceUsageKind = "put"
+)
+
+// ResourceUsage is a job that gets or puts to a resource.
+type ResourceUsage struct {
+	Job  string
+	Kind ResourceUsageKind
+}
+
+// JobsUsingResource returns the jobs consuming the resource through a get
+// step followed by the jobs producing it through a put step. A job is only
+// listed once per kind even if it has several steps for the resource.
+func (graph PipelineGraph) JobsUsingResource(resource string) []ResourceUsage {
+	usages := []ResourceUsage{}
+	seen := map[ResourceUsage]bool{}
+
+	add := func(usage ResourceUsage) {
+		if seen[usage] {
+			return
+		}
+
+		seen[usage] = true
+		usages = append(usages, usage)
+	}
+
+	for _, input := range graph.Inputs {
+		if input.Resource == resource {
+			add(ResourceUsage{Job: input.Job, Kind: ResourceUsageGet})
+		}
+	}
+
+	for _, output := range graph.Outputs {
+		if output.Resource == resource {
+			add(ResourceUsage{Job: output.Job, Kind: ResourceUsagePut})
+		}
+	}
+
+	return usages
+}
@@ -0,0 +1,94 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildPipelineGraph", func() {
+	var config atc.Config
+
+	BeforeEach(func() {
+		config = atc.Config{
+			Resources: atc.ResourceConfigs{
+				{Name: "some-repo", Type: "git"},
+				{Name: "some-image", Type: "docker-image"},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "unit",
+					Plan: atc.PlanSequence{
+						{Get: "some-repo", Trigger: true},
+					},
+				},
+				{
+					Name: "build",
+					Plan: atc.PlanSequence{
+						{Get: "some-repo", Passed: []string{"unit"}, Trigger: true},
+						{Get: "other-repo", Resource: "some-repo", Passed: []string{"unit"}},
+						{Put: "some-image"},
+					},
+				},
+				{
+					Name: "deploy",
+					Plan: atc.PlanSequence{
+						{Get: "some-image", Passed: []string{"build"}},
+					},
+				},
+			},
+		}
+	})
+
+	It("returns the gets, puts and passed constraints as edges", func() {
+		graph := db.BuildPipelineGraph(config)
+
+		Expect(graph.Inputs).To(Equal([]db.GraphInput{
+			{Job: "unit", Name: "some-repo", Resource: "some-repo", Trigger: true},
+			{Job: "build", Name: "some-repo", Resource: "some-repo", Trigger: true, Passed: []string{"unit"}},
+			{Job: "build", Name: "other-repo", Resource: "some-repo", Passed: []string{"unit"}},
+			{Job: "deploy", Name: "some-image", Resource: "some-image", Passed: []string{"build"}},
+		}))
+
+		Expect(graph.Outputs).To(Equal([]db.GraphOutput{
+			{Job: "build", Name: "some-image", Resource: "some-image"},
+		}))
+
+		Expect(graph.JobEdges).To(Equal([]db.JobEdge{
+			{From: "unit", To: "build", Resource: "some-repo"},
+			{From: "build", To: "deploy", Resource: "some-image"},
+		}))
+	})
+
+	Describe("JobsUsingResource", func() {
+		It("returns the jobs getting and putting to the resource once per kind", func() {
+			graph := db.BuildPipelineGraph(config)
+
+			Expect(graph.JobsUsingResource("some-repo")).To(Equal([]db.ResourceUsage{
+				{Job: "unit", Kind: db.ResourceUsageGet},
+				{Job: "build", Kind: db.ResourceUsageGet},
+			}))
+
+			Expect(graph.JobsUsingResource("some-image")).To(Equal([]db.ResourceUsage{
+				{Job: "deploy", Kind: db.ResourceUsageGet},
+				{Job: "build", Kind: db.ResourceUsagePut},
+			}))
+		})
+
+		It("returns nothing for an unused resource", func() {
+			graph := db.BuildPipelineGraph(config)
+
+			Expect(graph.JobsUsingResource("bogus-resource")).To(BeEmpty())
+		})
+	})
+
+	It("returns empty edges for an empty config", func() {
+		graph := db.BuildPipelineGraph(atc.Config{})
+
+		Expect(graph.Inputs).To(BeEmpty())
+		Expect(graph.Outputs).To(BeEmpty())
+		Expect(graph.JobEdges).To(BeEmpty())
+	})
+})
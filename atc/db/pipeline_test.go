@@ -1,6 +1,9 @@
 package db_test
 
 import (
+	"context"
+	"fmt"
+	"os"
 	"strconv"
 	"time"
 
@@ -149,7 +152,7 @@ var _ = Describe("Pipeline", func() {
 
 		Context("when the pipeline is unpaused", func() {
 			BeforeEach(func() {
-				Expect(pipeline.Unpause()).To(Succeed())
+				Expect(pipeline.Unpause("")).To(Succeed())
 			})
 
 			It("returns the pipeline is paused", func() {
@@ -159,7 +162,7 @@ var _ = Describe("Pipeline", func() {
 
 		Context("when the pipeline is paused", func() {
 			BeforeEach(func() {
-				Expect(pipeline.Pause()).To(Succeed())
+				Expect(pipeline.Pause("")).To(Succeed())
 			})
 
 			It("returns the pipeline is paused", func() {
@@ -170,7 +173,7 @@ var _ = Describe("Pipeline", func() {
 
 	Describe("Pause", func() {
 		JustBeforeEach(func() {
-			Expect(pipeline.Pause()).To(Succeed())
+			Expect(pipeline.Pause("")).To(Succeed())
 
 			found, err := pipeline.Reload()
 			Expect(err).ToNot(HaveOccurred())
@@ -179,7 +182,7 @@ var _ = Describe("Pipeline", func() {
 
 		Context("when the pipeline is unpaused", func() {
 			BeforeEach(func() {
-				Expect(pipeline.Unpause()).To(Succeed())
+				Expect(pipeline.Unpause("")).To(Succeed())
 			})
 
 			It("pauses the pipeline", func() {
@@ -190,7 +193,7 @@ var _ = Describe("Pipeline", func() {
 
 	Describe("Unpause", func() {
 		JustBeforeEach(func() {
-			Expect(pipeline.Unpause()).To(Succeed())
+			Expect(pipeline.Unpause("")).To(Succeed())
 
 			found, err := pipeline.Reload()
 			Expect(err).ToNot(HaveOccurred())
@@ -199,7 +202,7 @@ var _ = Describe("Pipeline", func() {
 
 		Context("when the pipeline is paused", func() {
 			BeforeEach(func() {
-				Expect(pipeline.Pause()).To(Succeed())
+				Expect(pipeline.Pause("")).To(Succeed())
 			})
 
 			It("unpauses the pipeline", func() {
@@ -208,6 +211,299 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("PauseHistory", func() {
+		It("starts out empty", func() {
+			history, err := pipeline.PauseHistory()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(BeEmpty())
+		})
+
+		It("records each change of the paused state with its actor", func() {
+			Expect(pipeline.Pause("some-user")).To(Succeed())
+			Expect(pipeline.Pause("some-other-user")).To(Succeed())
+			Expect(pipeline.Unpause("")).To(Succeed())
+
+			history, err := pipeline.PauseHistory()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(2))
+
+			Expect(history[0].Paused).To(BeTrue())
+			Expect(history[0].Actor).To(Equal("some-user"))
+			Expect(history[0].Time).ToNot(BeZero())
+
+			Expect(history[1].Paused).To(BeFalse())
+			Expect(history[1].Actor).To(BeEmpty())
+			Expect(history[1].Time).ToNot(BeZero())
+		})
+	})
+
+	Describe("Archive", func() {
+		JustBeforeEach(func() {
+			Expect(pipeline.Archive()).To(Succeed())
+
+			found, err := pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("archives the pipeline", func() {
+			Expect(pipeline.Archived()).To(BeTrue())
+		})
+
+		It("can still be found by name", func() {
+			archived, found, err := team.Pipeline(pipeline.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(archived.Archived()).To(BeTrue())
+		})
+
+		Context("when the pipeline is unarchived", func() {
+			It("unarchives the pipeline", func() {
+				Expect(pipeline.Unarchive()).To(Succeed())
+
+				found, err := pipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(pipeline.Archived()).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("config history", func() {
+		var (
+			firstVersion  db.ConfigVersion
+			secondVersion db.ConfigVersion
+			newConfig     atc.Config
+		)
+
+		BeforeEach(func() {
+			firstVersion = pipeline.ConfigVersion()
+
+			newConfig = pipelineConfig
+			newConfig.Jobs = append(atc.JobConfigs{}, pipelineConfig.Jobs...)
+			newConfig.Jobs = append(newConfig.Jobs, atc.JobConfig{Name: "new-job"})
+
+			var err error
+			pipeline, _, err = team.SavePipeline("fake-pipeline", newConfig, firstVersion, db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+
+			secondVersion = pipeline.ConfigVersion()
+		})
+
+		It("keeps each saved config by version", func() {
+			config, found, err := pipeline.GetConfigVersion(firstVersion)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(config.Jobs).To(HaveLen(len(pipelineConfig.Jobs)))
+
+			config, found, err = pipeline.GetConfigVersion(secondVersion)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(config.Jobs).To(HaveLen(len(newConfig.Jobs)))
+		})
+
+		It("does not find unknown versions", func() {
+			_, found, err := pipeline.GetConfigVersion(db.ConfigVersion(0))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("lists the versions newest first", func() {
+			versions, err := pipeline.ListConfigVersions(0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(Equal([]db.ConfigVersion{secondVersion, firstVersion}))
+
+			versions, err = pipeline.ListConfigVersions(1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(Equal([]db.ConfigVersion{secondVersion}))
+		})
+
+		It("diffs two versions", func() {
+			diff, err := pipeline.ConfigDiff(firstVersion, secondVersion)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff.AddedJobs).To(Equal([]atc.JobConfig{{Name: "new-job"}}))
+			Expect(diff.RemovedJobs).To(BeEmpty())
+			Expect(diff.ModifiedJobs).To(BeEmpty())
+		})
+
+		It("fails to diff a version that is not in the history", func() {
+			_, err := pipeline.ConfigDiff(db.ConfigVersion(0), secondVersion)
+			Expect(err).To(Equal(db.ErrConfigVersionNotFound{Version: db.ConfigVersion(0)}))
+		})
+
+		Describe("RevertConfig", func() {
+			var (
+				validConfig  atc.Config
+				validVersion db.ConfigVersion
+			)
+
+			BeforeEach(func() {
+				validConfig = atc.Config{
+					Resources: atc.ResourceConfigs{
+						{Name: "some-resource", Type: "some-type"},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							Plan: atc.PlanSequence{{Get: "some-resource"}},
+						},
+					},
+				}
+
+				var err error
+				pipeline, _, err = team.SavePipeline("fake-pipeline", validConfig, secondVersion, db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+				validVersion = pipeline.ConfigVersion()
+
+				pipeline, _, err = team.SavePipeline("fake-pipeline", atc.Config{
+					Jobs: atc.JobConfigs{{Name: "some-other-job"}},
+				}, validVersion, db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("saves the old config as a new version", func() {
+				currentVersion := pipeline.ConfigVersion()
+
+				newVersion, err := pipeline.RevertConfig(validVersion)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newVersion).To(BeNumerically(">", currentVersion))
+				Expect(pipeline.ConfigVersion()).To(Equal(newVersion))
+
+				diff, err := pipeline.ConfigDiff(validVersion, newVersion)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(diff.IsEmpty()).To(BeTrue())
+
+				_, found, err := pipeline.Job("some-other-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("fails when the version is not in the history", func() {
+				_, err := pipeline.RevertConfig(db.ConfigVersion(0))
+				Expect(err).To(Equal(db.ErrConfigVersionNotFound{Version: db.ConfigVersion(0)}))
+			})
+
+			It("fails when the old config does not pass validation", func() {
+				_, err := pipeline.RevertConfig(firstVersion)
+				Expect(err).To(BeAssignableToTypeOf(db.ErrInvalidConfig{}))
+				Expect(err.(db.ErrInvalidConfig).Errors).ToNot(BeEmpty())
+			})
+
+			Context("when the pipeline has been saved since it was loaded", func() {
+				BeforeEach(func() {
+					_, _, err := team.SavePipeline("fake-pipeline", validConfig, pipeline.ConfigVersion(), db.PipelineNoChange)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("reverts the latest version", func() {
+					latest, found, err := team.Pipeline("fake-pipeline")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					newVersion, err := pipeline.RevertConfig(validVersion)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(newVersion).To(BeNumerically(">", latest.ConfigVersion()))
+				})
+			})
+		})
+	})
+
+	Describe("targeted config updates", func() {
+		var validPipeline db.Pipeline
+
+		BeforeEach(func() {
+			var err error
+			validPipeline, _, err = team.SavePipeline("valid-pipeline", atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "some-resource", Type: "some-type", Source: atc.Source{"uri": "some-uri"}},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						Plan: atc.PlanSequence{{Get: "some-resource"}},
+					},
+				},
+			}, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Describe("UpdateResourceConfig", func() {
+			It("replaces the source and bumps the version", func() {
+				oldVersion := validPipeline.ConfigVersion()
+
+				newVersion, err := validPipeline.UpdateResourceConfig("some-resource", atc.Source{"uri": "some-other-uri"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newVersion).To(BeNumerically(">", oldVersion))
+
+				diff, err := validPipeline.ConfigDiff(oldVersion, newVersion)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(diff.ModifiedResources).To(HaveLen(1))
+				Expect(diff.ModifiedResources[0].After.Source).To(Equal(atc.Source{"uri": "some-other-uri"}))
+				Expect(diff.ModifiedJobs).To(BeEmpty())
+			})
+
+			It("fails for an unknown resource", func() {
+				_, err := validPipeline.UpdateResourceConfig("bogus-resource", atc.Source{})
+				Expect(err).To(Equal(db.ErrResourceNotFound{Name: "bogus-resource"}))
+			})
+
+			It("applies to the latest config when the pipeline was loaded before another save", func() {
+				stale, found, err := team.Pipeline("valid-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, err = validPipeline.UpdateJob("some-job", atc.JobConfig{
+					Name:   "some-job",
+					Serial: true,
+					Plan:   atc.PlanSequence{{Get: "some-resource"}},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				newVersion, err := stale.UpdateResourceConfig("some-resource", atc.Source{"uri": "some-other-uri"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newVersion).To(BeNumerically(">", validPipeline.ConfigVersion()))
+
+				config, found, err := stale.GetConfigVersion(newVersion)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(config.Resources[0].Source).To(Equal(atc.Source{"uri": "some-other-uri"}))
+				Expect(config.Jobs[0].Serial).To(BeTrue())
+			})
+		})
+
+		Describe("UpdateJob", func() {
+			It("replaces the job config and bumps the version", func() {
+				oldVersion := validPipeline.ConfigVersion()
+
+				newVersion, err := validPipeline.UpdateJob("some-job", atc.JobConfig{
+					Name:   "some-job",
+					Serial: true,
+					Plan:   atc.PlanSequence{{Get: "some-resource"}},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newVersion).To(BeNumerically(">", oldVersion))
+
+				job, found, err := validPipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(job.Config().Serial).To(BeTrue())
+			})
+
+			It("fails for an unknown job", func() {
+				_, err := validPipeline.UpdateJob("bogus-job", atc.JobConfig{Name: "bogus-job"})
+				Expect(err).To(Equal(db.ErrJobNotFound{Name: "bogus-job"}))
+			})
+
+			It("fails when the new job config is invalid", func() {
+				_, err := validPipeline.UpdateJob("some-job", atc.JobConfig{
+					Name: "some-job",
+					Plan: atc.PlanSequence{{Get: "bogus-resource"}},
+				})
+				Expect(err).To(BeAssignableToTypeOf(db.ErrInvalidConfig{}))
+			})
+		})
+	})
+
 	Describe("Rename", func() {
 		JustBeforeEach(func() {
 			Expect(pipeline.Rename("oopsies")).To(Succeed())
@@ -219,6 +515,31 @@ var _ = Describe("Pipeline", func() {
 			Expect(found).To(BeTrue())
 			Expect(err).ToNot(HaveOccurred())
 		})
+
+		It("keeps the pipeline's id and builds", func() {
+			build, err := job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			renamed, found, err := team.Pipeline("oopsies")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(renamed.ID()).To(Equal(pipeline.ID()))
+
+			found, err = build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.PipelineName()).To(Equal("oopsies"))
+		})
+
+		Context("when the team already has a pipeline with the new name", func() {
+			It("returns an error", func() {
+				_, _, err := team.SavePipeline("taken", pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = pipeline.Rename("taken")
+				Expect(err).To(Equal(db.ErrPipelineNameTaken{Name: "taken"}))
+			})
+		})
 	})
 
 	Describe("Resource Config Versions", func() {
@@ -550,7 +871,7 @@ var _ = Describe("Pipeline", func() {
 			}))
 
 			By("including outputs of successful builds")
-			build1DB, err := aJob.CreateBuild()
+			build1DB, err := aJob.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = build1DB.SaveOutput(logger, "some-type", atc.Source{"source-config": "some-value"}, creds.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-resource")
@@ -597,7 +918,7 @@ var _ = Describe("Pipeline", func() {
 			}))
 
 			By("not including outputs of failed builds")
-			build2DB, err := aJob.CreateBuild()
+			build2DB, err := aJob.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = build2DB.SaveOutput(logger, "some-type", atc.Source{"source-config": "some-value"}, creds.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-resource")
@@ -646,7 +967,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			otherPipelineBuild, err := anotherJob.CreateBuild()
+			otherPipelineBuild, err := anotherJob.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = otherPipelineBuild.SaveOutput(logger, "some-type", atc.Source{"other-source-config": "some-other-value"}, creds.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-other-resource")
@@ -695,7 +1016,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build1DB, err = aJob.CreateBuild()
+			build1DB, err = aJob.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = build1DB.UseInputs([]db.BuildInput{
@@ -839,7 +1160,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = resourceConfigScope.SaveVersions([]atc.Version{
@@ -870,7 +1191,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 
 				beforeVR, found, err := resourceConfigScope.LatestVersion()
@@ -976,7 +1297,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build1, err := aJob.CreateBuild()
+				build1, err := aJob.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "disabled"}})
@@ -1121,7 +1442,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 
 			By("populating build inputs")
@@ -1159,94 +1480,323 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
-	Describe("GetPendingBuilds/GetAllPendingBuilds", func() {
-		Context("when a build is created", func() {
-			BeforeEach(func() {
-				_, err := job.CreateBuild()
-				Expect(err).ToNot(HaveOccurred())
-			})
-
-			It("returns the build", func() {
-				pendingBuildsForJob, err := job.GetPendingBuilds()
-				Expect(err).ToNot(HaveOccurred())
-				Expect(pendingBuildsForJob).To(HaveLen(1))
-
-				pendingBuilds, err := pipeline.GetAllPendingBuilds()
-				Expect(err).ToNot(HaveOccurred())
-				Expect(pendingBuilds).To(HaveLen(1))
-				Expect(pendingBuilds["job-name"]).ToNot(BeNil())
-			})
-		})
-	})
+	Describe("BuildsWithStatus", func() {
+		var (
+			startedBuild   db.Build
+			erroredBuild   db.Build
+			succeededBuild db.Build
+		)
 
-	Describe("VersionsDB caching", func() {
-		var otherPipeline db.Pipeline
 		BeforeEach(func() {
-			otherPipelineConfig := atc.Config{
-				Resources: atc.ResourceConfigs{
-					{
-						Name: "some-other-resource",
-						Type: "some-type",
-						Source: atc.Source{
-							"some-source": "some-other-value",
-						},
-					},
-				},
-				Jobs: atc.JobConfigs{
-					{
-						Name: "some-job",
-					},
-				},
-			}
 			var err error
-			otherPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused)
+			erroredBuild, err = job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
-		})
 
-		Context("when build outputs are added", func() {
-			var build db.Build
-			var savedVR db.ResourceConfigVersion
-			var resourceConfigScope db.ResourceConfigScope
-			var savedResource db.Resource
+			succeededBuild, err = job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
 
-			BeforeEach(func() {
-				var err error
-				job, found, err := pipeline.Job("job-name")
-				Expect(err).ToNot(HaveOccurred())
-				Expect(found).To(BeTrue())
+			startedBuild, err = job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
 
-				build, err = job.CreateBuild()
-				Expect(err).ToNot(HaveOccurred())
+			_, err = job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
 
-				savedResource, _, err = pipeline.Resource("some-resource")
+			for _, b := range []db.Build{erroredBuild, succeededBuild, startedBuild} {
+				started, err := b.Start(atc.Plan{})
 				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeTrue())
+			}
 
-				resourceConfigScope, err = savedResource.SetResourceConfig(logger, atc.Source{"some": "source"}, creds.VersionedResourceTypes{})
-				Expect(err).ToNot(HaveOccurred())
+			err = erroredBuild.Finish(db.BuildStatusErrored)
+			Expect(err).ToNot(HaveOccurred())
 
-				err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "1"}})
-				Expect(err).ToNot(HaveOccurred())
+			err = succeededBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+		})
 
-				savedVR, found, err = resourceConfigScope.LatestVersion()
-				Expect(err).ToNot(HaveOccurred())
-				Expect(found).To(BeTrue())
-			})
+		It("returns builds in any of the given statuses, most recently started first", func() {
+			builds, err := pipeline.BuildsWithStatus([]db.BuildStatus{db.BuildStatusStarted, db.BuildStatusErrored}, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(2))
+			Expect(builds[0].ID()).To(Equal(startedBuild.ID()))
+			Expect(builds[1].ID()).To(Equal(erroredBuild.ID()))
+		})
 
-			It("will cache VersionsDB if no change has occured", func() {
-				err := build.SaveOutput(logger, "some-type", atc.Source{"some": "source"}, creds.VersionedResourceTypes{}, atc.Version(savedVR.Version()), nil, "some-output-name", "some-resource")
-				Expect(err).ToNot(HaveOccurred())
+		It("limits the number of builds returned", func() {
+			builds, err := pipeline.BuildsWithStatus([]db.BuildStatus{db.BuildStatusStarted, db.BuildStatusErrored}, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(startedBuild.ID()))
+		})
 
-				versionsDB, err := pipeline.LoadVersionsDB()
-				Expect(err).ToNot(HaveOccurred())
+		It("does not return builds of other pipelines", func() {
+			otherPipeline, _, err := team.SavePipeline("other-pipeline", pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
 
-				cachedVersionsDB, err := pipeline.LoadVersionsDB()
-				Expect(err).ToNot(HaveOccurred())
-				Expect(versionsDB == cachedVersionsDB).To(BeTrue(), "Expected VersionsDB to be the same object")
-			})
+			builds, err := otherPipeline.BuildsWithStatus([]db.BuildStatus{db.BuildStatusStarted}, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+	})
 
-			It("will not cache VersionsDB if a build has completed", func() {
-				versionsDB, err := pipeline.LoadVersionsDB()
-				Expect(err).ToNot(HaveOccurred())
+	Describe("CreateJobBuild", func() {
+		It("creates a pending build of the job with the given trigger", func() {
+			build, err := pipeline.CreateJobBuild("job-name", db.BuildTriggerResource)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.JobName()).To(Equal("job-name"))
+			Expect(build.Status()).To(Equal(db.BuildStatusPending))
+			Expect(build.TriggerSource()).To(Equal(db.BuildTriggerResource))
+			Expect(build.IsManuallyTriggered()).To(BeFalse())
+
+			build, err = pipeline.CreateJobBuild("job-name", db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.TriggerSource()).To(Equal(db.BuildTriggerManual))
+			Expect(build.IsManuallyTriggered()).To(BeTrue())
+		})
+
+		It("returns an error when the job does not exist", func() {
+			_, err := pipeline.CreateJobBuild("bogus-job", db.BuildTriggerManual)
+			Expect(err).To(Equal(db.ErrJobNotFound{Name: "bogus-job"}))
+		})
+	})
+
+	Describe("BuildsWithTrigger", func() {
+		var (
+			manualBuild    db.Build
+			scheduledBuild db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			manualBuild, err = job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			started, err := manualBuild.Start(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			err = job.EnsurePendingBuildExists(db.BuildTriggerResource)
+			Expect(err).ToNot(HaveOccurred())
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(1))
+			scheduledBuild = pendingBuilds[0]
+		})
+
+		It("records what triggered each build", func() {
+			Expect(manualBuild.TriggerSource()).To(Equal(db.BuildTriggerManual))
+			Expect(scheduledBuild.TriggerSource()).To(Equal(db.BuildTriggerResource))
+		})
+
+		It("returns the builds created by the given trigger", func() {
+			builds, err := pipeline.BuildsWithTrigger(db.BuildTriggerManual, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(manualBuild.ID()))
+
+			builds, err = pipeline.BuildsWithTrigger(db.BuildTriggerResource, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(scheduledBuild.ID()))
+
+			builds, err = pipeline.BuildsWithTrigger(db.BuildTriggerRerun, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+	})
+
+	Describe("RunningBuildCount", func() {
+		BeforeEach(func() {
+			startedBuild, err := job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			started, err := startedBuild.Start(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			_, err = job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			oneOffBuild, err := team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			started, err = oneOffBuild.Start(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+		})
+
+		It("counts only the pipeline's started builds", func() {
+			count, err := pipeline.RunningBuildCount()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(1))
+		})
+	})
+
+	Describe("AbortAllRunningBuilds", func() {
+		var (
+			pendingBuild   db.Build
+			startedBuild   db.Build
+			succeededBuild db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			succeededBuild, err = job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = succeededBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			startedBuild, err = job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			started, err := startedBuild.Start(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			pendingBuild, err = job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("aborts the pipeline's pending and started builds", func() {
+			abortedIDs, err := pipeline.AbortAllRunningBuilds("pipeline destroyed")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(abortedIDs).To(Equal([]int{startedBuild.ID(), pendingBuild.ID()}))
+
+			for _, build := range []db.Build{startedBuild, pendingBuild} {
+				found, err := build.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.IsAborted()).To(BeTrue())
+				Expect(build.AbortReason()).To(Equal("pipeline destroyed"))
+			}
+
+			found, err := succeededBuild.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(succeededBuild.IsAborted()).To(BeFalse())
+		})
+
+		It("saves an abort event for each build", func() {
+			_, err := pipeline.AbortAllRunningBuilds("pipeline destroyed")
+			Expect(err).ToNot(HaveOccurred())
+
+			events, err := startedBuild.Events(0)
+			Expect(err).ToNot(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusStarted,
+				Time:   startedBuild.StartTime().Unix(),
+			})))
+			Expect(events.Next()).To(Equal(envelope(event.Error{
+				Message: "aborted: pipeline destroyed",
+			})))
+		})
+
+		It("notifies whoever is running each build", func() {
+			notifier, err := startedBuild.AbortNotifier()
+			Expect(err).ToNot(HaveOccurred())
+
+			defer notifier.Close()
+
+			_, err = pipeline.AbortAllRunningBuilds("pipeline destroyed")
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(notifier.Notify()).Should(Receive())
+		})
+	})
+
+	Describe("GetPendingBuilds/GetAllPendingBuilds", func() {
+		Context("when a build is created", func() {
+			BeforeEach(func() {
+				_, err := job.CreateBuild(db.BuildTriggerManual)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the build", func() {
+				pendingBuildsForJob, err := job.GetPendingBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pendingBuildsForJob).To(HaveLen(1))
+
+				pendingBuilds, err := pipeline.GetAllPendingBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+				Expect(pendingBuilds["job-name"]).ToNot(BeNil())
+			})
+		})
+	})
+
+	Describe("VersionsDB caching", func() {
+		var otherPipeline db.Pipeline
+		BeforeEach(func() {
+			otherPipelineConfig := atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name: "some-other-resource",
+						Type: "some-type",
+						Source: atc.Source{
+							"some-source": "some-other-value",
+						},
+					},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+					},
+				},
+			}
+			var err error
+			otherPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when build outputs are added", func() {
+			var build db.Build
+			var savedVR db.ResourceConfigVersion
+			var resourceConfigScope db.ResourceConfigScope
+			var savedResource db.Resource
+
+			BeforeEach(func() {
+				var err error
+				job, found, err := pipeline.Job("job-name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err = job.CreateBuild(db.BuildTriggerManual)
+				Expect(err).ToNot(HaveOccurred())
+
+				savedResource, _, err = pipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+
+				resourceConfigScope, err = savedResource.SetResourceConfig(logger, atc.Source{"some": "source"}, creds.VersionedResourceTypes{})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "1"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				savedVR, found, err = resourceConfigScope.LatestVersion()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("will cache VersionsDB if no change has occured", func() {
+				err := build.SaveOutput(logger, "some-type", atc.Source{"some": "source"}, creds.VersionedResourceTypes{}, atc.Version(savedVR.Version()), nil, "some-output-name", "some-resource")
+				Expect(err).ToNot(HaveOccurred())
+
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+
+				cachedVersionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versionsDB == cachedVersionsDB).To(BeTrue(), "Expected VersionsDB to be the same object")
+			})
+
+			It("will not cache VersionsDB if a build has completed", func() {
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
 
 				err = build.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
@@ -1288,7 +1838,7 @@ var _ = Describe("Pipeline", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					otherBuild, err := job.CreateBuild()
+					otherBuild, err := job.CreateBuild(db.BuildTriggerManual)
 					Expect(err).ToNot(HaveOccurred())
 
 					otherSavedResource, _, err := otherPipeline.Resource("some-other-resource")
@@ -1373,7 +1923,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(created).To(BeTrue())
 
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = build.UseInputs([]db.BuildInput{{Name: "some-resource", Version: atc.Version{"version": "1"}, ResourceID: resource.ID()}})
@@ -1409,114 +1959,342 @@ var _ = Describe("Pipeline", func() {
 				})
 			})
 		})
+
+		Context("when the context is already done", func() {
+			It("returns the context's error", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				_, err := pipeline.LoadVersionsDBContext(ctx)
+				Expect(err).To(Equal(context.Canceled))
+			})
+		})
 	})
 
-	Describe("Dashboard", func() {
-		It("returns a Dashboard object with a DashboardJob corresponding to each configured job", func() {
-			job, found, err := pipeline.Job("job-name")
+	Describe("JobStatus", func() {
+		It("returns an empty status for a job with no builds", func() {
+			status, found, err := pipeline.JobStatus("job-name")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
+			Expect(status).To(Equal(db.JobStatus{}))
+		})
 
-			err = job.UpdateFirstLoggedBuildID(57)
+		It("does not find unknown jobs", func() {
+			_, found, err := pipeline.JobStatus("bogus-job")
 			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
 
-			otherJob, found, err := pipeline.Job("some-other-job")
+		It("returns the next, finished and transition builds", func() {
+			finishedBuild, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(found).To(BeTrue())
+			Expect(finishedBuild.Finish(db.BuildStatusSucceeded)).To(Succeed())
 
-			aJob, found, err := pipeline.Job("a-job")
+			nextBuild, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(found).To(BeTrue())
 
-			sharedJob, found, err := pipeline.Job("shared-job")
+			status, found, err := pipeline.JobStatus("job-name")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
+			Expect(status.NextBuild.ID()).To(Equal(nextBuild.ID()))
+			Expect(status.FinishedBuild.ID()).To(Equal(finishedBuild.ID()))
+			Expect(status.TransitionBuild.ID()).To(Equal(finishedBuild.ID()))
+		})
+	})
 
-			randomJob, found, err := pipeline.Job("random-job")
+	Describe("AcquireSerialLock", func() {
+		It("can only be held once at a time", func() {
+			serialLock, acquired, err := pipeline.AcquireSerialLock(logger, "job-name")
 			Expect(err).ToNot(HaveOccurred())
-			Expect(found).To(BeTrue())
+			Expect(acquired).To(BeTrue())
 
-			otherSerialGroupJob, found, err := pipeline.Job("other-serial-group-job")
+			_, acquired, err = pipeline.AcquireSerialLock(logger, "job-name")
 			Expect(err).ToNot(HaveOccurred())
-			Expect(found).To(BeTrue())
+			Expect(acquired).To(BeFalse())
 
-			differentSerialGroupJob, found, err := pipeline.Job("different-serial-group-job")
+			otherLock, acquired, err := pipeline.AcquireSerialLock(logger, "some-other-job")
 			Expect(err).ToNot(HaveOccurred())
-			Expect(found).To(BeTrue())
+			Expect(acquired).To(BeTrue())
 
-			By("returning jobs with no builds")
-			actualDashboard, err := pipeline.Dashboard()
+			err = otherLock.Release()
 			Expect(err).ToNot(HaveOccurred())
 
-			Expect(actualDashboard[0].Job.Name()).To(Equal(job.Name()))
-			Expect(actualDashboard[1].Job.Name()).To(Equal(otherJob.Name()))
-			Expect(actualDashboard[2].Job.Name()).To(Equal(aJob.Name()))
-			Expect(actualDashboard[3].Job.Name()).To(Equal(sharedJob.Name()))
-			Expect(actualDashboard[4].Job.Name()).To(Equal(randomJob.Name()))
-			Expect(actualDashboard[5].Job.Name()).To(Equal(otherSerialGroupJob.Name()))
-			Expect(actualDashboard[6].Job.Name()).To(Equal(differentSerialGroupJob.Name()))
-
-			By("returning a job's most recent pending build if there are no running builds")
-			job, found, err = pipeline.Job("job-name")
+			err = serialLock.Release()
 			Expect(err).ToNot(HaveOccurred())
-			Expect(found).To(BeTrue())
 
-			firstJobBuild, err := job.CreateBuild()
+			serialLock, acquired, err = pipeline.AcquireSerialLock(logger, "job-name")
 			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeTrue())
 
-			actualDashboard, err = pipeline.Dashboard()
+			err = serialLock.Release()
 			Expect(err).ToNot(HaveOccurred())
+		})
 
-			Expect(actualDashboard[0].Job.Name()).To(Equal(job.Name()))
-			Expect(actualDashboard[0].NextBuild.ID()).To(Equal(firstJobBuild.ID()))
+		It("returns an error when the job does not exist", func() {
+			_, _, err := pipeline.AcquireSerialLock(logger, "bogus-job")
+			Expect(err).To(Equal(db.ErrJobNotFound{Name: "bogus-job"}))
+		})
+	})
 
-			By("returning a job's most recent started build")
-			found, err = firstJobBuild.Start(atc.Plan{ID: "some-id"})
+	Describe("AcquireSerialGroupLock", func() {
+		It("can not be held by two sets of overlapping groups at once", func() {
+			groupLock, acquired, err := pipeline.AcquireSerialGroupLock(logger, []string{"prod", "staging"})
 			Expect(err).ToNot(HaveOccurred())
-			Expect(found).To(BeTrue())
+			Expect(acquired).To(BeTrue())
 
-			found, err = firstJobBuild.Reload()
+			_, acquired, err = pipeline.AcquireSerialGroupLock(logger, []string{"staging"})
 			Expect(err).ToNot(HaveOccurred())
-			Expect(found).To(BeTrue())
+			Expect(acquired).To(BeFalse())
 
-			actualDashboard, err = pipeline.Dashboard()
+			otherLock, acquired, err := pipeline.AcquireSerialGroupLock(logger, []string{"dev"})
 			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeTrue())
 
-			Expect(actualDashboard[0].Job.Name()).To(Equal(job.Name()))
-			Expect(actualDashboard[0].NextBuild.ID()).To(Equal(firstJobBuild.ID()))
-			Expect(actualDashboard[0].NextBuild.Status()).To(Equal(db.BuildStatusStarted))
-			Expect(actualDashboard[0].NextBuild.Schema()).To(Equal("exec.v2"))
-			Expect(actualDashboard[0].NextBuild.PrivatePlan()).To(Equal(atc.Plan{ID: "some-id"}))
+			err = otherLock.Release()
+			Expect(err).ToNot(HaveOccurred())
 
-			By("returning a job's most recent started build even if there is a newer pending build")
-			job, found, err = pipeline.Job("job-name")
+			err = groupLock.Release()
 			Expect(err).ToNot(HaveOccurred())
-			Expect(found).To(BeTrue())
 
-			secondJobBuild, err := job.CreateBuild()
+			groupLock, acquired, err = pipeline.AcquireSerialGroupLock(logger, []string{"staging"})
 			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeTrue())
 
-			actualDashboard, err = pipeline.Dashboard()
+			err = groupLock.Release()
 			Expect(err).ToNot(HaveOccurred())
+		})
+	})
 
-			Expect(actualDashboard[0].Job.Name()).To(Equal(job.Name()))
-			Expect(actualDashboard[0].NextBuild.ID()).To(Equal(firstJobBuild.ID()))
+	Describe("LeaseResourceChecking", func() {
+		It("can only be held by one at a time until it is broken", func() {
+			lease, acquired, err := pipeline.LeaseResourceChecking("some-resource", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeTrue())
 
-			By("returning a job's most recent finished build")
-			err = firstJobBuild.Finish(db.BuildStatusSucceeded)
+			_, acquired, err = pipeline.LeaseResourceChecking("some-resource", time.Minute)
 			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeFalse())
 
-			err = secondJobBuild.Finish(db.BuildStatusSucceeded)
+			err = lease.Renew()
 			Expect(err).ToNot(HaveOccurred())
 
-			found, err = secondJobBuild.Reload()
+			err = lease.Break()
 			Expect(err).ToNot(HaveOccurred())
-			Expect(found).To(BeTrue())
 
-			actualDashboard, err = pipeline.Dashboard()
+			lease, acquired, err = pipeline.LeaseResourceChecking("some-resource", time.Minute)
 			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeTrue())
 
-			Expect(actualDashboard[0].Job.Name()).To(Equal(job.Name()))
+			err = lease.Break()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("can be taken over once it expires", func() {
+			lease, acquired, err := pipeline.LeaseResourceChecking("some-resource", time.Second)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			Eventually(func() bool {
+				_, acquired, err := pipeline.LeaseResourceChecking("some-resource", time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+				return acquired
+			}, 3*time.Second).Should(BeTrue())
+
+			Expect(lease.Renew()).To(Equal(db.ErrLeaseLost))
+			Expect(lease.Break()).To(Equal(db.ErrLeaseLost))
+		})
+
+		It("returns an error when the resource does not exist", func() {
+			_, _, err := pipeline.LeaseResourceChecking("bogus-resource", time.Minute)
+			Expect(err).To(Equal(db.ErrResourceNotFound{Name: "bogus-resource"}))
+		})
+	})
+
+	Describe("LeaseScheduling", func() {
+		It("can only be held by one at a time", func() {
+			lease, acquired, err := pipeline.LeaseScheduling(time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			_, acquired, err = pipeline.LeaseScheduling(time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeFalse())
+
+			err = lease.Break()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, acquired, err = pipeline.LeaseScheduling(time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+		})
+
+		It("exposes the holder of the lease until it is broken", func() {
+			_, held, err := pipeline.SchedulingLeaseHolder()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(held).To(BeFalse())
+
+			lease, acquired, err := pipeline.LeaseScheduling(time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+
+			holder, held, err := pipeline.SchedulingLeaseHolder()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(held).To(BeTrue())
+			Expect(holder).To(ContainSubstring(fmt.Sprintf(":%d", os.Getpid())))
+
+			err = lease.Break()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, held, err = pipeline.SchedulingLeaseHolder()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(held).To(BeFalse())
+		})
+	})
+
+	Describe("LatestSuccessfulBuilds", func() {
+		It("returns the latest succeeded build of each job that has one", func() {
+			job, found, err := pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			otherJob, found, err := pipeline.Job("some-other-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			firstSuccess, err := job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+			err = firstSuccess.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			latestSuccess, err := job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+			err = latestSuccess.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			failure, err := job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+			err = failure.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherFailure, err := otherJob.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+			err = otherFailure.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+
+			builds, err := pipeline.LatestSuccessfulBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds).To(HaveKey("job-name"))
+			Expect(builds["job-name"].ID()).To(Equal(latestSuccess.ID()))
+		})
+	})
+
+	Describe("Dashboard", func() {
+		It("returns a Dashboard object with a DashboardJob corresponding to each configured job", func() {
+			job, found, err := pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = job.UpdateFirstLoggedBuildID(57)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherJob, found, err := pipeline.Job("some-other-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			aJob, found, err := pipeline.Job("a-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			sharedJob, found, err := pipeline.Job("shared-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			randomJob, found, err := pipeline.Job("random-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			otherSerialGroupJob, found, err := pipeline.Job("other-serial-group-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			differentSerialGroupJob, found, err := pipeline.Job("different-serial-group-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			By("returning jobs with no builds")
+			actualDashboard, err := pipeline.Dashboard()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(actualDashboard[0].Job.Name()).To(Equal(job.Name()))
+			Expect(actualDashboard[1].Job.Name()).To(Equal(otherJob.Name()))
+			Expect(actualDashboard[2].Job.Name()).To(Equal(aJob.Name()))
+			Expect(actualDashboard[3].Job.Name()).To(Equal(sharedJob.Name()))
+			Expect(actualDashboard[4].Job.Name()).To(Equal(randomJob.Name()))
+			Expect(actualDashboard[5].Job.Name()).To(Equal(otherSerialGroupJob.Name()))
+			Expect(actualDashboard[6].Job.Name()).To(Equal(differentSerialGroupJob.Name()))
+
+			By("returning a job's most recent pending build if there are no running builds")
+			job, found, err = pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			firstJobBuild, err := job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			actualDashboard, err = pipeline.Dashboard()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(actualDashboard[0].Job.Name()).To(Equal(job.Name()))
+			Expect(actualDashboard[0].NextBuild.ID()).To(Equal(firstJobBuild.ID()))
+
+			By("returning a job's most recent started build")
+			found, err = firstJobBuild.Start(atc.Plan{ID: "some-id"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			found, err = firstJobBuild.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			actualDashboard, err = pipeline.Dashboard()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(actualDashboard[0].Job.Name()).To(Equal(job.Name()))
+			Expect(actualDashboard[0].NextBuild.ID()).To(Equal(firstJobBuild.ID()))
+			Expect(actualDashboard[0].NextBuild.Status()).To(Equal(db.BuildStatusStarted))
+			Expect(actualDashboard[0].NextBuild.Schema()).To(Equal("exec.v2"))
+			Expect(actualDashboard[0].NextBuild.PrivatePlan()).To(Equal(atc.Plan{ID: "some-id"}))
+
+			By("returning a job's most recent started build even if there is a newer pending build")
+			job, found, err = pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			secondJobBuild, err := job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			actualDashboard, err = pipeline.Dashboard()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(actualDashboard[0].Job.Name()).To(Equal(job.Name()))
+			Expect(actualDashboard[0].NextBuild.ID()).To(Equal(firstJobBuild.ID()))
+
+			By("returning a job's most recent finished build")
+			err = firstJobBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = secondJobBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err = secondJobBuild.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			actualDashboard, err = pipeline.Dashboard()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(actualDashboard[0].Job.Name()).To(Equal(job.Name()))
 			Expect(actualDashboard[0].NextBuild).To(BeNil())
 			Expect(actualDashboard[0].FinishedBuild.ID()).To(Equal(secondJobBuild.ID()))
 		})
@@ -1672,12 +2450,12 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerManual)
 
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err := job.CreateBuild()
+			secondBuild, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
@@ -1685,7 +2463,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			_, err = someOtherJob.CreateBuild()
+			_, err = someOtherJob.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 
 			dbBuild, found, err := buildFactory.Build(build.ID())
@@ -1807,11 +2585,11 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err = job.CreateBuild()
+			secondBuild, err = job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
@@ -1819,7 +2597,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			_, err = someOtherJob.CreateBuild()
+			_, err = someOtherJob.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 
 			dbBuild, found, err := buildFactory.Build(build.ID())
@@ -1908,6 +2686,251 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("GetBuildForOutput", func() {
+		var (
+			firstBuild  db.Build
+			secondBuild db.Build
+		)
+
+		BeforeEach(func() {
+			job, found, err := pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			firstBuild, err = job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			secondBuild, err = job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, build := range []db.Build{firstBuild, secondBuild} {
+				err = build.SaveOutput(logger, "some-type", atc.Source{"some": "source"}, creds.VersionedResourceTypes{}, atc.Version{"version": "v1"}, nil, "some-output-name", "some-resource")
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			err = firstBuild.SaveOutput(logger, "some-type", atc.Source{"some": "source"}, creds.VersionedResourceTypes{}, atc.Version{"version": "v2"}, nil, "some-output-name", "some-resource")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the latest build that put the version", func() {
+			build, found, err := pipeline.GetBuildForOutput("some-resource", atc.Version{"version": "v1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.ID()).To(Equal(secondBuild.ID()))
+
+			build, found, err = pipeline.GetBuildForOutput("some-resource", atc.Version{"version": "v2"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.ID()).To(Equal(firstBuild.ID()))
+		})
+
+		It("returns false when no build put the version", func() {
+			_, found, err := pipeline.GetBuildForOutput("some-resource", atc.Version{"version": "v3"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns an error when the resource does not exist", func() {
+			_, _, err := pipeline.GetBuildForOutput("bogus-resource", atc.Version{"version": "v1"})
+			Expect(err).To(Equal(db.ErrResourceNotFound{Name: "bogus-resource"}))
+		})
+	})
+
+	Describe("GetBuildsForInputVersion", func() {
+		var builds []db.Build
+
+		BeforeEach(func() {
+			job, found, err := pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resource, found, err := pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			builds = []db.Build{}
+			for _, version := range []string{"v1", "v2", "v1", "v1"} {
+				build, err := job.CreateBuild(db.BuildTriggerManual)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.UseInputs([]db.BuildInput{
+					{
+						Name:       "some-input",
+						Version:    atc.Version{"version": version},
+						ResourceID: resource.ID(),
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				builds = append(builds, build)
+			}
+		})
+
+		ids := func(builds []db.Build) []int {
+			ids := []int{}
+			for _, build := range builds {
+				ids = append(ids, build.ID())
+			}
+			return ids
+		}
+
+		It("returns the builds that used the version as an input, newest first", func() {
+			consumers, err := pipeline.GetBuildsForInputVersion("some-resource", atc.Version{"version": "v1"}, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids(consumers)).To(Equal([]int{builds[3].ID(), builds[2].ID(), builds[0].ID()}))
+		})
+
+		It("returns at most limit builds", func() {
+			consumers, err := pipeline.GetBuildsForInputVersion("some-resource", atc.Version{"version": "v1"}, 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids(consumers)).To(Equal([]int{builds[3].ID(), builds[2].ID()}))
+		})
+
+		It("returns no builds for a version that was never used", func() {
+			consumers, err := pipeline.GetBuildsForInputVersion("some-resource", atc.Version{"version": "v3"}, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(consumers).To(BeEmpty())
+		})
+
+		It("returns an error when the resource does not exist", func() {
+			_, err := pipeline.GetBuildsForInputVersion("bogus-resource", atc.Version{"version": "v1"}, 0)
+			Expect(err).To(Equal(db.ErrResourceNotFound{Name: "bogus-resource"}))
+		})
+	})
+
+	Describe("GetVersionCausality", func() {
+		var (
+			firstBuild  db.Build
+			secondBuild db.Build
+			v1ID, v2ID  int
+		)
+
+		saveOutput := func(build db.Build, version string) {
+			err := build.SaveOutput(logger, "some-base-resource-type", atc.Source{"some": "source"}, creds.VersionedResourceTypes{}, atc.Version{"version": version}, nil, "some-output", "some-resource")
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			scope, err := defaultResource.SetResourceConfig(logger, atc.Source{"some": "source"}, creds.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			firstBuild, err = defaultJob.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			saveOutput(firstBuild, "v1")
+
+			secondBuild, err = defaultJob.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = secondBuild.UseInputs([]db.BuildInput{
+				{
+					Name:       "some-input",
+					Version:    atc.Version{"version": "v1"},
+					ResourceID: defaultResource.ID(),
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			saveOutput(secondBuild, "v2")
+
+			v1, found, err := scope.FindVersion(atc.Version{"version": "v1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			v1ID = v1.ID()
+
+			v2, found, err := scope.FindVersion(atc.Version{"version": "v2"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			v2ID = v2.ID()
+		})
+
+		It("walks back through the builds that produced the version", func() {
+			graph, err := defaultPipeline.GetVersionCausality(v2ID, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(graph.ResourceVersionIDs).To(Equal([]int{v2ID, v1ID}))
+			Expect(graph.BuildIDs).To(Equal([]int{secondBuild.ID(), firstBuild.ID()}))
+			Expect(graph.Edges).To(Equal([]db.CausalityEdge{
+				{BuildID: secondBuild.ID(), ResourceVersionID: v2ID},
+				{BuildID: secondBuild.ID(), ResourceVersionID: v1ID, Input: true},
+				{BuildID: firstBuild.ID(), ResourceVersionID: v1ID},
+			}))
+		})
+
+		It("stops at the max depth", func() {
+			graph, err := defaultPipeline.GetVersionCausality(v2ID, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(graph.BuildIDs).To(Equal([]int{secondBuild.ID()}))
+			Expect(graph.ResourceVersionIDs).To(Equal([]int{v2ID, v1ID}))
+		})
+
+		Context("when a build used the version it produced", func() {
+			BeforeEach(func() {
+				err := firstBuild.UseInputs([]db.BuildInput{
+					{
+						Name:       "some-input",
+						Version:    atc.Version{"version": "v2"},
+						ResourceID: defaultResource.ID(),
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("visits each build and version once", func() {
+				graph, err := defaultPipeline.GetVersionCausality(v2ID, 10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(graph.ResourceVersionIDs).To(Equal([]int{v2ID, v1ID}))
+				Expect(graph.BuildIDs).To(Equal([]int{secondBuild.ID(), firstBuild.ID()}))
+			})
+		})
+
+		It("returns an error when the version does not exist", func() {
+			_, err := defaultPipeline.GetVersionCausality(v2ID+1, 10)
+			Expect(err).To(Equal(db.ErrVersionNotFound))
+		})
+	})
+
+	Describe("UpdateVersionMetadata", func() {
+		var scope db.ResourceConfigScope
+
+		BeforeEach(func() {
+			var err error
+			scope, err = defaultResource.SetResourceConfig(logger, atc.Source{"some": "source"}, creds.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = scope.SaveVersions([]atc.Version{{"version": "v1"}})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("replaces the metadata of the version", func() {
+			err := defaultPipeline.UpdateVersionMetadata("some-resource", atc.Version{"version": "v1"}, db.ResourceConfigMetadataFields{
+				{Name: "tag", Value: "moved"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			version, found, err := scope.FindVersion(atc.Version{"version": "v1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(version.Metadata()).To(Equal(db.ResourceConfigMetadataFields{
+				{Name: "tag", Value: "moved"},
+			}))
+
+			var count int
+			err = dbConn.QueryRow(`SELECT COUNT(*) FROM resource_config_versions WHERE resource_config_scope_id = $1`, scope.ID()).Scan(&count)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(1))
+		})
+
+		It("returns an error when the version does not exist", func() {
+			err := defaultPipeline.UpdateVersionMetadata("some-resource", atc.Version{"version": "v2"}, nil)
+			Expect(err).To(Equal(db.ErrVersionNotFound))
+		})
+
+		It("returns an error when the resource does not exist", func() {
+			err := defaultPipeline.UpdateVersionMetadata("bogus-resource", atc.Version{"version": "v1"}, nil)
+			Expect(err).To(Equal(db.ErrResourceNotFound{Name: "bogus-resource"}))
+		})
+	})
+
 	Describe("Builds", func() {
 		var expectedBuilds []db.Build
 
@@ -1919,11 +2942,11 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err := job.CreateBuild()
+			secondBuild, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
@@ -1931,7 +2954,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			thirdBuild, err := someOtherJob.CreateBuild()
+			thirdBuild, err := someOtherJob.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, thirdBuild)
 		})
@@ -1943,6 +2966,257 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("ConsecutiveFailures", func() {
+		finishBuilds := func(statuses ...db.BuildStatus) {
+			for _, status := range statuses {
+				build, err := defaultJob.CreateBuild(db.BuildTriggerManual)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.Finish(status)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		}
+
+		It("returns 0 when the latest build succeeded", func() {
+			finishBuilds(db.BuildStatusFailed, db.BuildStatusSucceeded)
+
+			failures, err := defaultPipeline.ConsecutiveFailures("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(failures).To(BeZero())
+		})
+
+		It("counts the finished builds since the latest success", func() {
+			finishBuilds(db.BuildStatusFailed, db.BuildStatusSucceeded, db.BuildStatusFailed, db.BuildStatusErrored)
+
+			_, err := defaultJob.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			failures, err := defaultPipeline.ConsecutiveFailures("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(failures).To(Equal(2))
+		})
+
+		It("counts every finished build when the job never succeeded", func() {
+			finishBuilds(db.BuildStatusFailed, db.BuildStatusAborted, db.BuildStatusFailed)
+
+			failures, err := defaultPipeline.ConsecutiveFailures("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(failures).To(Equal(3))
+		})
+
+		It("returns an error when the job does not exist", func() {
+			_, err := defaultPipeline.ConsecutiveFailures("bogus-job")
+			Expect(err).To(Equal(db.ErrJobNotFound{Name: "bogus-job"}))
+		})
+	})
+
+	Describe("CreateJobBuildWithKey", func() {
+		It("creates a pending build of the job", func() {
+			build, created, err := pipeline.CreateJobBuildWithKey("job-name", "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+			Expect(build.JobName()).To(Equal("job-name"))
+			Expect(build.Status()).To(Equal(db.BuildStatusPending))
+			Expect(build.IsManuallyTriggered()).To(BeTrue())
+		})
+
+		It("returns the existing build when retried with the same key", func() {
+			build, created, err := pipeline.CreateJobBuildWithKey("job-name", "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			retried, created, err := pipeline.CreateJobBuildWithKey("job-name", "some-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeFalse())
+			Expect(retried.ID()).To(Equal(build.ID()))
+
+			other, created, err := pipeline.CreateJobBuildWithKey("job-name", "some-other-key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+			Expect(other.ID()).ToNot(Equal(build.ID()))
+		})
+
+		It("returns an error when the job does not exist", func() {
+			_, _, err := pipeline.CreateJobBuildWithKey("bogus-job", "some-key")
+			Expect(err).To(Equal(db.ErrJobNotFound{Name: "bogus-job"}))
+		})
+	})
+
+	Describe("ResourceReuseStats", func() {
+		var (
+			job      db.Job
+			resource db.Resource
+			start    time.Time
+		)
+
+		BeforeEach(func() {
+			var found bool
+			var err error
+			job, found, err = pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resourceConfigScope, err := resource.SetResourceConfig(logger, atc.Source{"some": "source"}, creds.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "v1"}, {"version": "v2"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			start = time.Now().Add(-time.Minute)
+
+			for _, input := range []struct {
+				version  string
+				reported bool
+				reused   bool
+			}{
+				{version: "v1", reported: true, reused: false},
+				{version: "v1", reported: true, reused: true},
+				{version: "v2", reported: true, reused: false},
+				{version: "v2", reported: false},
+			} {
+				build, err := job.CreateBuild(db.BuildTriggerManual)
+				Expect(err).ToNot(HaveOccurred())
+
+				buildInput := db.BuildInput{
+					Name:       "some-input",
+					Version:    atc.Version{"version": input.version},
+					ResourceID: resource.ID(),
+				}
+
+				err = build.UseInputs([]db.BuildInput{buildInput})
+				Expect(err).ToNot(HaveOccurred())
+
+				if input.reported {
+					err = build.SaveInput(buildInput, input.reused)
+					Expect(err).ToNot(HaveOccurred())
+				}
+			}
+		})
+
+		It("counts fresh and reused versions, ignoring inputs that weren't reported", func() {
+			stats, err := pipeline.ResourceReuseStats("some-resource", start)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stats).To(Equal(db.ReuseStats{Reused: 1, Fresh: 2}))
+		})
+
+		It("ignores builds created before the given time", func() {
+			stats, err := pipeline.ResourceReuseStats("some-resource", time.Now().Add(time.Minute))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stats).To(Equal(db.ReuseStats{}))
+		})
+
+		It("returns an error when the resource does not exist", func() {
+			_, err := pipeline.ResourceReuseStats("bogus-resource", start)
+			Expect(err).To(Equal(db.ErrResourceNotFound{Name: "bogus-resource"}))
+		})
+	})
+
+	Describe("RerunBuild", func() {
+		var (
+			originalBuild db.Build
+			resource      db.Resource
+		)
+
+		BeforeEach(func() {
+			job, found, err := pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			originalBuild, err = job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		Context("when the build has recorded inputs", func() {
+			BeforeEach(func() {
+				resourceConfigScope, err := resource.SetResourceConfig(logger, atc.Source{"some": "source"}, creds.VersionedResourceTypes{})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "v1"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = originalBuild.UseInputs([]db.BuildInput{
+					{
+						Name:       "some-input",
+						Version:    atc.Version{"version": "v1"},
+						ResourceID: resource.ID(),
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "v2"}})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("creates a pending build of the same job linked to the original", func() {
+				rerun, err := pipeline.RerunBuild(originalBuild.ID())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rerun.ID()).ToNot(Equal(originalBuild.ID()))
+				Expect(rerun.JobID()).To(Equal(originalBuild.JobID()))
+				Expect(rerun.Status()).To(Equal(db.BuildStatusPending))
+				Expect(rerun.IsManuallyTriggered()).To(BeTrue())
+				Expect(rerun.RerunOf()).To(Equal(originalBuild.ID()))
+				Expect(rerun.TriggerSource()).To(Equal(db.BuildTriggerRerun))
+			})
+
+			It("names and numbers the build like any other build of the job", func() {
+				rerun, err := pipeline.RerunBuild(originalBuild.ID())
+				Expect(err).ToNot(HaveOccurred())
+
+				originalName, err := strconv.Atoi(originalBuild.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rerun.Name()).To(Equal(strconv.Itoa(originalName + 1)))
+
+				job, found, err := pipeline.Job("job-name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				nextBuild, err := job.CreateBuild(db.BuildTriggerManual)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(nextBuild.Name()).To(Equal(strconv.Itoa(originalName + 2)))
+			})
+
+			It("uses the same input versions as the original build", func() {
+				rerun, err := pipeline.RerunBuild(originalBuild.ID())
+				Expect(err).ToNot(HaveOccurred())
+
+				inputs, _, err := rerun.Resources()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(inputs).To(ConsistOf(db.BuildInput{
+					Name:            "some-input",
+					Version:         atc.Version{"version": "v1"},
+					ResourceID:      resource.ID(),
+					FirstOccurrence: false,
+					Enabled:         true,
+				}))
+			})
+		})
+
+		Context("when the build has no recorded inputs", func() {
+			It("returns an error", func() {
+				_, err := pipeline.RerunBuild(originalBuild.ID())
+				Expect(err).To(Equal(db.ErrBuildHasNoInputs))
+			})
+		})
+
+		Context("when the build is not a job build in the pipeline", func() {
+			It("returns an error", func() {
+				oneOffBuild, err := team.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = pipeline.RerunBuild(oneOffBuild.ID())
+				Expect(err).To(Equal(db.ErrBuildNotFound))
+			})
+		})
+	})
+
 	Describe("CreateStartedBuild", func() {
 		var (
 			plan         atc.Plan
@@ -2168,7 +3442,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(found).To(BeTrue())
 
 			for i := range builds {
-				builds[i], err = job.CreateBuild()
+				builds[i], err = job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 
 				buildStart := time.Date(2020, 11, i+1, 0, 0, 0, 0, time.UTC)
@@ -2187,7 +3461,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			_, err = otherJob.CreateBuild()
+			_, err = otherJob.CreateBuild(db.BuildTriggerManual)
 		})
 
 		Context("when not providing boundaries", func() {
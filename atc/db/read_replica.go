@@ -0,0 +1,67 @@
+package db
+
+import (
+	"database/sql"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// WithReadReplica returns a connection that sends reads which can tolerate
+// replication lag, such as build listings and resource version history, to the
+// given replica. Everything else, including all writes, goes to the primary.
+func WithReadReplica(primary Conn, replica *sql.DB) Conn {
+	return &replicatedConn{
+		Conn: primary,
+
+		replica: &db{
+			DB: replica,
+
+			bus:        primary.Bus(),
+			encryption: primary.EncryptionStrategy(),
+			name:       primary.Name(),
+		},
+	}
+}
+
+type replicatedConn struct {
+	Conn
+
+	replica *db
+}
+
+func (c *replicatedConn) ReadReplica() Conn {
+	return c.replica
+}
+
+func (c *replicatedConn) Close() error {
+	var errs error
+
+	err := c.Conn.Close()
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	// the bus is shared with the primary, so only the pool is closed here
+	err = c.replica.DB.Close()
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	return errs
+}
+
+// PrimaryOnly returns a connection that never reads from a replica. Use it for
+// anything that must see its own writes straight away.
+func PrimaryOnly(conn Conn) Conn {
+	return &primaryOnlyConn{
+		Conn: conn,
+	}
+}
+
+type primaryOnlyConn struct {
+	Conn
+}
+
+func (c *primaryOnlyConn) ReadReplica() Conn {
+	return c
+}
@@ -0,0 +1,70 @@
+package db_test
+
+import (
+	"database/sql"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Read replicas", func() {
+	var (
+		fakePrimary *dbfakes.FakeConn
+		replicaDB   *sql.DB
+	)
+
+	BeforeEach(func() {
+		fakePrimary = new(dbfakes.FakeConn)
+		fakePrimary.NameReturns("some-conn")
+
+		var err error
+		replicaDB, err = sql.Open("postgres", postgresRunner.DataSourceName())
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("WithReadReplica", func() {
+		var conn db.Conn
+
+		BeforeEach(func() {
+			conn = db.WithReadReplica(fakePrimary, replicaDB)
+		})
+
+		It("reads from the replica", func() {
+			replica := conn.ReadReplica()
+			Expect(replica).ToNot(Equal(conn))
+			Expect(replica.Name()).To(Equal("some-conn"))
+
+			var one int
+			err := replica.QueryRow("SELECT 1").Scan(&one)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(one).To(Equal(1))
+
+			Expect(fakePrimary.QueryRowCallCount()).To(BeZero())
+		})
+
+		It("sends everything else to the primary", func() {
+			_, err := conn.Exec("SELECT 1")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakePrimary.ExecCallCount()).To(Equal(1))
+		})
+
+		It("closes both the primary and the replica", func() {
+			err := conn.Close()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakePrimary.CloseCallCount()).To(Equal(1))
+			Expect(replicaDB.Ping()).To(HaveOccurred())
+		})
+	})
+
+	Describe("PrimaryOnly", func() {
+		It("never reads from the replica", func() {
+			conn := db.PrimaryOnly(db.WithReadReplica(fakePrimary, replicaDB))
+			Expect(conn.ReadReplica()).To(Equal(conn))
+		})
+	})
+})
@@ -16,6 +16,8 @@ import (
 	"github.com/lib/pq"
 )
 
+var ErrVersionNotFound = errors.New("version not found")
+
 //go:generate counterfeiter . Resource
 
 type Resource interface {
@@ -42,11 +44,13 @@ type Resource interface {
 	ResourceConfigID() int
 	ResourceConfigScopeID() int
 	Icon() string
+	Paused() bool
 
 	CurrentPinnedVersion() atc.Version
 
 	ResourceConfigVersionID(atc.Version) (int, bool, error)
 	Versions(page Page) ([]atc.ResourceVersion, Pagination, bool, error)
+	FindVersionsByMetadata(field string, value string, caseInsensitive bool) ([]atc.ResourceVersion, error)
 	SaveUncheckedVersion(atc.Version, ResourceConfigMetadataFields, ResourceConfig, creds.VersionedResourceTypes) (bool, error)
 
 	EnableVersion(rcvID int) error
@@ -55,6 +59,9 @@ type Resource interface {
 	PinVersion(rcvID int) error
 	UnpinVersion() error
 
+	Pause() error
+	Unpause() error
+
 	SetResourceConfig(lager.Logger, atc.Source, creds.VersionedResourceTypes) (ResourceConfigScope, error)
 	SetCheckSetupError(error) error
 	NotifyScan() error
@@ -62,7 +69,7 @@ type Resource interface {
 	Reload() (bool, error)
 }
 
-var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, rs.last_check_start_time, rs.last_check_end_time, r.pipeline_id, r.nonce, r.resource_config_id, r.resource_config_scope_id, p.name, t.name, rs.check_error, rp.version, rp.comment_text").
+var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, rs.last_check_start_time, rs.last_check_end_time, r.pipeline_id, r.nonce, r.resource_config_id, r.resource_config_scope_id, p.name, t.name, rs.check_error, rp.version, rp.comment_text, r.paused").
 	From("resources r").
 	Join("pipelines p ON p.id = r.pipeline_id").
 	Join("teams t ON t.id = p.team_id").
@@ -93,6 +100,7 @@ type resource struct {
 	resourceConfigID      int
 	resourceConfigScopeID int
 	icon                  string
+	paused                bool
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -160,6 +168,7 @@ func (r *resource) PinComment() string               { return r.pinComment }
 func (r *resource) ResourceConfigID() int            { return r.resourceConfigID }
 func (r *resource) ResourceConfigScopeID() int       { return r.resourceConfigScopeID }
 func (r *resource) Icon() string                     { return r.icon }
+func (r *resource) Paused() bool                     { return r.paused }
 
 func (r *resource) Reload() (bool, error) {
 	row := resourcesQuery.Where(sq.Eq{"r.id": r.id}).
@@ -354,10 +363,13 @@ func (r *resource) Versions(page Page) ([]atc.ResourceVersion, Pagination, bool,
 		WHERE r.id = $1 AND r.resource_config_scope_id = v.resource_config_scope_id AND v.check_order != 0
 	`
 
+	// version history can tolerate replication lag
+	reader := r.conn.ReadReplica()
+
 	var rows *sql.Rows
 	var err error
 	if page.Until != 0 {
-		rows, err = r.conn.Query(fmt.Sprintf(`
+		rows, err = reader.Query(fmt.Sprintf(`
 			SELECT sub.*
 				FROM (
 						%s
@@ -371,7 +383,7 @@ func (r *resource) Versions(page Page) ([]atc.ResourceVersion, Pagination, bool,
 			return nil, Pagination{}, false, err
 		}
 	} else if page.Since != 0 {
-		rows, err = r.conn.Query(fmt.Sprintf(`
+		rows, err = reader.Query(fmt.Sprintf(`
 			%s
 				AND v.check_order < (SELECT check_order FROM resource_config_versions WHERE id = $2)
 			ORDER BY v.check_order DESC
@@ -381,7 +393,7 @@ func (r *resource) Versions(page Page) ([]atc.ResourceVersion, Pagination, bool,
 			return nil, Pagination{}, false, err
 		}
 	} else if page.To != 0 {
-		rows, err = r.conn.Query(fmt.Sprintf(`
+		rows, err = reader.Query(fmt.Sprintf(`
 			SELECT sub.*
 				FROM (
 						%s
@@ -395,7 +407,7 @@ func (r *resource) Versions(page Page) ([]atc.ResourceVersion, Pagination, bool,
 			return nil, Pagination{}, false, err
 		}
 	} else if page.From != 0 {
-		rows, err = r.conn.Query(fmt.Sprintf(`
+		rows, err = reader.Query(fmt.Sprintf(`
 			%s
 				AND v.check_order <= (SELECT check_order FROM resource_config_versions WHERE id = $2)
 			ORDER BY v.check_order DESC
@@ -405,7 +417,7 @@ func (r *resource) Versions(page Page) ([]atc.ResourceVersion, Pagination, bool,
 			return nil, Pagination{}, false, err
 		}
 	} else {
-		rows, err = r.conn.Query(fmt.Sprintf(`
+		rows, err = reader.Query(fmt.Sprintf(`
 			%s
 			ORDER BY v.check_order DESC
 			LIMIT $2
@@ -465,7 +477,7 @@ func (r *resource) Versions(page Page) ([]atc.ResourceVersion, Pagination, bool,
 	var minCheckOrder int
 	var maxCheckOrder int
 
-	err = r.conn.QueryRow(`
+	err = reader.QueryRow(`
 		SELECT COALESCE(MAX(v.check_order), 0) as maxCheckOrder,
 			COALESCE(MIN(v.check_order), 0) as minCheckOrder
 		FROM resource_config_versions v, resources r
@@ -497,6 +509,77 @@ func (r *resource) Versions(page Page) ([]atc.ResourceVersion, Pagination, bool,
 	return rvs, pagination, true, nil
 }
 
+// FindVersionsByMetadata returns the versions of the resource that have a
+// metadata field with the given name and value, newest first. The value is
+// compared case-sensitively unless caseInsensitive is set.
+func (r *resource) FindVersionsByMetadata(field string, value string, caseInsensitive bool) ([]atc.ResourceVersion, error) {
+	valueMatch := "m->>'value' = $3"
+	if caseInsensitive {
+		valueMatch = "lower(m->>'value') = lower($3)"
+	}
+
+	rows, err := r.conn.Query(`
+		SELECT v.id, v.version, v.metadata,
+			NOT EXISTS (
+				SELECT 1
+				FROM resource_disabled_versions d
+				WHERE v.version_md5 = d.version_md5
+				AND r.id = d.resource_id
+			)
+		FROM resource_config_versions v, resources r
+		WHERE r.id = $1
+		AND r.resource_config_scope_id = v.resource_config_scope_id
+		AND v.check_order != 0
+		AND EXISTS (
+			SELECT 1
+			FROM jsonb_array_elements(CASE WHEN jsonb_typeof(v.metadata) = 'array' THEN v.metadata ELSE '[]' END) m
+			WHERE m->>'name' = $2
+			AND `+valueMatch+`
+		)
+		ORDER BY v.check_order DESC
+	`, r.id, field, value)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	rvs := []atc.ResourceVersion{}
+	for rows.Next() {
+		var (
+			metadataBytes sql.NullString
+			versionBytes  string
+		)
+
+		rv := atc.ResourceVersion{}
+		err := rows.Scan(&rv.ID, &versionBytes, &metadataBytes, &rv.Enabled)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(versionBytes), &rv.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		if metadataBytes.Valid {
+			err = json.Unmarshal([]byte(metadataBytes.String), &rv.Metadata)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rvs = append(rvs, rv)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return rvs, nil
+}
+
 func (r *resource) EnableVersion(rcvID int) error {
 	return r.toggleVersion(rcvID, true)
 }
@@ -505,14 +588,16 @@ func (r *resource) DisableVersion(rcvID int) error {
 	return r.toggleVersion(rcvID, false)
 }
 
+// PinVersion pins the resource to one of its versions. It returns
+// ErrVersionNotFound if the version is not part of the resource's history.
 func (r *resource) PinVersion(rcvID int) error {
 	results, err := r.conn.Exec(`
-	    INSERT INTO resource_pins(resource_id, version, comment_text)
-			VALUES ($1,
-				( SELECT rcv.version
-				FROM resource_config_versions rcv
-				WHERE rcv.id = $2 ),
-				'')`, r.id, rcvID)
+		INSERT INTO resource_pins(resource_id, version, comment_text)
+		SELECT r.id, rcv.version, ''
+		FROM resource_config_versions rcv
+		JOIN resources r ON r.resource_config_scope_id = rcv.resource_config_scope_id
+		WHERE r.id = $1
+		AND rcv.id = $2`, r.id, rcvID)
 	if err != nil {
 		return err
 	}
@@ -522,6 +607,10 @@ func (r *resource) PinVersion(rcvID int) error {
 		return err
 	}
 
+	if rowsAffected == 0 {
+		return ErrVersionNotFound
+	}
+
 	if rowsAffected != 1 {
 		return nonOneRowAffectedError{rowsAffected}
 	}
@@ -594,6 +683,40 @@ func (r *resource) toggleVersion(rcvID int, enable bool) error {
 	return tx.Commit()
 }
 
+// Pause stops the resource from being checked. Its existing versions are
+// left untouched.
+func (r *resource) Pause() error {
+	return r.updatePaused(true)
+}
+
+func (r *resource) Unpause() error {
+	return r.updatePaused(false)
+}
+
+func (r *resource) updatePaused(pause bool) error {
+	result, err := psql.Update("resources").
+		Set("paused", pause).
+		Where(sq.Eq{"id": r.id}).
+		RunWith(r.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	r.paused = pause
+
+	return nil
+}
+
 func (r *resource) NotifyScan() error {
 	return r.conn.Bus().Notify(fmt.Sprintf("resource_scan_%d", r.id))
 }
@@ -605,7 +728,7 @@ func scanResource(r *resource, row scannable) error {
 		lastCheckStartTime, lastCheckEndTime                                        pq.NullTime
 	)
 
-	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &lastCheckStartTime, &lastCheckEndTime, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &r.teamName, &rcsCheckErr, &apiPinnedVersion, &pinComment)
+	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &lastCheckStartTime, &lastCheckEndTime, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &r.teamName, &rcsCheckErr, &apiPinnedVersion, &pinComment, &r.paused)
 	if err != nil {
 		return err
 	}
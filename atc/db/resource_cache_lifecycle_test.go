@@ -31,7 +31,7 @@ var _ = Describe("ResourceCacheLifecycle", func() {
 			}
 
 			resourceCacheForJobBuild := func() (db.UsedResourceCache, db.Build) {
-				build, err := defaultJob.CreateBuild()
+				build, err := defaultJob.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 				return createResourceCacheWithUser(db.ForBuild(build.ID())), build
 			}
@@ -302,7 +302,7 @@ var _ = Describe("ResourceCacheLifecycle", func() {
 
 		Context("when the cache is for a resource version used as an input for the next build of a job", func() {
 			It("does not remove the cache", func() {
-				err := defaultPipeline.Unpause()
+				err := defaultPipeline.Unpause("")
 				Expect(err).ToNot(HaveOccurred())
 
 				resourceConfigScope, err := defaultResource.SetResourceConfig(
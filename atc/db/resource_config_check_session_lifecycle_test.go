@@ -108,7 +108,7 @@ var _ = Describe("ResourceConfigCheckSessionLifecycle", func() {
 
 			It("removes check sessions for resources in paused pipelines", func() {
 				By("pausing the pipeline")
-				Expect(defaultPipeline.Pause()).To(Succeed())
+				Expect(defaultPipeline.Pause("")).To(Succeed())
 
 				By("cleaning up inactive sessions")
 				Expect(lifecycle.CleanInactiveResourceConfigCheckSessions()).To(Succeed())
@@ -205,7 +205,7 @@ var _ = Describe("ResourceConfigCheckSessionLifecycle", func() {
 
 			It("removes check sessions for resource types in paused pipelines", func() {
 				By("pausing the pipeline")
-				Expect(defaultPipeline.Pause()).To(Succeed())
+				Expect(defaultPipeline.Pause("")).To(Succeed())
 
 				By("cleaning up inactive sessions")
 				Expect(lifecycle.CleanInactiveResourceConfigCheckSessions()).To(Succeed())
@@ -21,7 +21,7 @@ var _ = Describe("ResourceConfigFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err = job.CreateBuild()
+			build, err = job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
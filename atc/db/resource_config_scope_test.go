@@ -88,6 +88,38 @@ var _ = Describe("Resource Config Scope", func() {
 			Expect(latestVR.CheckOrder()).To(Equal(4))
 		})
 
+		It("re-orders existing versions to match the order of a later check", func() {
+			err := resourceScope.SaveVersions([]atc.Version{
+				{"ref": "v1"},
+				{"ref": "v2"},
+				{"ref": "v3"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = resourceScope.SaveVersions([]atc.Version{
+				{"ref": "v3"},
+				{"ref": "v2"},
+				{"ref": "v1"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			latestVR, found, err := resourceScope.LatestVersion()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(latestVR.Version()).To(Equal(db.Version{"ref": "v1"}))
+
+			v2, found, err := resourceScope.FindVersion(atc.Version{"ref": "v2"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			v3, found, err := resourceScope.FindVersion(atc.Version{"ref": "v3"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(v2.CheckOrder()).To(BeNumerically(">", v3.CheckOrder()))
+			Expect(latestVR.CheckOrder()).To(BeNumerically(">", v2.CheckOrder()))
+		})
+
 		Context("when the versions already exists", func() {
 			var newVersionSlice []atc.Version
 
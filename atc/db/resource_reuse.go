@@ -0,0 +1,55 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ReuseStats counts how many times versions of a resource were reused from a
+// cache (Reused) or newly fetched (Fresh) by the get steps of build inputs.
+// Inputs whose get step hasn't reported either, see Build.SaveInput, are not
+// counted.
+type ReuseStats struct {
+	Reused int
+	Fresh  int
+}
+
+// ResourceReuseStats returns the reuse stats of the named resource's inputs
+// for builds created since the given time. It returns ErrResourceNotFound if
+// the pipeline has no such resource.
+func (p *pipeline) ResourceReuseStats(resource string, since time.Time) (ReuseStats, error) {
+	var resourceID int
+	err := psql.Select("id").
+		From("resources").
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+			"name":        resource,
+			"active":      true,
+		}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&resourceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ReuseStats{}, ErrResourceNotFound{resource}
+		}
+		return ReuseStats{}, err
+	}
+
+	var stats ReuseStats
+	err = psql.Select("COUNT(*) FILTER (WHERE i.reused)", "COUNT(*) FILTER (WHERE NOT i.reused)").
+		From("build_resource_config_version_inputs i").
+		Join("builds b ON b.id = i.build_id").
+		Where(sq.Eq{"i.resource_id": resourceID}).
+		Where(sq.GtOrEq{"b.create_time": since}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&stats.Reused, &stats.Fresh)
+	if err != nil {
+		return ReuseStats{}, err
+	}
+
+	return stats, nil
+}
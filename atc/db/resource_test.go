@@ -874,6 +874,42 @@ var _ = Describe("Resource", func() {
 				})
 			})
 
+			Context("when searching versions by metadata", func() {
+				BeforeEach(func() {
+					metadata := []db.ResourceConfigMetadataField{{Name: "git.sha", Value: "ABC123"}}
+
+					_, err := resource.SaveUncheckedVersion(atc.Version(resourceVersions[3].Version), metadata, resourceScope.ResourceConfig(), creds.VersionedResourceTypes{})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("finds versions with a matching field and value", func() {
+					versions, err := resource.FindVersionsByMetadata("git.sha", "ABC123", false)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(versions).To(HaveLen(1))
+					Expect(versions[0].Version).To(Equal(resourceVersions[3].Version))
+					Expect(versions[0].Metadata).To(Equal([]atc.MetadataField{{Name: "git.sha", Value: "ABC123"}}))
+				})
+
+				It("matches the value case-sensitively by default", func() {
+					versions, err := resource.FindVersionsByMetadata("git.sha", "abc123", false)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(versions).To(BeEmpty())
+				})
+
+				It("can match the value case-insensitively", func() {
+					versions, err := resource.FindVersionsByMetadata("git.sha", "abc123", true)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(versions).To(HaveLen(1))
+					Expect(versions[0].Version).To(Equal(resourceVersions[3].Version))
+				})
+
+				It("does not match other fields", func() {
+					versions, err := resource.FindVersionsByMetadata("build.url", "ABC123", false)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(versions).To(BeEmpty())
+				})
+			})
+
 			Context("when a version is disabled", func() {
 				BeforeEach(func() {
 					err := resource.DisableVersion(resourceVersions[9].ID)
@@ -1149,6 +1185,20 @@ var _ = Describe("Resource", func() {
 			})
 		})
 
+		Context("when the version is not part of the resource's history", func() {
+			It("returns ErrVersionNotFound", func() {
+				otherResource, found, err := pipeline.Resource("some-resource")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = otherResource.PinVersion(resID)
+				Expect(err).To(Equal(db.ErrVersionNotFound))
+
+				err = resource.PinVersion(resID + 1000)
+				Expect(err).To(Equal(db.ErrVersionNotFound))
+			})
+		})
+
 		Context("when we pin a resource that is already pinned to a version (through the config)", func() {
 			BeforeEach(func() {
 				var found bool
@@ -1196,6 +1246,45 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("Pause and Unpause", func() {
+		var resource db.Resource
+
+		BeforeEach(func() {
+			var found bool
+			var err error
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("starts out as unpaused", func() {
+			Expect(resource.Paused()).To(BeFalse())
+		})
+
+		It("can be paused", func() {
+			err := resource.Pause()
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err := resource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(resource.Paused()).To(BeTrue())
+		})
+
+		It("can be unpaused", func() {
+			err := resource.Pause()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = resource.Unpause()
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err := resource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(resource.Paused()).To(BeFalse())
+		})
+	})
+
 	Describe("Public", func() {
 		var (
 			resource db.Resource
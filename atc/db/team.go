@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -17,6 +18,24 @@ import (
 )
 
 var ErrConfigComparisonFailed = errors.New("comparison with existing config failed during save")
+var ErrPipelineNotFound = errors.New("pipeline not found")
+var ErrTeamNotFound = errors.New("team not found")
+var ErrCannotDeleteDefaultTeam = errors.New("the default team cannot be deleted")
+var ErrCannotRenameDefaultTeam = errors.New("the default team cannot be renamed")
+
+type ErrTeamNameTaken struct {
+	Name string
+}
+
+func (e ErrTeamNameTaken) Error() string {
+	return fmt.Sprintf("team '%s' already exists", e.Name)
+}
+
+// TeamDeletion counts what was removed along with a team.
+type TeamDeletion struct {
+	Pipelines int
+	Builds    int
+}
 
 //go:generate counterfeiter . Team
 
@@ -27,7 +46,10 @@ type Team interface {
 
 	Auth() atc.TeamAuth
 
-	Delete() error
+	Quotas() (Quotas, error)
+	SetQuotas(Quotas) error
+
+	Delete() (TeamDeletion, error)
 	Rename(string) error
 
 	SavePipeline(
@@ -36,19 +58,34 @@ type Team interface {
 		from ConfigVersion,
 		pausedState PipelinePausedState,
 	) (Pipeline, bool, error)
+	SaveConfigCAS(
+		pipelineName string,
+		config atc.Config,
+		expected ConfigVersion,
+		pausedState PipelinePausedState,
+	) (Pipeline, ConfigVersion, error)
+
+	ClonePipeline(sourceName string, newName string) (Pipeline, error)
+	TransferPipeline(pipelineName string, toTeam string) error
 
 	Pipeline(pipelineName string) (Pipeline, bool, error)
 	Pipelines() ([]Pipeline, error)
+	PipelinesIncludingArchived() ([]Pipeline, error)
 	PublicPipelines() ([]Pipeline, error)
 	VisiblePipelines() ([]Pipeline, error)
 	OrderPipelines([]string) error
+	PipelineCount() (int, error)
+	RunningBuildCount() (int, error)
 
 	CreateOneOffBuild() (Build, error)
+	CreateOneOffBuildWithLabels(labels map[string]string) (Build, error)
 	CreateStartedBuild(plan atc.Plan) (Build, error)
 
 	PrivateAndPublicBuilds(Page) ([]Build, Pagination, error)
 	Builds(page Page) ([]Build, Pagination, error)
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
+	BuildsWithLabel(key string, value string, page Page) ([]Build, Pagination, error)
+	BuildsBetween(start time.Time, end time.Time, limit int) ([]Build, error)
 
 	SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error)
 	Workers() ([]Worker, error)
@@ -85,18 +122,73 @@ func (t *team) Admin() bool  { return t.admin }
 
 func (t *team) Auth() atc.TeamAuth { return t.auth }
 
-func (t *team) Delete() error {
-	_, err := psql.Delete("teams").
+// Delete removes the team along with everything it owns. Its builds and
+// pipelines are deleted first so the returned counts are exactly the rows
+// removed, and their build events tables are dropped by triggers, all within
+// the one transaction.
+func (t *team) Delete() (TeamDeletion, error) {
+	if strings.EqualFold(t.name, atc.DefaultTeamName) {
+		return TeamDeletion{}, ErrCannotDeleteDefaultTeam
+	}
+
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return TeamDeletion{}, err
+	}
+
+	defer Rollback(tx)
+
+	var deletion TeamDeletion
+	err = tx.QueryRow(`
+		WITH deleted AS (
+			DELETE FROM builds
+			WHERE team_id = $1
+			RETURNING 1
+		)
+		SELECT COUNT(*) FROM deleted
+	`, t.id).Scan(&deletion.Builds)
+	if err != nil {
+		return TeamDeletion{}, err
+	}
+
+	err = tx.QueryRow(`
+		WITH deleted AS (
+			DELETE FROM pipelines
+			WHERE team_id = $1
+			RETURNING 1
+		)
+		SELECT COUNT(*) FROM deleted
+	`, t.id).Scan(&deletion.Pipelines)
+	if err != nil {
+		return TeamDeletion{}, err
+	}
+
+	_, err = psql.Delete("teams").
 		Where(sq.Eq{
-			"name": t.name,
+			"id": t.id,
 		}).
-		RunWith(t.conn).
+		RunWith(tx).
 		Exec()
+	if err != nil {
+		return TeamDeletion{}, err
+	}
 
-	return err
+	err = tx.Commit()
+	if err != nil {
+		return TeamDeletion{}, err
+	}
+
+	return deletion, nil
 }
 
+// Rename changes the name of the team in place, so its pipelines and builds
+// stay with it. It returns ErrTeamNameTaken if another team already has the
+// new name, regardless of case.
 func (t *team) Rename(name string) error {
+	if strings.EqualFold(t.name, atc.DefaultTeamName) {
+		return ErrCannotRenameDefaultTeam
+	}
+
 	_, err := psql.Update("teams").
 		Set("name", name).
 		Where(sq.Eq{
@@ -104,8 +196,17 @@ func (t *team) Rename(name string) error {
 		}).
 		RunWith(t.conn).
 		Exec()
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return ErrTeamNameTaken{Name: name}
+		}
 
-	return err
+		return err
+	}
+
+	t.name = name
+
+	return nil
 }
 
 func (t *team) Workers() ([]Worker, error) {
@@ -340,11 +441,232 @@ func (t *team) FindCreatedContainerByHandle(
 	return nil, false, nil
 }
 
+// ClonePipeline saves the current config of an existing pipeline as a new,
+// paused pipeline. Builds and resource versions are not carried over.
+func (t *team) ClonePipeline(sourceName string, newName string) (Pipeline, error) {
+	source, found, err := t.Pipeline(sourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ErrPipelineNotFound
+	}
+
+	_, found, err = t.Pipeline(newName)
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		return nil, ErrPipelineNameTaken{Name: newName}
+	}
+
+	config, err := source.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	clone, created, err := t.SavePipeline(newName, config, ConfigVersion(1), PipelinePaused)
+	if err != nil {
+		return nil, err
+	}
+
+	if !created {
+		return nil, ErrPipelineNameTaken{Name: newName}
+	}
+
+	return clone, nil
+}
+
+// TransferPipeline moves a pipeline to another team, keeping its builds,
+// build events and resource versions. The pipeline goes to the end of the
+// other team's ordering. It returns ErrPipelineNameTaken if the other team
+// already has a pipeline with the same name.
+func (t *team) TransferPipeline(pipelineName string, toTeam string) error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	var pipelineID int
+	err = psql.Select("id").
+		From("pipelines").
+		Where(sq.Eq{
+			"name":    pipelineName,
+			"team_id": t.id,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&pipelineID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrPipelineNotFound
+		}
+		return err
+	}
+
+	var toTeamID int
+	err = psql.Select("id").
+		From("teams").
+		Where(sq.Eq{"LOWER(name)": strings.ToLower(toTeam)}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&toTeamID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrTeamNotFound
+		}
+		return err
+	}
+
+	if toTeamID == t.id {
+		return nil
+	}
+
+	err = checkPipelinesQuota(tx, toTeamID)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("pipelines").
+		Set("team_id", toTeamID).
+		Set("ordering", sq.Expr("(SELECT COALESCE(MAX(ordering), 0) + 1 FROM pipelines WHERE team_id = ?)", toTeamID)).
+		Set("last_updated", sq.Expr("clock_timestamp()")).
+		Where(sq.Eq{"id": pipelineID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return ErrPipelineNameTaken{Name: pipelineName}
+		}
+
+		return err
+	}
+
+	_, err = psql.Update("builds").
+		Set("team_id", toTeamID).
+		Where(sq.Or{
+			sq.Eq{"pipeline_id": pipelineID},
+			sq.Expr("job_id IN (SELECT id FROM jobs WHERE pipeline_id = ?)", pipelineID),
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SaveConfigCAS saves the pipeline like SavePipeline, but only if it is at
+// the expected version, and returns the version it replaced. A pipeline that
+// doesn't exist yet is at version 0.
+//
+// If the pipeline is at a different version, it fails with
+// ErrConfigComparisonFailed and returns the version the pipeline is at.
+func (t *team) SaveConfigCAS(
+	pipelineName string,
+	config atc.Config,
+	expected ConfigVersion,
+	pausedState PipelinePausedState,
+) (Pipeline, ConfigVersion, error) {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer Rollback(tx)
+
+	current, err := t.pipelineConfigVersion(tx, pipelineName, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if current != expected {
+		return nil, current, ErrConfigComparisonFailed
+	}
+
+	pipeline, _, err := t.savePipeline(tx, pipelineName, config, expected, pausedState)
+	if err != nil {
+		// the pipeline didn't exist yet, but a concurrent save created it
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode && pqErr.Table == "pipelines" {
+			current, err := t.pipelineConfigVersion(t.conn, pipelineName, false)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			return nil, current, ErrConfigComparisonFailed
+		}
+
+		return nil, 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return pipeline, current, nil
+}
+
+// pipelineConfigVersion returns the config version of the named pipeline, or
+// 0 if it doesn't exist, optionally locking its row.
+func (t *team) pipelineConfigVersion(runner sq.BaseRunner, pipelineName string, forUpdate bool) (ConfigVersion, error) {
+	query := psql.Select("version").
+		From("pipelines").
+		Where(sq.Eq{
+			"name":    pipelineName,
+			"team_id": t.id,
+		})
+
+	if forUpdate {
+		query = query.Suffix("FOR UPDATE")
+	}
+
+	var version ConfigVersion
+	err := query.
+		RunWith(runner).
+		QueryRow().
+		Scan(&version)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+
+	return version, nil
+}
+
 func (t *team) SavePipeline(
 	pipelineName string,
 	config atc.Config,
 	from ConfigVersion,
 	pausedState PipelinePausedState,
+) (Pipeline, bool, error) {
+	var pipeline Pipeline
+	var created bool
+
+	// concurrent saves can fail to serialize, which is worth retrying as the
+	// whole save is done within the transaction
+	err := t.conn.TxRetry(func(tx Tx) error {
+		var err error
+		pipeline, created, err = t.savePipeline(tx, pipelineName, config, from, pausedState)
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return pipeline, created, nil
+}
+
+func (t *team) savePipeline(
+	tx Tx,
+	pipelineName string,
+	config atc.Config,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
 ) (Pipeline, bool, error) {
 	groupsPayload, err := json.Marshal(config.Groups)
 	if err != nil {
@@ -361,13 +683,6 @@ func (t *team) SavePipeline(
 	var created bool
 	var existingConfig int
 
-	tx, err := t.conn.Begin()
-	if err != nil {
-		return nil, false, err
-	}
-
-	defer Rollback(tx)
-
 	err = tx.QueryRow(`
 		SELECT COUNT(1)
 		FROM pipelines
@@ -379,7 +694,13 @@ func (t *team) SavePipeline(
 	}
 
 	var pipelineID int
+	var version ConfigVersion
 	if existingConfig == 0 {
+		err = checkPipelinesQuota(tx, t.id)
+		if err != nil {
+			return nil, false, err
+		}
+
 		if pausedState == PipelineNoChange {
 			pausedState = PipelinePaused
 		}
@@ -392,10 +713,13 @@ func (t *team) SavePipeline(
 				"ordering": sq.Expr("currval('pipelines_id_seq')"),
 				"paused":   pausedState.Bool(),
 				"team_id":  t.id,
+
+				"last_updated":   sq.Expr("clock_timestamp()"),
+				"config_updated": sq.Expr("clock_timestamp()"),
 			}).
-			Suffix("RETURNING id").
+			Suffix("RETURNING id, version").
 			RunWith(tx).
-			QueryRow().Scan(&pipelineID)
+			QueryRow().Scan(&pipelineID, &version)
 		if err != nil {
 			return nil, false, err
 		}
@@ -405,18 +729,20 @@ func (t *team) SavePipeline(
 		update := psql.Update("pipelines").
 			Set("groups", groupsPayload).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
+			Set("last_updated", sq.Expr("clock_timestamp()")).
+			Set("config_updated", sq.Expr("clock_timestamp()")).
 			Where(sq.Eq{
 				"name":    pipelineName,
 				"version": from,
 				"team_id": t.id,
 			}).
-			Suffix("RETURNING id")
+			Suffix("RETURNING id, version")
 
 		if pausedState != PipelineNoChange {
 			update = update.Set("paused", pausedState.Bool())
 		}
 
-		err = update.RunWith(tx).QueryRow().Scan(&pipelineID)
+		err = update.RunWith(tx).QueryRow().Scan(&pipelineID, &version)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				return nil, false, ErrConfigComparisonFailed
@@ -513,6 +839,11 @@ func (t *team) SavePipeline(
 		return nil, false, err
 	}
 
+	err = t.saveConfigVersion(tx, config, pipelineID, version)
+	if err != nil {
+		return nil, false, err
+	}
+
 	pipeline := newPipeline(t.conn, t.lockFactory)
 
 	err = scanPipeline(
@@ -526,11 +857,6 @@ func (t *team) SavePipeline(
 		return nil, false, err
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		return nil, false, err
-	}
-
 	return pipeline, created, nil
 }
 
@@ -558,7 +884,17 @@ func (t *team) Pipeline(pipelineName string) (Pipeline, bool, error) {
 }
 
 func (t *team) Pipelines() ([]Pipeline, error) {
-	rows, err := pipelinesQuery.
+	return t.pipelines(pipelinesQuery.Where(sq.Eq{"p.archived": false}))
+}
+
+// PipelinesIncludingArchived returns all of the team's pipelines, including
+// the archived ones hidden by Pipelines.
+func (t *team) PipelinesIncludingArchived() ([]Pipeline, error) {
+	return t.pipelines(pipelinesQuery)
+}
+
+func (t *team) pipelines(query sq.SelectBuilder) ([]Pipeline, error) {
+	rows, err := query.
 		Where(sq.Eq{
 			"team_id": t.id,
 		}).
@@ -580,8 +916,9 @@ func (t *team) Pipelines() ([]Pipeline, error) {
 func (t *team) PublicPipelines() ([]Pipeline, error) {
 	rows, err := pipelinesQuery.
 		Where(sq.Eq{
-			"team_id": t.id,
-			"public":  true,
+			"team_id":    t.id,
+			"public":     true,
+			"p.archived": false,
 		}).
 		OrderBy("team_id ASC", "ordering ASC").
 		RunWith(t.conn).
@@ -600,7 +937,10 @@ func (t *team) PublicPipelines() ([]Pipeline, error) {
 
 func (t *team) VisiblePipelines() ([]Pipeline, error) {
 	rows, err := pipelinesQuery.
-		Where(sq.Eq{"team_id": t.id}).
+		Where(sq.Eq{
+			"team_id":    t.id,
+			"p.archived": false,
+		}).
 		OrderBy("team_id ASC", "ordering ASC").
 		RunWith(t.conn).
 		Query()
@@ -615,7 +955,10 @@ func (t *team) VisiblePipelines() ([]Pipeline, error) {
 
 	rows, err = pipelinesQuery.
 		Where(sq.NotEq{"team_id": t.id}).
-		Where(sq.Eq{"public": true}).
+		Where(sq.Eq{
+			"public":     true,
+			"p.archived": false,
+		}).
 		OrderBy("team_id ASC", "ordering ASC").
 		RunWith(t.conn).
 		Query()
@@ -642,6 +985,7 @@ func (t *team) OrderPipelines(pipelineNames []string) error {
 	for i, name := range pipelineNames {
 		pipelineUpdate, err := psql.Update("pipelines").
 			Set("ordering", i).
+			Set("last_updated", sq.Expr("CASE WHEN ordering = ? THEN last_updated ELSE clock_timestamp() END", i)).
 			Where(sq.Eq{
 				"name":    name,
 				"team_id": t.id,
@@ -660,10 +1004,73 @@ func (t *team) OrderPipelines(pipelineNames []string) error {
 		}
 	}
 
+	// keep the relative order of pipelines left out, after the given ones
+	_, err = tx.Exec(`
+		UPDATE pipelines p
+		SET ordering = o.ordering,
+			last_updated = CASE WHEN p.ordering = o.ordering THEN p.last_updated ELSE clock_timestamp() END
+		FROM (
+			SELECT id, $3 + row_number() OVER (ORDER BY ordering, id) - 1 AS ordering
+			FROM pipelines
+			WHERE team_id = $1
+			AND NOT (name = ANY($2))
+		) o
+		WHERE p.id = o.id
+	`, t.id, pq.Array(pipelineNames), len(pipelineNames))
+	if err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
+// PipelineCount returns how many pipelines the team has, not counting archived
+// ones.
+func (t *team) PipelineCount() (int, error) {
+	var count int
+	err := psql.Select("COUNT(*)").
+		From("pipelines").
+		Where(sq.Eq{
+			"team_id":  t.id,
+			"archived": false,
+		}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// RunningBuildCount returns how many of the team's builds have started and
+// not yet finished. Pending builds are not counted.
+func (t *team) RunningBuildCount() (int, error) {
+	var count int
+	err := psql.Select("COUNT(*)").
+		From("builds").
+		Where(sq.Eq{
+			"team_id": t.id,
+			"status":  BuildStatusStarted,
+		}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (t *team) CreateOneOffBuild() (Build, error) {
+	return t.CreateOneOffBuildWithLabels(nil)
+}
+
+// CreateOneOffBuildWithLabels creates a one-off build carrying the given
+// labels, e.g. who submitted it, so that it can be told apart from others.
+func (t *team) CreateOneOffBuildWithLabels(labels map[string]string) (Build, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -671,12 +1078,24 @@ func (t *team) CreateOneOffBuild() (Build, error) {
 
 	defer Rollback(tx)
 
+	vals := map[string]interface{}{
+		"name":           sq.Expr("nextval('one_off_name')"),
+		"team_id":        t.id,
+		"status":         BuildStatusPending,
+		"trigger_source": BuildTriggerManual,
+	}
+
+	if len(labels) > 0 {
+		payload, err := json.Marshal(labels)
+		if err != nil {
+			return nil, err
+		}
+
+		vals["labels"] = payload
+	}
+
 	build := &build{conn: t.conn, lockFactory: t.lockFactory}
-	err = createBuild(tx, build, map[string]interface{}{
-		"name":    sq.Expr("nextval('one_off_name')"),
-		"team_id": t.id,
-		"status":  BuildStatusPending,
-	})
+	err = createBuild(tx, build, vals)
 	if err != nil {
 		return nil, err
 	}
@@ -709,20 +1128,21 @@ func (t *team) CreateStartedBuild(plan atc.Plan) (Build, error) {
 
 	build := &build{conn: t.conn, lockFactory: t.lockFactory}
 	err = createBuild(tx, build, map[string]interface{}{
-		"name":         sq.Expr("nextval('one_off_name')"),
-		"team_id":      t.id,
-		"status":       BuildStatusStarted,
-		"start_time":   sq.Expr("now()"),
-		"schema":       schema,
-		"private_plan": encryptedPlan,
-		"public_plan":  plan.Public(),
-		"nonce":        nonce,
+		"name":           sq.Expr("nextval('one_off_name')"),
+		"team_id":        t.id,
+		"status":         BuildStatusStarted,
+		"start_time":     sq.Expr("now()"),
+		"schema":         schema,
+		"private_plan":   encryptedPlan,
+		"public_plan":    plan.Public(),
+		"nonce":          nonce,
+		"trigger_source": BuildTriggerManual,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	err = build.saveEvent(tx, event.Status{
+	eventID, err := build.saveEvent(tx, event.Status{
 		Status: atc.StatusStarted,
 		Time:   build.StartTime().Unix(),
 	})
@@ -739,7 +1159,7 @@ func (t *team) CreateStartedBuild(plan atc.Plan) (Build, error) {
 		return nil, err
 	}
 
-	if err = t.conn.Bus().Notify(buildEventsChannel(build.id)); err != nil {
+	if err = build.notifyEvents(eventID); err != nil {
 		return nil, err
 	}
 
@@ -761,6 +1181,70 @@ func (t *team) Builds(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithPagination(buildsQuery.Where(sq.Eq{"t.id": t.id}), minMaxIdQuery, page, t.conn, t.lockFactory)
 }
 
+// BuildsWithLabel returns the team's builds labelled with the given key. If
+// value is not empty, the label must also have that value.
+func (t *team) BuildsWithLabel(key string, value string, page Page) ([]Build, Pagination, error) {
+	var labelFilter sq.Sqlizer = sq.Expr("b.labels ?? ?", key)
+	if value != "" {
+		payload, err := json.Marshal(map[string]string{key: value})
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		labelFilter = sq.Expr("b.labels @> ?", payload)
+	}
+
+	return getBuildsWithPagination(
+		buildsQuery.Where(sq.Eq{"t.id": t.id}).Where(labelFilter),
+		minMaxIdQuery.Where(sq.Eq{"b.team_id": t.id}).Where(labelFilter),
+		page,
+		t.conn,
+		t.lockFactory,
+	)
+}
+
+// BuildsBetween returns the team's one-off and job builds created at or after
+// start and before end, oldest first. If limit is not zero, at most that many
+// builds are returned.
+func (t *team) BuildsBetween(start time.Time, end time.Time, limit int) ([]Build, error) {
+	query := buildsQuery.
+		Where(sq.Eq{"b.team_id": t.id}).
+		Where(sq.GtOrEq{"b.create_time": start}).
+		Where(sq.Lt{"b.create_time": end}).
+		OrderBy("b.create_time ASC", "b.id ASC")
+
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+
+	rows, err := query.
+		RunWith(t.conn.ReadReplica()).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	builds := []Build{}
+	for rows.Next() {
+		build := &build{conn: t.conn, lockFactory: t.lockFactory}
+		err := scanBuild(build, rows, t.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		builds = append(builds, build)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return builds, nil
+}
+
 func (t *team) SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -1034,6 +1518,27 @@ func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string
 	return swallowUniqueViolation(err)
 }
 
+func (t *team) saveConfigVersion(tx Tx, config atc.Config, pipelineID int, version ConfigVersion) error {
+	configPayload, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	es := t.conn.EncryptionStrategy()
+	encryptedPayload, nonce, err := es.Encrypt(configPayload)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("pipeline_configs").
+		Columns("pipeline_id", "version", "config", "nonce").
+		Values(pipelineID, version, encryptedPayload, nonce).
+		RunWith(tx).
+		Exec()
+
+	return err
+}
+
 func (t *team) registerSerialGroup(tx Tx, jobName, serialGroup string, pipelineID int) error {
 	_, err := tx.Exec(`
     INSERT INTO jobs_serial_groups (serial_group, job_id) VALUES
@@ -1187,7 +1692,7 @@ func (t *team) findContainer(whereClause sq.Sqlizer) (CreatingContainer, Created
 
 func scanPipeline(p *pipeline, scan scannable) error {
 	var groups sql.NullString
-	err := scan.Scan(&p.id, &p.name, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public)
+	err := scan.Scan(&p.id, &p.name, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &p.lastUpdated, &p.configUpdated)
 	if err != nil {
 		return err
 	}
@@ -17,10 +17,21 @@ type TeamFactory interface {
 	CreateTeam(atc.Team) (Team, error)
 	FindTeam(string) (Team, bool, error)
 	GetTeams() ([]Team, error)
+	GetTeamSummaries() ([]TeamSummary, error)
 	GetByID(teamID int) Team
 	CreateDefaultTeamIfNotExists() (Team, error)
 }
 
+// TeamSummary is a team along with how many pipelines it has, not counting
+// archived ones.
+type TeamSummary struct {
+	Team          Team
+	PipelineCount int
+
+	// Default is true for the team created by CreateDefaultTeamIfNotExists.
+	Default bool
+}
+
 type teamFactory struct {
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -137,6 +148,45 @@ func (factory *teamFactory) GetTeams() ([]Team, error) {
 	return teams, nil
 }
 
+// GetTeamSummaries returns every team with its pipeline count, using a single
+// query regardless of how many teams there are.
+func (factory *teamFactory) GetTeamSummaries() ([]TeamSummary, error) {
+	rows, err := psql.Select("t.id, t.name, t.admin, t.auth, COUNT(p.id)").
+		From("teams t").
+		LeftJoin("pipelines p ON p.team_id = t.id AND NOT p.archived").
+		GroupBy("t.id").
+		OrderBy("t.id ASC").
+		RunWith(factory.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+	defer Close(rows)
+
+	summaries := []TeamSummary{}
+
+	for rows.Next() {
+		team := &team{
+			conn:        factory.conn,
+			lockFactory: factory.lockFactory,
+		}
+
+		var pipelineCount int
+		err = factory.scanTeam(team, rows, &pipelineCount)
+		if err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, TeamSummary{
+			Team:          team,
+			PipelineCount: pipelineCount,
+			Default:       strings.EqualFold(team.Name(), atc.DefaultTeamName),
+		})
+	}
+
+	return summaries, nil
+}
+
 func (factory *teamFactory) CreateDefaultTeamIfNotExists() (Team, error) {
 	_, err := psql.Update("teams").
 		Set("admin", true).
@@ -165,15 +215,17 @@ func (factory *teamFactory) CreateDefaultTeamIfNotExists() (Team, error) {
 	)
 }
 
-func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
+func (factory *teamFactory) scanTeam(t *team, rows scannable, extra ...interface{}) error {
 	var providerAuth sql.NullString
 
-	err := rows.Scan(
+	dest := append([]interface{}{
 		&t.id,
 		&t.name,
 		&t.admin,
 		&providerAuth,
-	)
+	}, extra...)
+
+	err := rows.Scan(dest...)
 
 	if providerAuth.Valid {
 		err = json.Unmarshal([]byte(providerAuth.String), &t.auth)
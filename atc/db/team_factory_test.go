@@ -110,7 +110,7 @@ var _ = Describe("Team Factory", func() {
 		)
 
 		BeforeEach(func() {
-			err := defaultTeam.Delete()
+			_, err := defaultTeam.Delete()
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -156,4 +156,45 @@ var _ = Describe("Team Factory", func() {
 			})
 		})
 	})
+
+	Describe("GetTeamSummaries", func() {
+		var (
+			mainTeam  db.Team
+			otherTeam db.Team
+		)
+
+		BeforeEach(func() {
+			var err error
+			mainTeam, err = teamFactory.CreateDefaultTeamIfNotExists()
+			Expect(err).ToNot(HaveOccurred())
+
+			otherTeam, err = teamFactory.CreateTeam(atcTeam)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = otherTeam.SavePipeline("some-pipeline", atc.Config{}, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = otherTeam.SavePipeline("some-other-pipeline", atc.Config{}, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns every team with its pipeline count", func() {
+			summaries, err := teamFactory.GetTeamSummaries()
+			Expect(err).ToNot(HaveOccurred())
+
+			counts := map[string]int{}
+			defaults := map[string]bool{}
+			for _, summary := range summaries {
+				counts[summary.Team.Name()] = summary.PipelineCount
+				defaults[summary.Team.Name()] = summary.Default
+			}
+
+			Expect(counts).To(HaveKeyWithValue(mainTeam.Name(), 0))
+			Expect(counts).To(HaveKeyWithValue(otherTeam.Name(), 2))
+			Expect(counts).To(HaveKeyWithValue(defaultTeam.Name(), 1))
+
+			Expect(defaults).To(HaveKeyWithValue(mainTeam.Name(), true))
+			Expect(defaults).To(HaveKeyWithValue(otherTeam.Name(), false))
+		})
+	})
 })
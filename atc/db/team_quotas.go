@@ -0,0 +1,164 @@
+package db
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// Quotas limit how much of the cluster a team may use. A zero limit means
+// unlimited, which is the default.
+type Quotas struct {
+	// MaxPipelines limits how many pipelines the team may have, not counting
+	// archived ones.
+	MaxPipelines int
+
+	// MaxRunningBuilds limits how many of the team's builds may be pending or
+	// started at once.
+	MaxRunningBuilds int
+}
+
+type ErrQuotaExceeded struct {
+	Quota string
+	Limit int
+}
+
+func (e ErrQuotaExceeded) Error() string {
+	return fmt.Sprintf("team quota exceeded: limited to %d %s", e.Limit, e.Quota)
+}
+
+func (t *team) Quotas() (Quotas, error) {
+	var quotas Quotas
+	err := psql.Select("max_pipelines, max_running_builds").
+		From("teams").
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&quotas.MaxPipelines, &quotas.MaxRunningBuilds)
+	if err != nil {
+		return Quotas{}, err
+	}
+
+	return quotas, nil
+}
+
+// SetQuotas replaces the team's quotas. Lowering a limit below the team's
+// current usage doesn't remove anything; it only prevents creating more.
+func (t *team) SetQuotas(quotas Quotas) error {
+	result, err := psql.Update("teams").
+		Set("max_pipelines", quotas.MaxPipelines).
+		Set("max_running_builds", quotas.MaxRunningBuilds).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
+// checkPipelinesQuota returns ErrQuotaExceeded if the team can't have another
+// pipeline.
+func checkPipelinesQuota(tx Tx, teamID int) error {
+	quotas, err := lockTeamQuotas(tx, teamID, func(quotas Quotas) int {
+		return quotas.MaxPipelines
+	})
+	if err != nil || quotas.MaxPipelines == 0 {
+		return err
+	}
+
+	var pipelines int
+	err = psql.Select("COUNT(*)").
+		From("pipelines").
+		Where(sq.Eq{
+			"team_id":  teamID,
+			"archived": false,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&pipelines)
+	if err != nil {
+		return err
+	}
+
+	if pipelines >= quotas.MaxPipelines {
+		return ErrQuotaExceeded{Quota: "pipelines", Limit: quotas.MaxPipelines}
+	}
+
+	return nil
+}
+
+// checkRunningBuildsQuota returns ErrQuotaExceeded if the team can't have
+// another build pending or started.
+func checkRunningBuildsQuota(tx Tx, teamID int) error {
+	quotas, err := lockTeamQuotas(tx, teamID, func(quotas Quotas) int {
+		return quotas.MaxRunningBuilds
+	})
+	if err != nil || quotas.MaxRunningBuilds == 0 {
+		return err
+	}
+
+	var running int
+	err = psql.Select("COUNT(*)").
+		From("builds").
+		Where(sq.Eq{
+			"team_id": teamID,
+			"status":  []BuildStatus{BuildStatusPending, BuildStatusStarted},
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&running)
+	if err != nil {
+		return err
+	}
+
+	if running >= quotas.MaxRunningBuilds {
+		return ErrQuotaExceeded{Quota: "running builds", Limit: quotas.MaxRunningBuilds}
+	}
+
+	return nil
+}
+
+// lockTeamQuotas reads the team's quotas. If the one returned by limit is set,
+// the team's row is locked for the rest of the transaction so that concurrent
+// transactions can't both take the last slot. Teams without that limit are
+// never locked.
+func lockTeamQuotas(tx Tx, teamID int, limit func(Quotas) int) (Quotas, error) {
+	query := psql.Select("max_pipelines, max_running_builds").
+		From("teams").
+		Where(sq.Eq{"id": teamID})
+
+	var quotas Quotas
+	err := query.
+		RunWith(tx).
+		QueryRow().
+		Scan(&quotas.MaxPipelines, &quotas.MaxRunningBuilds)
+	if err != nil {
+		return Quotas{}, err
+	}
+
+	if limit(quotas) == 0 {
+		return quotas, nil
+	}
+
+	err = query.
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&quotas.MaxPipelines, &quotas.MaxRunningBuilds)
+	if err != nil {
+		return Quotas{}, err
+	}
+
+	return quotas, nil
+}
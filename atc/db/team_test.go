@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cloudfoundry/bosh-cli/director/template"
@@ -40,6 +42,7 @@ var _ = Describe("Team", func() {
 	Describe("Delete", func() {
 		var err error
 		var otherTeamPipeline db.Pipeline
+		var deletion db.TeamDeletion
 
 		BeforeEach(func() {
 			otherTeamPipeline, _, err = otherTeam.SavePipeline("fake-pipeline", atc.Config{
@@ -49,8 +52,43 @@ var _ = Describe("Team", func() {
 			}, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
-			err = otherTeam.Delete()
+			build, err := otherTeamPipeline.CreateOneOffBuild()
 			Expect(err).ToNot(HaveOccurred())
+			Expect(build.SaveEvent(event.StartTask{})).To(Succeed())
+
+			_, err = otherTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			deletion, err = otherTeam.Delete()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns how many pipelines and builds were removed", func() {
+			Expect(deletion).To(Equal(db.TeamDeletion{
+				Pipelines: 1,
+				Builds:    2,
+			}))
+		})
+
+		It("removes the team's builds", func() {
+			var count int
+			err := dbConn.QueryRow("SELECT COUNT(*) FROM builds WHERE team_id = $1", otherTeam.ID()).Scan(&count)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+
+		Context("when the team is the default team", func() {
+			It("refuses to delete it", func() {
+				mainTeam, err := teamFactory.CreateDefaultTeamIfNotExists()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = mainTeam.Delete()
+				Expect(err).To(Equal(db.ErrCannotDeleteDefaultTeam))
+
+				_, found, err := teamFactory.FindTeam(atc.DefaultTeamName)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
 		})
 
 		It("deletes the team", func() {
@@ -76,6 +114,11 @@ var _ = Describe("Team", func() {
 	})
 
 	Describe("Rename", func() {
+		BeforeEach(func() {
+			_, _, err := team.SavePipeline("some-pipeline", atc.Config{}, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		JustBeforeEach(func() {
 			Expect(team.Rename("oopsies")).To(Succeed())
 		})
@@ -84,6 +127,38 @@ var _ = Describe("Team", func() {
 			_, found, _ := teamFactory.FindTeam("oopsies")
 			Expect(found).To(BeTrue())
 		})
+
+		It("keeps the team's pipelines", func() {
+			renamedTeam, found, err := teamFactory.FindTeam("oopsies")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			pipeline, found, err := renamedTeam.Pipeline("some-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pipeline.TeamName()).To(Equal("oopsies"))
+		})
+	})
+
+	Describe("Rename to a taken name", func() {
+		It("returns ErrTeamNameTaken", func() {
+			err := team.Rename("SOME-OTHER-TEAM")
+			Expect(err).To(Equal(db.ErrTeamNameTaken{Name: "SOME-OTHER-TEAM"}))
+
+			_, found, err := teamFactory.FindTeam("some-team")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+
+	Describe("Rename the default team", func() {
+		It("returns ErrCannotRenameDefaultTeam", func() {
+			mainTeam, err := teamFactory.CreateDefaultTeamIfNotExists()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = mainTeam.Rename("not-main")
+			Expect(err).To(Equal(db.ErrCannotRenameDefaultTeam))
+		})
 	})
 
 	Describe("SaveWorker", func() {
@@ -297,7 +372,7 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 
 			metaContainers = make(map[db.ContainerMetadata][]db.Container)
@@ -423,7 +498,7 @@ var _ = Describe("Team", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 
 				firstContainerCreating, err = defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-job"), defaultTeam.ID()), db.ContainerMetadata{Type: "task", StepName: "some-task"})
@@ -654,7 +729,7 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 
 			creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-job"), defaultTeam.ID()), db.ContainerMetadata{Type: "task", StepName: "some-task"})
@@ -874,6 +949,24 @@ var _ = Describe("Team", func() {
 			It("returns the pipelines", func() {
 				Expect(pipelines).To(Equal([]db.Pipeline{pipeline1, pipeline2}))
 			})
+
+			Context("when a pipeline is archived", func() {
+				BeforeEach(func() {
+					Expect(pipeline2.Archive()).To(Succeed())
+				})
+
+				It("leaves it out", func() {
+					Expect(pipelines).To(Equal([]db.Pipeline{pipeline1}))
+				})
+
+				It("is still returned by PipelinesIncludingArchived", func() {
+					allPipelines, err := team.PipelinesIncludingArchived()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(allPipelines).To(HaveLen(2))
+					Expect(allPipelines[1].Name()).To(Equal("fake-pipeline-two"))
+					Expect(allPipelines[1].Archived()).To(BeTrue())
+				})
+			})
 		})
 		Context("when the team has no configured pipelines", func() {
 			It("returns no pipelines", func() {
@@ -992,6 +1085,124 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("Quotas", func() {
+		It("defaults to unlimited", func() {
+			quotas, err := team.Quotas()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(quotas).To(Equal(db.Quotas{}))
+		})
+
+		Context("when the team is limited to one pipeline", func() {
+			BeforeEach(func() {
+				err := team.SetQuotas(db.Quotas{MaxPipelines: 1})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = team.SavePipeline("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the quotas", func() {
+				quotas, err := team.Quotas()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(quotas).To(Equal(db.Quotas{MaxPipelines: 1}))
+			})
+
+			It("refuses to create another pipeline", func() {
+				_, _, err := team.SavePipeline("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+				Expect(err).To(Equal(db.ErrQuotaExceeded{Quota: "pipelines", Limit: 1}))
+			})
+
+			It("still allows updating the existing pipeline", func() {
+				pipeline, found, err := team.Pipeline("pipeline-name-a")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, _, err = team.SavePipeline("pipeline-name-a", atc.Config{}, pipeline.ConfigVersion(), db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not affect other teams", func() {
+				_, _, err := otherTeam.SavePipeline("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when the team is limited to one running build", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				err := team.SetQuotas(db.Quotas{MaxRunningBuilds: 1})
+				Expect(err).ToNot(HaveOccurred())
+
+				build, err = team.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("refuses to create another build", func() {
+				_, err := team.CreateOneOffBuild()
+				Expect(err).To(Equal(db.ErrQuotaExceeded{Quota: "running builds", Limit: 1}))
+			})
+
+			It("allows another build once the first has finished", func() {
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = team.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("PipelineCount", func() {
+		BeforeEach(func() {
+			_, _, err := team.SavePipeline("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			archivedPipeline, _, err := team.SavePipeline("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = archivedPipeline.Archive()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = otherTeam.SavePipeline("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("counts the team's unarchived pipelines", func() {
+			count, err := team.PipelineCount()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(1))
+		})
+	})
+
+	Describe("RunningBuildCount", func() {
+		BeforeEach(func() {
+			for _, t := range []db.Team{team, team, otherTeam} {
+				build, err := t.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				started, err := build.Start(atc.Plan{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeTrue())
+			}
+
+			finishedBuild, err := team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = finishedBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("counts the team's started builds", func() {
+			count, err := team.RunningBuildCount()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+	})
+
 	Describe("OrderPipelines", func() {
 		var pipeline1 db.Pipeline
 		var pipeline2 db.Pipeline
@@ -1032,11 +1243,51 @@ var _ = Describe("Team", func() {
 			Expect(otherTeamOrderedPipelines[1].ID()).To(Equal(otherPipeline2.ID()))
 		})
 
+		Context("when only some of the pipelines are given", func() {
+			var pipeline3 db.Pipeline
+			var pipeline4 db.Pipeline
+
+			BeforeEach(func() {
+				var err error
+				pipeline3, _, err = team.SavePipeline("pipeline-name-c", atc.Config{}, 0, db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+				pipeline4, _, err = team.SavePipeline("pipeline-name-d", atc.Config{}, 0, db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = team.OrderPipelines([]string{"pipeline-name-d", "pipeline-name-c", "pipeline-name-b", "pipeline-name-a"})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("puts the given pipelines first and keeps the relative order of the rest", func() {
+				err := team.OrderPipelines([]string{"pipeline-name-a"})
+				Expect(err).ToNot(HaveOccurred())
+
+				orderedPipelines, err := team.Pipelines()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(orderedPipelines).To(HaveLen(4))
+				Expect(orderedPipelines[0].ID()).To(Equal(pipeline1.ID()))
+				Expect(orderedPipelines[1].ID()).To(Equal(pipeline4.ID()))
+				Expect(orderedPipelines[2].ID()).To(Equal(pipeline3.ID()))
+				Expect(orderedPipelines[3].ID()).To(Equal(pipeline2.ID()))
+			})
+		})
+
 		Context("when pipeline does not exist", func() {
 			It("returns error ", func() {
 				err := otherTeam.OrderPipelines([]string{"pipeline-name-a", "pipeline-does-not-exist"})
 				Expect(err).To(HaveOccurred())
 			})
+
+			It("does not change the order", func() {
+				err := team.OrderPipelines([]string{"pipeline-name-b", "pipeline-does-not-exist"})
+				Expect(err).To(HaveOccurred())
+
+				orderedPipelines, err := team.Pipelines()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(orderedPipelines).To(HaveLen(2))
+				Expect(orderedPipelines[0].ID()).To(Equal(pipeline1.ID()))
+				Expect(orderedPipelines[1].ID()).To(Equal(pipeline2.ID()))
+			})
 		})
 	})
 
@@ -1062,6 +1313,60 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("CreateOneOffBuildWithLabels", func() {
+		var (
+			labelledBuild   db.Build
+			unlabelledBuild db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			labelledBuild, err = team.CreateOneOffBuildWithLabels(map[string]string{
+				"user":    "some-user",
+				"command": "fly execute",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = team.CreateOneOffBuildWithLabels(map[string]string{
+				"user": "some-other-user",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			unlabelledBuild, err = team.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("saves the labels with the build", func() {
+			Expect(labelledBuild.Labels()).To(Equal(map[string]string{
+				"user":    "some-user",
+				"command": "fly execute",
+			}))
+
+			found, err := labelledBuild.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(labelledBuild.Labels()).To(HaveKeyWithValue("user", "some-user"))
+
+			Expect(unlabelledBuild.Labels()).To(BeEmpty())
+		})
+
+		It("can list builds with a label", func() {
+			builds, _, err := team.BuildsWithLabel("user", "", db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(2))
+
+			builds, _, err = team.BuildsWithLabel("user", "some-user", db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(labelledBuild.ID()))
+
+			builds, _, err = team.BuildsWithLabel("command", "", db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(labelledBuild.ID()))
+		})
+	})
+
 	Describe("CreateStartedBuild", func() {
 		var (
 			plan         atc.Plan
@@ -1172,7 +1477,7 @@ var _ = Describe("Team", func() {
 				Expect(found).To(BeTrue())
 
 				for i := 3; i < 5; i++ {
-					build, err := job.CreateBuild()
+					build, err := job.CreateBuild(db.BuildTriggerManual)
 					Expect(err).ToNot(HaveOccurred())
 					allBuilds[i] = build
 					pipelineBuilds[i-3] = build
@@ -1323,7 +1628,7 @@ var _ = Describe("Team", func() {
 			Expect(found).To(BeTrue())
 
 			for i := range builds {
-				builds[i], err = job.CreateBuild()
+				builds[i], err = job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 
 				buildStart := time.Date(2020, 11, i+1, 0, 0, 0, 0, time.UTC)
@@ -1397,6 +1702,73 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("BuildsBetween", func() {
+		var builds []db.Build
+
+		BeforeEach(func() {
+			config := atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "some-job"},
+				},
+			}
+			pipeline, _, err := team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			job, found, err := pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			builds = []db.Build{}
+			for i := 0; i < 4; i++ {
+				var build db.Build
+				if i%2 == 0 {
+					build, err = job.CreateBuild(db.BuildTriggerManual)
+				} else {
+					build, err = team.CreateOneOffBuild()
+				}
+				Expect(err).ToNot(HaveOccurred())
+
+				// create them out of id order to check the ordering
+				createTime := time.Date(2020, 11, 4-i, 0, 0, 0, 0, time.UTC)
+				_, err = dbConn.Exec("UPDATE builds SET create_time = $1 WHERE id = $2", createTime, build.ID())
+				Expect(err).NotTo(HaveOccurred())
+
+				builds = append(builds, build)
+			}
+
+			_, err = otherTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		buildIDs := func(builds []db.Build) []int {
+			ids := []int{}
+			for _, build := range builds {
+				ids = append(ids, build.ID())
+			}
+			return ids
+		}
+
+		It("returns the team's builds created in the window, oldest first", func() {
+			found, err := team.BuildsBetween(
+				time.Date(2020, 11, 2, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 11, 4, 0, 0, 0, 0, time.UTC),
+				0,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buildIDs(found)).To(Equal([]int{builds[2].ID(), builds[1].ID()}))
+		})
+
+		It("limits the number of builds returned", func() {
+			found, err := team.BuildsBetween(
+				time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 11, 5, 0, 0, 0, 0, time.UTC),
+				3,
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buildIDs(found)).To(Equal([]int{builds[3].ID(), builds[2].ID(), builds[1].ID()}))
+		})
+	})
+
 	Describe("Builds", func() {
 		var (
 			expectedBuilds                              []db.Build
@@ -1428,11 +1800,11 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err = job.CreateBuild()
+			build, err = job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err = job.CreateBuild()
+			secondBuild, err = job.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
@@ -1440,7 +1812,7 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			thirdBuild, err = someOtherJob.CreateBuild()
+			thirdBuild, err = someOtherJob.CreateBuild(db.BuildTriggerManual)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, thirdBuild)
 		})
@@ -1563,6 +1935,159 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("TransferPipeline", func() {
+		var (
+			pipeline db.Pipeline
+			build    db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			pipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "some-job"},
+				},
+			}, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			job, found, err := pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			build, err = job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("moves the pipeline and its builds to the other team", func() {
+			err := team.TransferPipeline("some-pipeline", otherTeam.Name())
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := team.Pipeline("some-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			transferred, found, err := otherTeam.Pipeline("some-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(transferred.ID()).To(Equal(pipeline.ID()))
+
+			found, err = build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.TeamID()).To(Equal(otherTeam.ID()))
+		})
+
+		Context("when the other team has a pipeline with the same name", func() {
+			BeforeEach(func() {
+				_, _, err := otherTeam.SavePipeline("some-pipeline", atc.Config{}, 0, db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns ErrPipelineNameTaken and leaves the pipeline alone", func() {
+				err := team.TransferPipeline("some-pipeline", otherTeam.Name())
+				Expect(err).To(Equal(db.ErrPipelineNameTaken{Name: "some-pipeline"}))
+
+				_, found, err := team.Pipeline("some-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the other team does not exist", func() {
+			It("returns ErrTeamNotFound", func() {
+				err := team.TransferPipeline("some-pipeline", "bogus-team")
+				Expect(err).To(Equal(db.ErrTeamNotFound))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			It("returns ErrPipelineNotFound", func() {
+				err := team.TransferPipeline("bogus-pipeline", otherTeam.Name())
+				Expect(err).To(Equal(db.ErrPipelineNotFound))
+			})
+		})
+	})
+
+	Describe("ClonePipeline", func() {
+		var (
+			source db.Pipeline
+			config atc.Config
+		)
+
+		BeforeEach(func() {
+			config = atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						Plan: atc.PlanSequence{{Get: "some-resource"}},
+					},
+				},
+			}
+
+			var err error
+			source, _, err = team.SavePipeline("source-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("creates a paused pipeline with the same config", func() {
+			clone, err := team.ClonePipeline("source-pipeline", "cloned-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clone.Name()).To(Equal("cloned-pipeline"))
+			Expect(clone.ID()).ToNot(Equal(source.ID()))
+			Expect(clone.Paused()).To(BeTrue())
+
+			clonedConfig, err := clone.Config()
+			Expect(err).ToNot(HaveOccurred())
+
+			sourceConfig, err := source.Config()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(clonedConfig).To(Equal(sourceConfig))
+		})
+
+		It("does not copy the build history", func() {
+			job, found, err := source.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, err = job.CreateBuild(db.BuildTriggerManual)
+			Expect(err).ToNot(HaveOccurred())
+
+			clone, err := team.ClonePipeline("source-pipeline", "cloned-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+
+			builds, _, err := clone.Builds(db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+
+		Context("when the new name is already taken", func() {
+			BeforeEach(func() {
+				_, _, err := team.SavePipeline("cloned-pipeline", config, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns an error", func() {
+				_, err := team.ClonePipeline("source-pipeline", "cloned-pipeline")
+				Expect(err).To(Equal(db.ErrPipelineNameTaken{Name: "cloned-pipeline"}))
+			})
+		})
+
+		Context("when the source pipeline does not exist", func() {
+			It("returns an error", func() {
+				_, err := team.ClonePipeline("bogus-pipeline", "cloned-pipeline")
+				Expect(err).To(Equal(db.ErrPipelineNotFound))
+			})
+		})
+	})
+
 	Describe("SavePipeline", func() {
 		type SerialGroup struct {
 			JobID int
@@ -2576,6 +3101,139 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("SaveConfigCAS", func() {
+		var (
+			firstConfig  atc.Config
+			secondConfig atc.Config
+		)
+
+		BeforeEach(func() {
+			firstConfig = atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "first-job"},
+				},
+			}
+
+			secondConfig = atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "second-job"},
+				},
+			}
+		})
+
+		It("creates the pipeline when expecting version 0", func() {
+			pipeline, previous, err := team.SaveConfigCAS("some-pipeline", firstConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipeline.Name()).To(Equal("some-pipeline"))
+			Expect(previous).To(Equal(db.ConfigVersion(0)))
+		})
+
+		It("fails without creating the pipeline when expecting another version", func() {
+			_, current, err := team.SaveConfigCAS("some-pipeline", firstConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+			Expect(current).To(Equal(db.ConfigVersion(0)))
+
+			_, found, err := team.Pipeline("some-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("lets only one of several concurrent creates through", func() {
+			var (
+				wg        sync.WaitGroup
+				succeeded int32
+			)
+
+			for i := 0; i < 5; i++ {
+				wg.Add(1)
+
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					_, current, err := team.SaveConfigCAS("some-pipeline", firstConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+					if err == nil {
+						atomic.AddInt32(&succeeded, 1)
+					} else {
+						Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+						Expect(current).ToNot(BeZero())
+					}
+				}()
+			}
+
+			wg.Wait()
+
+			Expect(succeeded).To(Equal(int32(1)))
+		})
+
+		Context("when the pipeline exists", func() {
+			var pipeline db.Pipeline
+
+			BeforeEach(func() {
+				var err error
+				pipeline, _, err = team.SaveConfigCAS("some-pipeline", firstConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the version it replaced", func() {
+				saved, previous, err := team.SaveConfigCAS("some-pipeline", secondConfig, pipeline.ConfigVersion(), db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(previous).To(Equal(pipeline.ConfigVersion()))
+				Expect(saved.ConfigVersion()).To(BeNumerically(">", pipeline.ConfigVersion()))
+
+				config, err := saved.Config()
+				Expect(err).ToNot(HaveOccurred())
+				expectConfigsEqual(config, secondConfig)
+			})
+
+			It("fails with the current version when the pipeline is not at the expected version", func() {
+				_, current, err := team.SaveConfigCAS("some-pipeline", secondConfig, pipeline.ConfigVersion()-1, db.PipelineNoChange)
+				Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+				Expect(current).To(Equal(pipeline.ConfigVersion()))
+
+				saved, _, err := team.SaveConfigCAS("some-pipeline", secondConfig, pipeline.ConfigVersion(), db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, current, err = team.SaveConfigCAS("some-pipeline", firstConfig, pipeline.ConfigVersion(), db.PipelineNoChange)
+				Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+				Expect(current).To(Equal(saved.ConfigVersion()))
+			})
+
+			It("fails when expecting the pipeline not to exist", func() {
+				_, current, err := team.SaveConfigCAS("some-pipeline", secondConfig, db.ConfigVersion(0), db.PipelineNoChange)
+				Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+				Expect(current).To(Equal(pipeline.ConfigVersion()))
+			})
+
+			It("lets only one of several concurrent saves at the same version through", func() {
+				var (
+					wg        sync.WaitGroup
+					succeeded int32
+				)
+
+				for i := 0; i < 5; i++ {
+					wg.Add(1)
+
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+
+						_, _, err := team.SaveConfigCAS("some-pipeline", secondConfig, pipeline.ConfigVersion(), db.PipelineNoChange)
+						if err == nil {
+							atomic.AddInt32(&succeeded, 1)
+						} else {
+							Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+						}
+					}()
+				}
+
+				wg.Wait()
+
+				Expect(succeeded).To(Equal(int32(1)))
+			})
+		})
+	})
+
 	Describe("FindCheckContainers", func() {
 		var (
 			fakeSecretManager *credsfakes.FakeSecrets
@@ -2769,7 +3427,7 @@ var _ = Describe("Team", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 
 				creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-job"), defaultTeam.ID()), db.ContainerMetadata{Type: "task", StepName: "some-task"})
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	maxTxAttempts         = 5
+	initialTxRetryBackoff = 10 * time.Millisecond
+)
+
+// RetryTx runs fn in a transaction on the given connection and commits it. If
+// either fails because the transaction could not be serialized or deadlocked
+// with another one, the whole transaction is retried with an increasing
+// backoff, up to a fixed number of attempts.
+//
+// fn may be called more than once, so it must not have any side effects
+// outside of the transaction it is given.
+func RetryTx(conn Conn, fn func(Tx) error) error {
+	backoff := initialTxRetryBackoff
+
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		err = runTx(conn, fn)
+		if !shouldRetryTx(err) {
+			return err
+		}
+
+		if attempt < maxTxAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	return err
+}
+
+func runTx(conn Conn, fn func(Tx) error) error {
+	tx, err := conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func shouldRetryTx(err error) bool {
+	if pqErr, ok := err.(*pq.Error); ok {
+		return pqErr.Code == "40001" || pqErr.Code == "40P01"
+	}
+
+	return false
+}
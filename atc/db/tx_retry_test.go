@@ -0,0 +1,96 @@
+package db_test
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RetryTx", func() {
+	var (
+		fakeConn *dbfakes.FakeConn
+		fakeTx   *dbfakes.FakeTx
+
+		attempts int
+		fnErrs   []error
+
+		retryErr error
+	)
+
+	BeforeEach(func() {
+		fakeConn = new(dbfakes.FakeConn)
+		fakeTx = new(dbfakes.FakeTx)
+		fakeConn.BeginReturns(fakeTx, nil)
+
+		attempts = 0
+		fnErrs = nil
+	})
+
+	JustBeforeEach(func() {
+		retryErr = db.RetryTx(fakeConn, func(tx db.Tx) error {
+			attempts++
+
+			if len(fnErrs) == 0 {
+				return nil
+			}
+
+			err := fnErrs[0]
+			fnErrs = fnErrs[1:]
+			return err
+		})
+	})
+
+	Context("when the transaction succeeds", func() {
+		It("runs it once and commits", func() {
+			Expect(retryErr).NotTo(HaveOccurred())
+			Expect(attempts).To(Equal(1))
+			Expect(fakeTx.CommitCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the transaction fails with a serialization failure", func() {
+		BeforeEach(func() {
+			fnErrs = []error{
+				&pq.Error{Code: "40001"},
+				&pq.Error{Code: "40P01"},
+			}
+		})
+
+		It("retries until it succeeds", func() {
+			Expect(retryErr).NotTo(HaveOccurred())
+			Expect(attempts).To(Equal(3))
+			Expect(fakeTx.CommitCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the commit keeps failing with a serialization failure", func() {
+		BeforeEach(func() {
+			fakeTx.CommitReturns(&pq.Error{Code: "40001"})
+		})
+
+		It("gives up after a bounded number of attempts", func() {
+			Expect(retryErr).To(Equal(&pq.Error{Code: "40001"}))
+			Expect(attempts).To(Equal(5))
+		})
+	})
+
+	Context("when the transaction fails with any other error", func() {
+		disaster := errors.New("disaster")
+
+		BeforeEach(func() {
+			fnErrs = []error{disaster}
+		})
+
+		It("does not retry", func() {
+			Expect(retryErr).To(Equal(disaster))
+			Expect(attempts).To(Equal(1))
+			Expect(fakeTx.CommitCallCount()).To(BeZero())
+			Expect(fakeTx.RollbackCallCount()).To(Equal(1))
+		})
+	})
+})
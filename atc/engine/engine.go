@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -11,8 +12,13 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/worker"
 )
 
+// heartbeatInterval is how often a running build records that it is still
+// being tracked.
+const heartbeatInterval = 30 * time.Second
+
 //go:generate counterfeiter . Engine
 
 type Engine interface {
@@ -32,9 +38,14 @@ type StepBuilder interface {
 	BuildStep(db.Build) (exec.Step, error)
 }
 
-func NewEngine(builder StepBuilder) Engine {
+// NewEngine returns an engine running builds with the given step builder.
+// Builds which could not be resumed and have not been tracked by any engine
+// for the stale build timeout are marked as errored; a zero timeout leaves
+// them running.
+func NewEngine(builder StepBuilder, staleBuildTimeout time.Duration) Engine {
 	return &engine{
-		builder: builder,
+		builder:           builder,
+		staleBuildTimeout: staleBuildTimeout,
 
 		release:       make(chan bool),
 		trackedStates: new(sync.Map),
@@ -43,7 +54,8 @@ func NewEngine(builder StepBuilder) Engine {
 }
 
 type engine struct {
-	builder StepBuilder
+	builder           StepBuilder
+	staleBuildTimeout time.Duration
 
 	release       chan bool
 	trackedStates *sync.Map
@@ -62,6 +74,7 @@ func (engine *engine) LookupBuild(logger lager.Logger, build db.Build) Build {
 		engine.release,
 		engine.trackedStates,
 		engine.waitGroup,
+		engine.staleBuildTimeout,
 	)
 }
 
@@ -85,6 +98,7 @@ func NewBuild(
 	release chan bool,
 	trackedStates *sync.Map,
 	waitGroup *sync.WaitGroup,
+	staleBuildTimeout time.Duration,
 ) Build {
 	return &execBuild{
 		ctx:    ctx,
@@ -96,6 +110,8 @@ func NewBuild(
 		release:       release,
 		trackedStates: trackedStates,
 		waitGroup:     waitGroup,
+
+		staleBuildTimeout: staleBuildTimeout,
 	}
 }
 
@@ -109,6 +125,8 @@ type execBuild struct {
 	release       chan bool
 	trackedStates *sync.Map
 	waitGroup     *sync.WaitGroup
+
+	staleBuildTimeout time.Duration
 }
 
 func (build *execBuild) Resume(logger lager.Logger) {
@@ -161,6 +179,7 @@ func (build *execBuild) Resume(logger lager.Logger) {
 	step, err := build.builder.BuildStep(build.build)
 	if err != nil {
 		logger.Error("failed-to-build-step", err)
+		build.errorIfStale(logger, err)
 		return
 	}
 
@@ -184,6 +203,22 @@ func (build *execBuild) Resume(logger lager.Logger) {
 		}
 	}()
 
+	build.heartbeat(logger)
+
+	go func() {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-noleak:
+				return
+			case <-ticker.C:
+				build.heartbeat(logger)
+			}
+		}
+	}()
+
 	done := make(chan error)
 	go func() {
 		ctx := lagerctx.NewContext(build.ctx, logger)
@@ -199,14 +234,50 @@ func (build *execBuild) Resume(logger lager.Logger) {
 	}
 }
 
+// errorIfStale marks the build as errored if it could not be resumed and no
+// engine has tracked it for the stale build timeout, e.g. because the ATC
+// that was running it went away, so that it does not stay started forever.
+func (build *execBuild) errorIfStale(logger lager.Logger, cause error) {
+	if build.staleBuildTimeout == 0 {
+		return
+	}
+
+	stale, err := build.build.IsStale(build.staleBuildTimeout)
+	if err != nil {
+		logger.Error("failed-to-check-if-build-is-stale", err)
+		return
+	}
+
+	if !stale {
+		return
+	}
+
+	err = build.build.MarkAsErrored(fmt.Errorf("build could not be resumed after not being tracked for %s: %s", build.staleBuildTimeout, cause), db.ErrorCategoryUnknown)
+	if err != nil {
+		logger.Error("failed-to-mark-stale-build-as-errored", err)
+		return
+	}
+
+	logger.Info("marked-stale-build-as-errored")
+}
+
+func (build *execBuild) heartbeat(logger lager.Logger) {
+	if err := build.build.Heartbeat(); err != nil {
+		logger.Error("failed-to-heartbeat", err)
+	}
+}
+
 func (build *execBuild) finish(logger lager.Logger, err error, succeeded bool) {
 	if err == context.Canceled {
 		build.saveStatus(logger, atc.StatusAborted)
 		logger.Info("aborted")
 
 	} else if err != nil {
-		build.saveStatus(logger, atc.StatusErrored)
-		logger.Info("errored", lager.Data{"error": err.Error()})
+		category := errorCategory(err)
+		if err := build.build.MarkAsErrored(err, category); err != nil {
+			logger.Error("failed-to-mark-build-as-errored", err)
+		}
+		logger.Info("errored", lager.Data{"error": err.Error(), "category": category})
 
 	} else if succeeded {
 		build.saveStatus(logger, atc.StatusSucceeded)
@@ -218,6 +289,24 @@ func (build *execBuild) finish(logger lager.Logger, err error, succeeded bool) {
 	}
 }
 
+// errorCategory tells apart the errors caused by the workers or by timeouts
+// from everything else.
+func errorCategory(err error) db.ErrorCategory {
+	switch err.(type) {
+	case worker.NoCompatibleWorkersError:
+		return db.ErrorCategoryWorker
+	}
+
+	switch err {
+	case worker.ErrNoWorkers, worker.ErrMissingVolume:
+		return db.ErrorCategoryWorker
+	case context.DeadlineExceeded:
+		return db.ErrorCategoryTimeout
+	}
+
+	return db.ErrorCategoryUnknown
+}
+
 func (build *execBuild) saveStatus(logger lager.Logger, status atc.BuildStatus) {
 	if err := build.build.Finish(db.BuildStatus(status)); err != nil {
 		logger.Error("failed-to-finish-build", err)
@@ -253,8 +342,9 @@ func (build *execBuild) trackFinished(logger lager.Logger) {
 			BuildName:     build.build.Name(),
 			BuildID:       build.build.ID(),
 			BuildStatus:   build.build.Status(),
-			BuildDuration: build.build.EndTime().Sub(build.build.StartTime()),
+			BuildDuration: build.build.Duration(),
 			TeamName:      build.build.TeamName(),
+			ErrorCategory: build.build.ErrorCategory(),
 		}.Emit(logger)
 	}
 }
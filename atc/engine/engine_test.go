@@ -15,6 +15,7 @@ import (
 	"github.com/concourse/concourse/atc/engine/enginefakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -46,7 +47,7 @@ var _ = Describe("Engine", func() {
 		)
 
 		BeforeEach(func() {
-			engine = NewEngine(fakeStepBuilder)
+			engine = NewEngine(fakeStepBuilder, 10*time.Minute)
 		})
 
 		JustBeforeEach(func() {
@@ -86,6 +87,7 @@ var _ = Describe("Engine", func() {
 				release,
 				trackedStates,
 				waitGroup,
+				10*time.Minute,
 			)
 		})
 
@@ -147,6 +149,11 @@ var _ = Describe("Engine", func() {
 								Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
 							})
 
+							It("records that the build is tracked", func() {
+								waitGroup.Wait()
+								Expect(fakeBuild.HeartbeatCallCount()).To(Equal(1))
+							})
+
 							Context("when the build is released", func() {
 								BeforeEach(func() {
 									readyToRelease := make(chan bool)
@@ -226,10 +233,39 @@ var _ = Describe("Engine", func() {
 									fakeStep.RunReturns(errors.New("nope"))
 								})
 
-								It("finishes the build", func() {
+								It("marks the build as errored", func() {
 									waitGroup.Wait()
-									Expect(fakeBuild.FinishCallCount()).To(Equal(1))
-									Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusErrored))
+									Expect(fakeBuild.FinishCallCount()).To(Equal(0))
+									Expect(fakeBuild.MarkAsErroredCallCount()).To(Equal(1))
+									cause, category := fakeBuild.MarkAsErroredArgsForCall(0)
+									Expect(cause).To(Equal(errors.New("nope")))
+									Expect(category).To(Equal(db.ErrorCategoryUnknown))
+								})
+							})
+
+							Context("when the build errors because there are no workers", func() {
+								BeforeEach(func() {
+									fakeStep.RunReturns(worker.ErrNoWorkers)
+								})
+
+								It("marks the build as errored by a worker", func() {
+									waitGroup.Wait()
+									Expect(fakeBuild.MarkAsErroredCallCount()).To(Equal(1))
+									_, category := fakeBuild.MarkAsErroredArgsForCall(0)
+									Expect(category).To(Equal(db.ErrorCategoryWorker))
+								})
+							})
+
+							Context("when the build errors because it timed out", func() {
+								BeforeEach(func() {
+									fakeStep.RunReturns(context.DeadlineExceeded)
+								})
+
+								It("marks the build as errored by a timeout", func() {
+									waitGroup.Wait()
+									Expect(fakeBuild.MarkAsErroredCallCount()).To(Equal(1))
+									_, category := fakeBuild.MarkAsErroredArgsForCall(0)
+									Expect(category).To(Equal(db.ErrorCategoryTimeout))
 								})
 							})
 
@@ -258,6 +294,29 @@ var _ = Describe("Engine", func() {
 							It("closes the notifier", func() {
 								Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
 							})
+
+							It("does not record that the build is tracked", func() {
+								Expect(fakeBuild.HeartbeatCallCount()).To(BeZero())
+							})
+
+							It("checks whether the build is stale", func() {
+								Expect(fakeBuild.IsStaleCallCount()).To(Equal(1))
+								Expect(fakeBuild.IsStaleArgsForCall(0)).To(Equal(10 * time.Minute))
+							})
+
+							It("leaves the build running", func() {
+								Expect(fakeBuild.MarkAsErroredCallCount()).To(BeZero())
+							})
+
+							Context("when the build has not been tracked for too long", func() {
+								BeforeEach(func() {
+									fakeBuild.IsStaleReturns(true, nil)
+								})
+
+								It("marks the build as errored", func() {
+									Expect(fakeBuild.MarkAsErroredCallCount()).To(Equal(1))
+								})
+							})
 						})
 					})
 
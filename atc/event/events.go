@@ -3,13 +3,34 @@ package event
 import "github.com/concourse/concourse/atc"
 
 type Error struct {
-	Message string `json:"message"`
-	Origin  Origin `json:"origin"`
-	Time    int64  `json:"time"`
+	Message  string `json:"message"`
+	Category string `json:"category,omitempty"`
+	Origin   Origin `json:"origin"`
+	Time     int64  `json:"time"`
 }
 
 func (Error) EventType() atc.EventType  { return EventTypeError }
-func (Error) Version() atc.EventVersion { return "4.1" }
+func (Error) Version() atc.EventVersion { return "4.2" }
+
+// Heartbeat is sent on build event streams that have been idle for a while. It
+// is not part of the build's events and should not be displayed.
+type Heartbeat struct {
+	Time int64 `json:"time"`
+}
+
+func (Heartbeat) EventType() atc.EventType  { return EventTypeHeartbeat }
+func (Heartbeat) Version() atc.EventVersion { return "1.0" }
+
+// Metric is a named measurement saved along with a build's events, so that
+// consumers of the stream can aggregate e.g. step timings.
+type Metric struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+	Time  int64   `json:"time"`
+}
+
+func (Metric) EventType() atc.EventType  { return EventTypeMetric }
+func (Metric) Version() atc.EventVersion { return "1.0" }
 
 type FinishTask struct {
 	Time       int64  `json:"time"`
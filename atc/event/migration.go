@@ -0,0 +1,75 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// MigrateFunc upgrades the payload of an event from one major version to the
+// next.
+type MigrateFunc func(payload []byte) ([]byte, error)
+
+type migrationKey struct {
+	typ   atc.EventType
+	major int
+}
+
+var migrations = map[migrationKey]MigrateFunc{}
+
+// RegisterMigration registers fn to upgrade events of the given type from
+// major version fromVersion to fromVersion+1. Migrations are chained, so an
+// event saved a few major versions ago is upgraded one version at a time
+// until no migration is left for it.
+func RegisterMigration(typ atc.EventType, fromVersion int, fn MigrateFunc) {
+	migrations[migrationKey{typ, fromVersion}] = fn
+}
+
+// Migrate upgrades the envelope's payload through any migrations registered
+// for its type and version, and returns it with the version it was upgraded
+// to. Envelopes without an applicable migration are returned as-is.
+func Migrate(envelope Envelope) (Envelope, error) {
+	if envelope.Data == nil {
+		return envelope, nil
+	}
+
+	segs := strings.SplitN(string(envelope.Version), ".", 2)
+
+	major, err := strconv.Atoi(segs[0])
+	if err != nil {
+		return envelope, nil
+	}
+
+	payload := []byte(*envelope.Data)
+	migrated := false
+
+	for {
+		fn, found := migrations[migrationKey{envelope.Event, major}]
+		if !found {
+			break
+		}
+
+		payload, err = fn(payload)
+		if err != nil {
+			return Envelope{}, err
+		}
+
+		major++
+		migrated = true
+	}
+
+	if !migrated {
+		return envelope, nil
+	}
+
+	data := json.RawMessage(payload)
+
+	return Envelope{
+		Data:    &data,
+		Event:   envelope.Event,
+		Version: atc.EventVersion(fmt.Sprintf("%d.0", major)),
+	}, nil
+}
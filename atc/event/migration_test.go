@@ -0,0 +1,61 @@
+package event_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+)
+
+var _ = Describe("Migrate", func() {
+	envelope := func(typ atc.EventType, version atc.EventVersion, payload string) event.Envelope {
+		data := json.RawMessage(payload)
+		return event.Envelope{
+			Data:    &data,
+			Event:   typ,
+			Version: version,
+		}
+	}
+
+	BeforeEach(func() {
+		event.RegisterMigration("migrated", 1, func(payload []byte) ([]byte, error) {
+			return bytes.Replace(payload, []byte(`"msg"`), []byte(`"message"`), 1), nil
+		})
+
+		event.RegisterMigration("migrated", 2, func(payload []byte) ([]byte, error) {
+			return bytes.Replace(payload, []byte(`"message"`), []byte(`"text"`), 1), nil
+		})
+
+		event.RegisterMigration("broken", 1, func(payload []byte) ([]byte, error) {
+			return nil, errors.New("nope")
+		})
+	})
+
+	It("upgrades old payloads through every migration in turn", func() {
+		migrated, err := event.Migrate(envelope("migrated", "1.1", `{"msg":"hi"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(migrated).To(Equal(envelope("migrated", "3.0", `{"text":"hi"}`)))
+	})
+
+	It("only applies the migrations from the saved version", func() {
+		migrated, err := event.Migrate(envelope("migrated", "2.0", `{"message":"hi"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(migrated).To(Equal(envelope("migrated", "3.0", `{"text":"hi"}`)))
+	})
+
+	It("leaves current payloads alone", func() {
+		migrated, err := event.Migrate(envelope("migrated", "3.1", `{"text":"hi"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(migrated).To(Equal(envelope("migrated", "3.1", `{"text":"hi"}`)))
+	})
+
+	It("returns the error of a failing migration", func() {
+		_, err := event.Migrate(envelope("broken", "1.0", `{}`))
+		Expect(err).To(MatchError("nope"))
+	})
+})
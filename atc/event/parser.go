@@ -24,10 +24,23 @@ func unmarshaler(e atc.Event) func([]byte) (atc.Event, error) {
 }
 
 func RegisterEvent(e atc.Event) {
-	versions, found := events[e.EventType()]
+	Register(e.EventType(), func() atc.Event { return e })
+}
+
+// Register adds events made by the given factory to the registry under the
+// given type, so that events defined outside of this package (e.g. by
+// plugins) can be parsed like the built-in ones. It panics if the factory
+// makes events of a different type, as they would not round-trip.
+func Register(typ atc.EventType, factory func() atc.Event) {
+	e := factory()
+	if e.EventType() != typ {
+		panic(fmt.Sprintf("event: registering %s with a factory for %s", typ, e.EventType()))
+	}
+
+	versions, found := events[typ]
 	if !found {
 		versions = eventVersions{}
-		events[e.EventType()] = versions
+		events[typ] = versions
 	}
 
 	versions[e.Version()] = unmarshaler(e)
@@ -46,6 +59,8 @@ func init() {
 	RegisterEvent(Status{})
 	RegisterEvent(Log{})
 	RegisterEvent(Error{})
+	RegisterEvent(Heartbeat{})
+	RegisterEvent(Metric{})
 
 	// deprecated:
 	RegisterEvent(InitializeV10{})
@@ -117,9 +132,21 @@ func (m *Message) UnmarshalJSON(bytes []byte) error {
 		return err
 	}
 
-	event, err := ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+	var payload []byte
+	if envelope.Data != nil {
+		payload = *envelope.Data
+	}
+
+	event, err := ParseEvent(envelope.Version, envelope.Event, payload)
 	if err != nil {
-		return err
+		if _, ok := err.(UnknownEventTypeError); !ok {
+			return err
+		}
+
+		event = Unknown{
+			Type:    envelope.Event,
+			Payload: json.RawMessage(payload),
+		}
 	}
 
 	m.Event = event
@@ -127,6 +154,27 @@ func (m *Message) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
+// Unknown is an event of a type that has not been registered, e.g. one saved
+// by a newer ATC during a rolling upgrade. Messages decode such events into
+// an Unknown rather than failing, keeping the payload as-is.
+type Unknown struct {
+	Type    atc.EventType
+	Payload json.RawMessage
+}
+
+func (u Unknown) EventType() atc.EventType { return u.Type }
+func (Unknown) Version() atc.EventVersion  { return "" }
+
+// MarshalJSON returns the original payload, so that an unknown event
+// survives being passed on.
+func (u Unknown) MarshalJSON() ([]byte, error) {
+	if u.Payload == nil {
+		return []byte("null"), nil
+	}
+
+	return u.Payload, nil
+}
+
 type UnknownEventTypeError struct {
 	Type atc.EventType
 }
@@ -1,6 +1,8 @@
 package event_test
 
 import (
+	"encoding/json"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -16,6 +18,13 @@ type fakeEvent struct {
 func (fakeEvent) EventType() atc.EventType  { return "fake" }
 func (fakeEvent) Version() atc.EventVersion { return "5.1" }
 
+type pluginEvent struct {
+	Value string `json:"value"`
+}
+
+func (pluginEvent) EventType() atc.EventType  { return "plugin" }
+func (pluginEvent) Version() atc.EventVersion { return "1.0" }
+
 var _ = Describe("ParseEvent", func() {
 	BeforeEach(func() {
 		event.RegisterEvent(fakeEvent{})
@@ -49,3 +58,50 @@ var _ = Describe("ParseEvent", func() {
 		}))
 	})
 })
+
+var _ = Describe("Register", func() {
+	BeforeEach(func() {
+		event.Register("plugin", func() atc.Event { return pluginEvent{} })
+	})
+
+	It("parses events of the registered type", func() {
+		e, err := event.ParseEvent("1.0", "plugin", []byte(`{"value":"sup"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(e).To(Equal(pluginEvent{Value: "sup"}))
+	})
+
+	It("panics if the factory makes events of a different type", func() {
+		Expect(func() {
+			event.Register("not-plugin", func() atc.Event { return pluginEvent{} })
+		}).To(Panic())
+	})
+})
+
+var _ = Describe("Message", func() {
+	It("decodes events of an unknown type into an Unknown event", func() {
+		var message event.Message
+		err := json.Unmarshal([]byte(`{"event":"fake-unknown","version":"1.0","data":{"hello":"sup"}}`), &message)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(message.Event).To(Equal(event.Unknown{
+			Type:    "fake-unknown",
+			Payload: json.RawMessage(`{"hello":"sup"}`),
+		}))
+	})
+
+	It("encodes an Unknown event with its original payload", func() {
+		payload, err := json.Marshal(event.Message{
+			Event: event.Unknown{
+				Type:    "fake-unknown",
+				Payload: json.RawMessage(`{"hello":"sup"}`),
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(payload).To(MatchJSON(`{"event":"fake-unknown","version":"","data":{"hello":"sup"}}`))
+	})
+
+	It("still fails to decode events of an incompatible version", func() {
+		var message event.Message
+		err := json.Unmarshal([]byte(`{"event":"log","version":"9.0","data":{}}`), &message)
+		Expect(err).To(BeAssignableToTypeOf(event.UnknownEventVersionError{}))
+	})
+})
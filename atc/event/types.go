@@ -38,4 +38,11 @@ const (
 
 	// error occurred
 	EventTypeError atc.EventType = "error"
+
+	// nothing happened for a while; never saved, only sent to keep streams
+	// alive
+	EventTypeHeartbeat atc.EventType = "heartbeat"
+
+	// a measurement taken while running the build (e.g. a step's duration)
+	EventTypeMetric atc.EventType = "metric"
 )
@@ -150,7 +150,8 @@ func NewGetStep(
 // fetched ArtifactSource is initialized, thus warming the worker's cache.
 //
 // At the end, the resulting ArtifactSource (either from using the cache or
-// fetching the resource) is registered under the step's SourceName.
+// fetching the resource) is registered under the step's SourceName. For a
+// pipeline resource, whether the cache was used is saved on the build's input.
 func (step *GetStep) Run(ctx context.Context, state RunState) error {
 	logger := lagerctx.FromContext(ctx)
 	logger = logger.Session("get-step", lager.Data{
@@ -221,7 +222,7 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 
 	step.delegate.Starting(logger)
 
-	versionedSource, err := step.resourceFetcher.Fetch(
+	versionedSource, reused, err := step.resourceFetcher.Fetch(
 		ctx,
 		logger,
 		resource.Session{
@@ -280,6 +281,16 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 			logger.Error("failed-to-save-resource-config-version", err, lager.Data{"name": step.name, "resource": step.resource, "version": versionedSource.Version()})
 			return err
 		}
+
+		err = step.build.SaveInput(db.BuildInput{
+			Name:       step.name,
+			Version:    version,
+			ResourceID: resource.ID(),
+		}, reused)
+		if err != nil {
+			// only used for reuse stats, so not worth failing the build over
+			logger.Error("failed-to-save-input", err, lager.Data{"name": step.name, "resource": step.resource})
+		}
 	}
 
 	step.succeeded = true
@@ -86,7 +86,7 @@ var _ = Describe("GetStep", func() {
 		state.ArtifactsReturns(artifactRepository)
 
 		fakeVersionedSource = new(resourcefakes.FakeVersionedSource)
-		fakeResourceFetcher.FetchReturns(fakeVersionedSource, nil)
+		fakeResourceFetcher.FetchReturns(fakeVersionedSource, false, nil)
 
 		fakeBuild = new(dbfakes.FakeBuild)
 		fakeBuild.IDReturns(buildID)
@@ -286,6 +286,7 @@ var _ = Describe("GetStep", func() {
 
 						BeforeEach(func() {
 							fakeResource = new(dbfakes.FakeResource)
+							fakeResource.IDReturns(7)
 							fakePipeline.ResourceReturns(fakeResource, true, nil)
 						})
 
@@ -310,6 +311,43 @@ var _ = Describe("GetStep", func() {
 								Expect(stepErr).To(Equal(disaster))
 							})
 						})
+
+						It("saves the input as newly fetched", func() {
+							Expect(fakeBuild.SaveInputCallCount()).To(Equal(1))
+
+							input, reused := fakeBuild.SaveInputArgsForCall(0)
+							Expect(input).To(Equal(db.BuildInput{
+								Name:       "some-name",
+								Version:    atc.Version{"some-version": "some-value"},
+								ResourceID: 7,
+							}))
+							Expect(reused).To(BeFalse())
+						})
+
+						Context("when the worker already has the version cached", func() {
+							BeforeEach(func() {
+								fakeResourceFetcher.FetchReturns(fakeVersionedSource, true, nil)
+							})
+
+							It("saves the input as reused", func() {
+								Expect(fakeBuild.SaveInputCallCount()).To(Equal(1))
+
+								_, reused := fakeBuild.SaveInputArgsForCall(0)
+								Expect(reused).To(BeTrue())
+							})
+						})
+
+						Context("when it fails to save the input", func() {
+							disaster := errors.New("oops")
+
+							BeforeEach(func() {
+								fakeBuild.SaveInputReturns(disaster)
+							})
+
+							It("does not fail the step", func() {
+								Expect(stepErr).ToNot(HaveOccurred())
+							})
+						})
 					})
 
 					Context("when it fails to find the resource", func() {
@@ -535,7 +573,7 @@ var _ = Describe("GetStep", func() {
 
 		Context("when fetching the resource exits unsuccessfully", func() {
 			BeforeEach(func() {
-				fakeResourceFetcher.FetchReturns(nil, resource.ErrResourceScriptFailed{
+				fakeResourceFetcher.FetchReturns(nil, false, resource.ErrResourceScriptFailed{
 					ExitStatus: 42,
 				})
 			})
@@ -560,7 +598,7 @@ var _ = Describe("GetStep", func() {
 			disaster := errors.New("oh no")
 
 			BeforeEach(func() {
-				fakeResourceFetcher.FetchReturns(nil, disaster)
+				fakeResourceFetcher.FetchReturns(nil, false, disaster)
 			})
 
 			It("does not finish the step via the delegate", func() {
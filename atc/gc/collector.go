@@ -22,6 +22,7 @@ type aggregateCollector struct {
 	containerCollector                  Collector
 	resourceConfigCheckSessionCollector Collector
 	artifactCollector                   Collector
+	pipelineConfigCollector             Collector
 }
 
 func NewCollector(
@@ -34,6 +35,7 @@ func NewCollector(
 	volumes Collector,
 	containers Collector,
 	resourceConfigCheckSessionCollector Collector,
+	pipelineConfigs Collector,
 ) Collector {
 	return &aggregateCollector{
 		buildCollector:                      buildCollector,
@@ -45,6 +47,7 @@ func NewCollector(
 		volumeCollector:                     volumes,
 		containerCollector:                  containers,
 		resourceConfigCheckSessionCollector: resourceConfigCheckSessionCollector,
+		pipelineConfigCollector:             pipelineConfigs,
 	}
 }
 
@@ -98,5 +101,10 @@ func (c *aggregateCollector) Run(ctx context.Context) error {
 		logger.Error("volume-collector", err)
 	}
 
+	err = c.pipelineConfigCollector.Run(ctx)
+	if err != nil {
+		logger.Error("pipeline-config-collector", err)
+	}
+
 	return nil
 }
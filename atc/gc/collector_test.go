@@ -23,6 +23,7 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeVolumeCollector                     *gcfakes.FakeCollector
 		fakeContainerCollector                  *gcfakes.FakeCollector
 		fakeResourceConfigCheckSessionCollector *gcfakes.FakeCollector
+		fakePipelineConfigCollector             *gcfakes.FakeCollector
 
 		err      error
 		disaster error
@@ -38,6 +39,7 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeVolumeCollector = new(gcfakes.FakeCollector)
 		fakeContainerCollector = new(gcfakes.FakeCollector)
 		fakeResourceConfigCheckSessionCollector = new(gcfakes.FakeCollector)
+		fakePipelineConfigCollector = new(gcfakes.FakeCollector)
 
 		subject = NewCollector(
 			fakeBuildCollector,
@@ -49,6 +51,7 @@ var _ = Describe("Aggregate Collector", func() {
 			fakeVolumeCollector,
 			fakeContainerCollector,
 			fakeResourceConfigCheckSessionCollector,
+			fakePipelineConfigCollector,
 		)
 
 		disaster = errors.New("disaster")
@@ -81,6 +84,7 @@ var _ = Describe("Aggregate Collector", func() {
 				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeResourceConfigCheckSessionCollector.RunCallCount()).To(Equal(1))
+				Expect(fakePipelineConfigCollector.RunCallCount()).To(Equal(1))
 			})
 		})
 
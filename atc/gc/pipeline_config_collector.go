@@ -0,0 +1,36 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+)
+
+type pipelineConfigCollector struct {
+	pipelineFactory  pipelineConfigPruner
+	versionsToRetain int
+}
+
+type pipelineConfigPruner interface {
+	PruneConfigVersions(keep int) error
+}
+
+func NewPipelineConfigCollector(pipelineFactory pipelineConfigPruner, versionsToRetain int) Collector {
+	return &pipelineConfigCollector{
+		pipelineFactory:  pipelineFactory,
+		versionsToRetain: versionsToRetain,
+	}
+}
+
+func (p *pipelineConfigCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("pipeline-config-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	if p.versionsToRetain <= 0 {
+		return nil
+	}
+
+	return p.pipelineFactory.PruneConfigVersions(p.versionsToRetain)
+}
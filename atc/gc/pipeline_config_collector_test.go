@@ -0,0 +1,64 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PipelineConfigCollector", func() {
+	var (
+		pipelineConfigCollector Collector
+		fakePipelineFactory     *dbfakes.FakePipelineFactory
+		versionsToRetain        int
+	)
+
+	BeforeEach(func() {
+		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
+		versionsToRetain = 3
+	})
+
+	JustBeforeEach(func() {
+		pipelineConfigCollector = NewPipelineConfigCollector(fakePipelineFactory, versionsToRetain)
+	})
+
+	It("prunes all but the versions to retain", func() {
+		err := pipelineConfigCollector.Run(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakePipelineFactory.PruneConfigVersionsCallCount()).To(Equal(1))
+		Expect(fakePipelineFactory.PruneConfigVersionsArgsForCall(0)).To(Equal(3))
+	})
+
+	Context("when pruning fails", func() {
+		var disaster error
+
+		BeforeEach(func() {
+			disaster = errors.New("sorry pal")
+			fakePipelineFactory.PruneConfigVersionsReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			err := pipelineConfigCollector.Run(context.TODO())
+			Expect(err).To(Equal(disaster))
+		})
+	})
+
+	Context("when no versions are to be retained", func() {
+		BeforeEach(func() {
+			versionsToRetain = 0
+		})
+
+		It("doesn't prune anything", func() {
+			err := pipelineConfigCollector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakePipelineFactory.PruneConfigVersionsCallCount()).To(BeZero())
+		})
+	})
+})
@@ -55,7 +55,7 @@ var _ = Describe("ResourceCacheCollector", func() {
 				)
 				Expect(err).NotTo(HaveOccurred())
 
-				jobBuild, err = defaultJob.CreateBuild()
+				jobBuild, err = defaultJob.CreateBuild(db.BuildTriggerManual)
 				Expect(err).ToNot(HaveOccurred())
 
 				jobCache, err = resourceCacheFactory.FindOrCreateResourceCache(
@@ -138,7 +138,7 @@ var _ = Describe("ResourceCacheCollector", func() {
 
 					Context("when pipeline is paused", func() {
 						BeforeEach(func() {
-							err := defaultPipeline.Pause()
+							err := defaultPipeline.Pause("")
 							Expect(err).NotTo(HaveOccurred())
 						})
 
@@ -169,7 +169,7 @@ var _ = Describe("ResourceCacheCollector", func() {
 						var secondJobCache db.UsedResourceCache
 
 						BeforeEach(func() {
-							secondJobBuild, err = defaultJob.CreateBuild()
+							secondJobBuild, err = defaultJob.CreateBuild(db.BuildTriggerManual)
 							Expect(err).ToNot(HaveOccurred())
 
 							secondJobCache, err = resourceCacheFactory.FindOrCreateResourceCache(
@@ -220,7 +220,7 @@ var _ = Describe("ResourceCacheCollector", func() {
 							Expect(err).NotTo(HaveOccurred())
 							Expect(found).To(BeTrue())
 
-							secondJobBuild, err = secondJob.CreateBuild()
+							secondJobBuild, err = secondJob.CreateBuild(db.BuildTriggerManual)
 							Expect(err).ToNot(HaveOccurred())
 
 							secondJobCache, err = resourceCacheFactory.FindOrCreateResourceCache(
@@ -12,6 +12,11 @@ import (
 
 var Databases []db.Conn
 var DatabaseQueries = Meter(0)
+var DatabaseFailedQueries = Meter(0)
+
+// DatabaseQueryDuration is the cumulative time spent on queries, in
+// nanoseconds.
+var DatabaseQueryDuration = Meter(0)
 
 var ContainersCreated = Meter(0)
 var VolumesCreated = Meter(0)
@@ -333,23 +338,30 @@ type BuildFinished struct {
 	BuildStatus   db.BuildStatus
 	BuildDuration time.Duration
 	TeamName      string
+	ErrorCategory db.ErrorCategory
 }
 
 func (event BuildFinished) Emit(logger lager.Logger) {
+	attributes := map[string]string{
+		"pipeline":     event.PipelineName,
+		"job":          event.JobName,
+		"build_name":   event.BuildName,
+		"build_id":     strconv.Itoa(event.BuildID),
+		"build_status": string(event.BuildStatus),
+		"team_name":    event.TeamName,
+	}
+
+	if event.ErrorCategory != "" {
+		attributes["error_category"] = string(event.ErrorCategory)
+	}
+
 	emit(
 		logger.Session("build-finished"),
 		Event{
-			Name:  "build finished",
-			Value: ms(event.BuildDuration),
-			State: EventStateOK,
-			Attributes: map[string]string{
-				"pipeline":     event.PipelineName,
-				"job":          event.JobName,
-				"build_name":   event.BuildName,
-				"build_id":     strconv.Itoa(event.BuildID),
-				"build_status": string(event.BuildStatus),
-				"team_name":    event.TeamName,
-			},
+			Name:       "build finished",
+			Value:      ms(event.BuildDuration),
+			State:      EventStateOK,
+			Attributes: attributes,
 		},
 	)
 }
@@ -37,19 +37,108 @@ func tick(logger lager.Logger) {
 		},
 	)
 
+	emit(
+		logger.Session("database-failed-queries"),
+		Event{
+			Name:  "database failed queries",
+			Value: DatabaseFailedQueries.Delta(),
+			State: EventStateOK,
+		},
+	)
+
+	emit(
+		logger.Session("database-query-time"),
+		Event{
+			Name:  "database query time (ms)",
+			Value: int(time.Duration(DatabaseQueryDuration.Delta()) / time.Millisecond),
+			State: EventStateOK,
+		},
+	)
+
 	if len(Databases) > 0 {
 		for _, database := range Databases {
+			dbStats := database.Stats()
+
 			emit(
 				logger.Session("database-connections"),
 				Event{
 					Name:  "database connections",
-					Value: database.Stats().OpenConnections,
+					Value: dbStats.OpenConnections,
+					State: EventStateOK,
+					Attributes: map[string]string{
+						"ConnectionName": database.Name(),
+					},
+				},
+			)
+
+			emit(
+				logger.Session("database-connections-in-use"),
+				Event{
+					Name:  "database connections in use",
+					Value: dbStats.InUse,
+					State: EventStateOK,
+					Attributes: map[string]string{
+						"ConnectionName": database.Name(),
+					},
+				},
+			)
+
+			emit(
+				logger.Session("database-connection-waits"),
+				Event{
+					Name:  "database connection waits",
+					Value: int(dbStats.WaitCount),
+					State: EventStateOK,
+					Attributes: map[string]string{
+						"ConnectionName": database.Name(),
+					},
+				},
+			)
+
+			busStats := database.Bus().Stats()
+
+			emit(
+				logger.Session("notification-channels"),
+				Event{
+					Name:  "notification channels",
+					Value: busStats.Channels,
+					State: EventStateOK,
+					Attributes: map[string]string{
+						"ConnectionName": database.Name(),
+					},
+				},
+			)
+
+			emit(
+				logger.Session("notification-subscribers"),
+				Event{
+					Name:  "notification subscribers",
+					Value: busStats.Subscribers,
 					State: EventStateOK,
 					Attributes: map[string]string{
 						"ConnectionName": database.Name(),
 					},
 				},
 			)
+
+			listenerConnected := 0
+			listenerState := EventStateCritical
+			if busStats.Connected {
+				listenerConnected = 1
+				listenerState = EventStateOK
+			}
+
+			emit(
+				logger.Session("notification-listener-connected"),
+				Event{
+					Name:  "notification listener connected",
+					Value: listenerConnected,
+					State: listenerState,
+					Attributes: map[string]string{
+						"ConnectionName": database.Name(),
+					},
+				},
+			)
 		}
 	}
 
@@ -32,8 +32,12 @@ var _ = Describe("Periodic emission of metrics", func() {
 		emitterFactory.NewEmitterReturns(emitter, nil)
 		a := &dbfakes.FakeConn{}
 		a.NameReturns("A")
+		aBus := &dbfakes.FakeNotificationsBus{}
+		aBus.StatsReturns(db.BusStats{Channels: 2, Subscribers: 3, Connected: true})
+		a.BusReturns(aBus)
 		b := &dbfakes.FakeConn{}
 		b.NameReturns("B")
+		b.BusReturns(&dbfakes.FakeNotificationsBus{})
 		metric.Databases = []db.Conn{a, b}
 		metric.Initialize(nil, "test", map[string]string{})
 
@@ -80,4 +84,58 @@ var _ = Describe("Periodic emission of metrics", func() {
 			),
 		)
 	})
+
+	It("emits notification bus stats for each pool", func() {
+		Eventually(emitter.EmitCallCount).Should(BeNumerically(">=", 1))
+		Expect(emitter.Invocations()["Emit"]).To(
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":       Equal("notification channels"),
+						"Value":      Equal(2),
+						"Attributes": Equal(map[string]string{"ConnectionName": "A"}),
+					}),
+				),
+			),
+		)
+		Expect(emitter.Invocations()["Emit"]).To(
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":       Equal("notification subscribers"),
+						"Value":      Equal(3),
+						"Attributes": Equal(map[string]string{"ConnectionName": "A"}),
+					}),
+				),
+			),
+		)
+	})
+
+	It("emits the notification listener's connection state for each pool", func() {
+		Eventually(emitter.EmitCallCount).Should(BeNumerically(">=", 1))
+		Expect(emitter.Invocations()["Emit"]).To(
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":       Equal("notification listener connected"),
+						"Value":      Equal(1),
+						"State":      Equal(metric.EventStateOK),
+						"Attributes": Equal(map[string]string{"ConnectionName": "A"}),
+					}),
+				),
+			),
+		)
+		Expect(emitter.Invocations()["Emit"]).To(
+			ContainElement(
+				ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":       Equal("notification listener connected"),
+						"Value":      Equal(0),
+						"State":      Equal(metric.EventStateCritical),
+						"Attributes": Equal(map[string]string{"ConnectionName": "B"}),
+					}),
+				),
+			),
+		)
+	})
 })
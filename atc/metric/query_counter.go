@@ -1,7 +1,9 @@
 package metric
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc/db"
@@ -18,21 +20,59 @@ type countingConn struct {
 }
 
 func (e *countingConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
-	DatabaseQueries.Inc()
+	defer countQuery(time.Now())
+
+	rows, err := e.Conn.Query(query, args...)
+	if err != nil {
+		DatabaseFailedQueries.Inc()
+	}
 
-	return e.Conn.Query(query, args...)
+	return rows, err
 }
 
 func (e *countingConn) QueryRow(query string, args ...interface{}) squirrel.RowScanner {
-	DatabaseQueries.Inc()
+	defer countQuery(time.Now())
 
 	return e.Conn.QueryRow(query, args...)
 }
 
 func (e *countingConn) Exec(query string, args ...interface{}) (sql.Result, error) {
-	DatabaseQueries.Inc()
+	defer countQuery(time.Now())
+
+	result, err := e.Conn.Exec(query, args...)
+	if err != nil {
+		DatabaseFailedQueries.Inc()
+	}
+
+	return result, err
+}
+
+func (e *countingConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	defer countQuery(time.Now())
+
+	rows, err := e.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		DatabaseFailedQueries.Inc()
+	}
+
+	return rows, err
+}
+
+func (e *countingConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) squirrel.RowScanner {
+	defer countQuery(time.Now())
+
+	return e.Conn.QueryRowContext(ctx, query, args...)
+}
+
+func (e *countingConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer countQuery(time.Now())
 
-	return e.Conn.Exec(query, args...)
+	result, err := e.Conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		DatabaseFailedQueries.Inc()
+	}
+
+	return result, err
 }
 
 func (e *countingConn) Begin() (db.Tx, error) {
@@ -44,24 +84,77 @@ func (e *countingConn) Begin() (db.Tx, error) {
 	return &countingTx{Tx: tx}, nil
 }
 
+func (e *countingConn) ReadReplica() db.Conn {
+	return CountQueries(e.Conn.ReadReplica())
+}
+
+func (e *countingConn) TxRetry(fn func(db.Tx) error) error {
+	return db.RetryTx(e, fn)
+}
+
 type countingTx struct {
 	db.Tx
 }
 
 func (e *countingTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
-	DatabaseQueries.Inc()
+	defer countQuery(time.Now())
+
+	rows, err := e.Tx.Query(query, args...)
+	if err != nil {
+		DatabaseFailedQueries.Inc()
+	}
 
-	return e.Tx.Query(query, args...)
+	return rows, err
 }
 
 func (e *countingTx) QueryRow(query string, args ...interface{}) squirrel.RowScanner {
-	DatabaseQueries.Inc()
+	defer countQuery(time.Now())
 
 	return e.Tx.QueryRow(query, args...)
 }
 
 func (e *countingTx) Exec(query string, args ...interface{}) (sql.Result, error) {
-	DatabaseQueries.Inc()
+	defer countQuery(time.Now())
+
+	result, err := e.Tx.Exec(query, args...)
+	if err != nil {
+		DatabaseFailedQueries.Inc()
+	}
+
+	return result, err
+}
 
-	return e.Tx.Exec(query, args...)
+func (e *countingTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	defer countQuery(time.Now())
+
+	rows, err := e.Tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		DatabaseFailedQueries.Inc()
+	}
+
+	return rows, err
+}
+
+func (e *countingTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) squirrel.RowScanner {
+	defer countQuery(time.Now())
+
+	return e.Tx.QueryRowContext(ctx, query, args...)
+}
+
+func (e *countingTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer countQuery(time.Now())
+
+	result, err := e.Tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		DatabaseFailedQueries.Inc()
+	}
+
+	return result, err
+}
+
+// countQuery records a query and the time spent on it. Errors from QueryRow
+// only surface when the row is scanned, so they aren't counted as failures.
+func countQuery(start time.Time) {
+	DatabaseQueries.Inc()
+	DatabaseQueryDuration.IncDelta(int(time.Since(start)))
 }
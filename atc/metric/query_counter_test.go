@@ -1,7 +1,9 @@
 package metric_test
 
 import (
+	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -66,5 +68,30 @@ var _ = Describe("Counting Database Queries", func() {
 
 			Expect(metric.DatabaseQueries.Delta()).To(Equal(1))
 		})
+
+		It("counts failed queries", func() {
+			underlyingConn.QueryReturns(nil, errors.New("disaster"))
+
+			_, err := countingConn.Query("SELECT $1::int", 1)
+			Expect(err).To(MatchError("disaster"))
+
+			_, err = countingConn.Exec("SELECT $1::int", 1)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metric.DatabaseQueries.Delta()).To(Equal(2))
+			Expect(metric.DatabaseFailedQueries.Delta()).To(Equal(1))
+		})
+
+		It("accumulates the time spent on queries", func() {
+			underlyingConn.ExecStub = func(string, ...interface{}) (sql.Result, error) {
+				time.Sleep(10 * time.Millisecond)
+				return nil, nil
+			}
+
+			_, err := countingConn.Exec("SELECT $1::int", 1)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metric.DatabaseQueryDuration.Delta()).To(BeNumerically(">=", int(10*time.Millisecond)))
+		})
 	})
 })
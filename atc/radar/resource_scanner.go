@@ -110,6 +110,13 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceID int, fromVe
 		return 0, err
 	}
 
+	// only the periodic checks are paused; checks asked for by a user, e.g.
+	// with fly check-resource, still go ahead
+	if savedResource.Paused() && !mustComplete {
+		logger.Debug("resource-paused")
+		return interval, nil
+	}
+
 	resourceTypes, err := scanner.dbPipeline.ResourceTypes()
 	if err != nil {
 		logger.Error("failed-to-get-resource-types", err)
@@ -156,6 +156,25 @@ var _ = Describe("ResourceScanner", func() {
 			actualInterval, runErr = scanner.Run(scanLogger, 39)
 		})
 
+		Context("when the resource is paused", func() {
+			BeforeEach(func() {
+				fakeDBResource.PausedReturns(true)
+			})
+
+			It("does not check", func() {
+				Expect(fakeResourceConfigScope.AcquireResourceCheckingLockCallCount()).To(BeZero())
+				Expect(fakeResource.CheckCallCount()).To(BeZero())
+			})
+
+			It("returns the default interval", func() {
+				Expect(actualInterval).To(Equal(interval))
+			})
+
+			It("does not return an error", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+			})
+		})
+
 		Context("when the lock cannot be acquired", func() {
 			BeforeEach(func() {
 				results := make(chan bool, 4)
@@ -668,6 +687,17 @@ var _ = Describe("ResourceScanner", func() {
 				Expect(scanErr).NotTo(HaveOccurred())
 			})
 
+			Context("when the resource is paused", func() {
+				BeforeEach(func() {
+					fakeDBResource.PausedReturns(true)
+				})
+
+				It("checks anyway", func() {
+					Expect(scanErr).NotTo(HaveOccurred())
+					Expect(fakeResource.CheckCallCount()).To(Equal(1))
+				})
+			})
+
 			It("constructs the resource of the correct type", func() {
 				Expect(fakeDBResource.SetResourceConfigCallCount()).To(Equal(1))
 				_, resourceSource, resourceTypes := fakeDBResource.SetResourceConfigArgsForCall(0)
@@ -21,6 +21,8 @@ var ErrInterrupted = errors.New("interrupted")
 //go:generate counterfeiter . Fetcher
 
 type Fetcher interface {
+	// Fetch returns the fetched source along with whether it was already
+	// cached on the worker rather than fetched anew.
 	Fetch(
 		ctx context.Context,
 		logger lager.Logger,
@@ -30,7 +32,7 @@ type Fetcher interface {
 		resourceTypes creds.VersionedResourceTypes,
 		resourceInstance ResourceInstance,
 		imageFetchingDelegate worker.ImageFetchingDelegate,
-	) (VersionedSource, error)
+	) (VersionedSource, bool, error)
 }
 
 func NewFetcher(
@@ -60,7 +62,7 @@ func (f *fetcher) Fetch(
 	resourceTypes creds.VersionedResourceTypes,
 	resourceInstance ResourceInstance,
 	imageFetchingDelegate worker.ImageFetchingDelegate,
-) (VersionedSource, error) {
+) (VersionedSource, bool, error) {
 	containerSpec.Outputs = map[string]string{
 		"resource": ResourcesDir("get"),
 	}
@@ -70,26 +72,26 @@ func (f *fetcher) Fetch(
 	ticker := f.clock.NewTicker(GetResourceLockInterval)
 	defer ticker.Stop()
 
-	versionedSource, err := f.fetchWithLock(ctx, logger, source, imageFetchingDelegate.Stdout())
+	versionedSource, reused, err := f.fetchWithLock(ctx, logger, source, imageFetchingDelegate.Stdout())
 	if err != ErrFailedToGetLock {
-		return versionedSource, err
+		return versionedSource, reused, err
 	}
 
 	for {
 		select {
 		case <-ticker.C():
-			versionedSource, err := f.fetchWithLock(ctx, logger, source, imageFetchingDelegate.Stdout())
+			versionedSource, reused, err := f.fetchWithLock(ctx, logger, source, imageFetchingDelegate.Stdout())
 			if err != nil {
 				if err == ErrFailedToGetLock {
 					break
 				}
-				return nil, err
+				return nil, false, err
 			}
 
-			return versionedSource, nil
+			return versionedSource, reused, nil
 
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return nil, false, ctx.Err()
 		}
 	}
 }
@@ -99,19 +101,19 @@ func (f *fetcher) fetchWithLock(
 	logger lager.Logger,
 	source FetchSource,
 	stdout io.Writer,
-) (VersionedSource, error) {
+) (VersionedSource, bool, error) {
 	versionedSource, found, err := source.Find()
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	if found {
-		return versionedSource, nil
+		return versionedSource, true, nil
 	}
 
 	lockName, err := source.LockName()
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	lockLogger := logger.Session("lock-task", lager.Data{"lock-name": lockName})
@@ -119,15 +121,20 @@ func (f *fetcher) fetchWithLock(
 	lock, acquired, err := f.lockFactory.Acquire(lockLogger, lock.NewTaskLockID(lockName))
 	if err != nil {
 		lockLogger.Error("failed-to-get-lock", err)
-		return nil, ErrFailedToGetLock
+		return nil, false, ErrFailedToGetLock
 	}
 
 	if !acquired {
 		lockLogger.Debug("did-not-get-lock")
-		return nil, ErrFailedToGetLock
+		return nil, false, ErrFailedToGetLock
 	}
 
 	defer lock.Release()
 
-	return source.Create(ctx)
+	versionedSource, err = source.Create(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return versionedSource, false, nil
 }
@@ -34,6 +34,7 @@ var _ = Describe("Fetcher", func() {
 		fakeFetchSourceFactory *resourcefakes.FakeFetchSourceFactory
 
 		versionedSource resource.VersionedSource
+		reused          bool
 		fetchErr        error
 		teamID          = 123
 	)
@@ -59,7 +60,7 @@ var _ = Describe("Fetcher", func() {
 	})
 
 	JustBeforeEach(func() {
-		versionedSource, fetchErr = fetcher.Fetch(
+		versionedSource, reused, fetchErr = fetcher.Fetch(
 			ctx,
 			lagertest.NewTestLogger("test"),
 			resource.Session{},
@@ -158,6 +159,26 @@ var _ = Describe("Fetcher", func() {
 			It("returns the source", func() {
 				Expect(versionedSource).To(Equal(fakeVersionedSource))
 			})
+
+			It("reports it as not reused", func() {
+				Expect(reused).To(BeFalse())
+			})
+		})
+
+		Context("when the source is already on the worker", func() {
+			BeforeEach(func() {
+				fakeFetchSource.FindReturns(fakeVersionedSource, true, nil)
+			})
+
+			It("does not acquire a lock or create the source", func() {
+				Expect(fakeLockFactory.AcquireCallCount()).To(BeZero())
+				Expect(fakeFetchSource.CreateCallCount()).To(BeZero())
+			})
+
+			It("returns the source, reported as reused", func() {
+				Expect(versionedSource).To(Equal(fakeVersionedSource))
+				Expect(reused).To(BeTrue())
+			})
 		})
 
 		Context("when finding fails", func() {
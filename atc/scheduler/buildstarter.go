@@ -55,6 +55,12 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 	nextPendingBuildsForJob []db.Build,
 ) error {
 	for _, nextPendingBuild := range nextPendingBuildsForJob {
+		if job.Paused() && !nextPendingBuild.OverridesPause() {
+			// skip rather than stop, so that builds overriding the pause are
+			// not held up by the builds queued before them
+			continue
+		}
+
 		started, err := s.tryStartNextPendingBuild(logger, nextPendingBuild, job, resources, resourceTypes)
 		if err != nil {
 			return err
@@ -80,6 +86,48 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		"build-name": nextPendingBuild.Name(),
 	})
 
+	if job.Config().Serial {
+		// hold the lock until the build has started, so that the max in
+		// flight check below can't race with another ATC
+		serialLock, acquired, err := s.pipeline.AcquireSerialLock(logger, job.Name())
+		if err != nil {
+			logger.Error("failed-to-acquire-serial-lock", err)
+			return false, err
+		}
+
+		if !acquired {
+			return false, nil
+		}
+
+		defer func() {
+			err := serialLock.Release()
+			if err != nil {
+				logger.Error("failed-to-release-serial-lock", err)
+			}
+		}()
+	}
+
+	if groups := job.Config().SerialGroups; len(groups) > 0 {
+		// jobs sharing a serial group count each other's builds towards max
+		// in flight, so they must not be started concurrently either
+		groupLock, acquired, err := s.pipeline.AcquireSerialGroupLock(logger, groups)
+		if err != nil {
+			logger.Error("failed-to-acquire-serial-group-lock", err)
+			return false, err
+		}
+
+		if !acquired {
+			return false, nil
+		}
+
+		defer func() {
+			err := groupLock.Release()
+			if err != nil {
+				logger.Error("failed-to-release-serial-group-lock", err)
+			}
+		}()
+	}
+
 	reachedMaxInFlight, err := s.maxInFlightUpdater.UpdateMaxInFlightReached(logger, job, nextPendingBuild.ID())
 	if err != nil {
 		return false, err
@@ -88,49 +136,60 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	if nextPendingBuild.IsManuallyTriggered() {
-		for _, input := range job.Config().Inputs() {
-			resource, found := resources.Lookup(input.Resource)
-
-			if !found {
-				logger.Debug("failed-to-find-resource")
-				return false, nil
+	var buildInputs []db.BuildInput
+	if nextPendingBuild.RerunOf() != 0 {
+		// reruns reuse the inputs copied from the original build
+		buildInputs, _, err = nextPendingBuild.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-rerun-build-inputs", err)
+			return false, err
+		}
+	} else {
+		if nextPendingBuild.IsManuallyTriggered() {
+			for _, input := range job.Config().Inputs() {
+				resource, found := resources.Lookup(input.Resource)
+
+				if !found {
+					logger.Debug("failed-to-find-resource")
+					return false, nil
+				}
+
+				if resource.CurrentPinnedVersion() != nil {
+					continue
+				}
+
+				if resource.LastCheckEndTime().Before(nextPendingBuild.CreateTime()) {
+					return false, nil
+				}
 			}
 
-			if resource.CurrentPinnedVersion() != nil {
-				continue
+			versions, err := s.pipeline.LoadVersionsDB()
+			if err != nil {
+				logger.Error("failed-to-load-versions-db", err)
+				return false, err
 			}
 
-			if resource.LastCheckEndTime().Before(nextPendingBuild.CreateTime()) {
-				return false, nil
+			_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job, resources)
+			if err != nil {
+				return false, err
 			}
-		}
 
-		versions, err := s.pipeline.LoadVersionsDB()
-		if err != nil {
-			logger.Error("failed-to-load-versions-db", err)
-			return false, err
+			dbResourceTypes, err := s.pipeline.ResourceTypes()
+			if err != nil {
+				return false, err
+			}
+			resourceTypes = dbResourceTypes.Deserialize()
 		}
 
-		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job, resources)
+		var found bool
+		buildInputs, found, err = job.GetNextBuildInputs()
 		if err != nil {
+			logger.Error("failed-to-get-next-build-inputs", err)
 			return false, err
 		}
-
-		dbResourceTypes, err := s.pipeline.ResourceTypes()
-		if err != nil {
-			return false, err
+		if !found {
+			return false, nil
 		}
-		resourceTypes = dbResourceTypes.Deserialize()
-	}
-
-	buildInputs, found, err := job.GetNextBuildInputs()
-	if err != nil {
-		logger.Error("failed-to-get-next-build-inputs", err)
-		return false, err
-	}
-	if !found {
-		return false, nil
 	}
 
 	pipelinePaused, err := s.pipeline.CheckPaused()
@@ -142,10 +201,6 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	if job.Paused() {
-		return false, nil
-	}
-
 	updated, err := nextPendingBuild.Schedule()
 	if err != nil {
 		logger.Error("failed-to-update-build-to-scheduled", err)
@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/algorithm"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/scheduler/inputmapper/inputmapperfakes"
 	"github.com/concourse/concourse/atc/scheduler/maxinflight/maxinflightfakes"
@@ -95,6 +96,96 @@ var _ = Describe("BuildStarter", func() {
 				Expect(actualBuildID).To(Equal(66))
 			})
 
+			It("does not take the serial lock", func() {
+				Expect(fakePipeline.AcquireSerialLockCallCount()).To(BeZero())
+			})
+
+			Context("when the job is serial", func() {
+				var fakeLock *lockfakes.FakeLock
+
+				BeforeEach(func() {
+					job.ConfigReturns(atc.JobConfig{Serial: true})
+
+					fakeLock = new(lockfakes.FakeLock)
+				})
+
+				Context("when the serial lock is acquired", func() {
+					BeforeEach(func() {
+						fakePipeline.AcquireSerialLockReturns(fakeLock, true, nil)
+					})
+
+					It("takes the job's serial lock", func() {
+						Expect(fakePipeline.AcquireSerialLockCallCount()).To(Equal(1))
+						_, jobName := fakePipeline.AcquireSerialLockArgsForCall(0)
+						Expect(jobName).To(Equal("some-job"))
+					})
+
+					It("releases it after trying to start the build", func() {
+						Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(Equal(1))
+						Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the serial lock is held elsewhere", func() {
+					BeforeEach(func() {
+						fakePipeline.AcquireSerialLockReturns(nil, false, nil)
+					})
+
+					It("does not start the build", func() {
+						Expect(tryStartErr).NotTo(HaveOccurred())
+						Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when acquiring the serial lock fails", func() {
+					BeforeEach(func() {
+						fakePipeline.AcquireSerialLockReturns(nil, false, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(tryStartErr).To(Equal(disaster))
+					})
+				})
+			})
+
+			Context("when the job has serial groups", func() {
+				var fakeLock *lockfakes.FakeLock
+
+				BeforeEach(func() {
+					job.ConfigReturns(atc.JobConfig{SerialGroups: []string{"prod", "staging"}})
+
+					fakeLock = new(lockfakes.FakeLock)
+				})
+
+				Context("when the serial group lock is acquired", func() {
+					BeforeEach(func() {
+						fakePipeline.AcquireSerialGroupLockReturns(fakeLock, true, nil)
+					})
+
+					It("takes the lock of the job's serial groups", func() {
+						Expect(fakePipeline.AcquireSerialGroupLockCallCount()).To(Equal(1))
+						_, groups := fakePipeline.AcquireSerialGroupLockArgsForCall(0)
+						Expect(groups).To(Equal([]string{"prod", "staging"}))
+					})
+
+					It("releases it after trying to start the build", func() {
+						Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(Equal(1))
+						Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the serial group lock is held elsewhere", func() {
+					BeforeEach(func() {
+						fakePipeline.AcquireSerialGroupLockReturns(nil, false, nil)
+					})
+
+					It("does not start the build", func() {
+						Expect(tryStartErr).NotTo(HaveOccurred())
+						Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(BeZero())
+					})
+				})
+			})
+
 			Context("when max in flight is reached", func() {
 				BeforeEach(func() {
 					fakeUpdater.UpdateMaxInFlightReachedReturns(true, nil)
@@ -281,6 +372,57 @@ var _ = Describe("BuildStarter", func() {
 			})
 		})
 
+		Context("when the build is a rerun", func() {
+			var rerunInputs []db.BuildInput
+
+			BeforeEach(func() {
+				job = new(dbfakes.FakeJob)
+				job.NameReturns("some-job")
+				job.ConfigReturns(atc.JobConfig{Plan: atc.PlanSequence{{Get: "some-input", Resource: "some-resource"}}})
+
+				rerunInputs = []db.BuildInput{{Name: "some-input", Version: atc.Version{"version": "v1"}}}
+
+				createdBuild.RerunOfReturns(42)
+				createdBuild.ResourcesReturns(rerunInputs, nil, nil)
+				createdBuild.ScheduleReturns(true, nil)
+				createdBuild.StartReturns(true, nil)
+			})
+
+			JustBeforeEach(func() {
+				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					job,
+					db.Resources{resource},
+					versionedResourceTypes,
+					pendingBuilds,
+				)
+			})
+
+			It("starts the build with the inputs of the original build", func() {
+				Expect(tryStartErr).NotTo(HaveOccurred())
+
+				Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(BeZero())
+				Expect(job.GetNextBuildInputsCallCount()).To(BeZero())
+
+				Expect(createdBuild.UseInputsCallCount()).To(Equal(1))
+				Expect(createdBuild.UseInputsArgsForCall(0)).To(Equal(rerunInputs))
+
+				Expect(fakeFactory.CreateCallCount()).To(Equal(1))
+				_, _, _, inputs := fakeFactory.CreateArgsForCall(0)
+				Expect(inputs).To(Equal(rerunInputs))
+			})
+
+			Context("when getting the inputs of the build fails", func() {
+				BeforeEach(func() {
+					createdBuild.ResourcesReturns(nil, nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(tryStartErr).To(Equal(disaster))
+				})
+			})
+		})
+
 		Context("when not manually triggered", func() {
 			BeforeEach(func() {
 				job = new(dbfakes.FakeJob)
@@ -608,7 +750,35 @@ var _ = Describe("BuildStarter", func() {
 						})
 
 						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
-						itUpdatedMaxInFlightForTheFirstBuild()
+
+						It("doesn't update max in flight", func() {
+							Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(BeZero())
+						})
+
+						Context("when the build overrides the pause", func() {
+							BeforeEach(func() {
+								pendingBuild1.OverridesPauseReturns(true)
+							})
+
+							It("marks the build as scheduled", func() {
+								Expect(pendingBuild1.ScheduleCallCount()).To(Equal(1))
+							})
+						})
+
+						Context("when a build queued behind another overrides the pause", func() {
+							BeforeEach(func() {
+								pendingBuild2.OverridesPauseReturns(true)
+							})
+
+							It("skips the builds not overriding the pause", func() {
+								Expect(pendingBuild1.ScheduleCallCount()).To(BeZero())
+								Expect(pendingBuild3.ScheduleCallCount()).To(BeZero())
+							})
+
+							It("marks the overriding build as scheduled", func() {
+								Expect(pendingBuild2.ScheduleCallCount()).To(Equal(1))
+							})
+						})
 					})
 				})
 			})
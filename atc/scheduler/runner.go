@@ -24,14 +24,22 @@ type BuildScheduler interface {
 	) (map[string]time.Duration, error)
 }
 
+//go:generate counterfeiter . Notifications
+
+type Notifications interface {
+	Listen(string) (chan bool, error)
+	Unlisten(string, chan bool) error
+}
+
 var errPipelineRemoved = errors.New("pipeline removed")
 
 type Runner struct {
-	Logger    lager.Logger
-	Pipeline  db.Pipeline
-	Scheduler BuildScheduler
-	Noop      bool
-	Interval  time.Duration
+	Logger        lager.Logger
+	Pipeline      db.Pipeline
+	Scheduler     BuildScheduler
+	Notifications Notifications
+	Noop          bool
+	Interval      time.Duration
 }
 
 func (runner *Runner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
@@ -47,6 +55,17 @@ func (runner *Runner) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	defer runner.Logger.Info("done")
 
+	// pausing or unpausing a job schedules the pipeline right away rather
+	// than on the next interval
+	channel := db.JobPausedChannel(runner.Pipeline.ID())
+
+	jobPaused, err := runner.Notifications.Listen(channel)
+	if err != nil {
+		return err
+	}
+
+	defer runner.Notifications.Unlisten(channel, jobPaused)
+
 dance:
 	for {
 		err := runner.tick(runner.Logger.Session("tick"))
@@ -56,6 +75,7 @@ dance:
 
 		select {
 		case <-time.After(runner.Interval):
+		case <-jobPaused:
 		case <-signals:
 			break dance
 		}
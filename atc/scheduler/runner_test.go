@@ -21,9 +21,12 @@ import (
 
 var _ = Describe("Runner", func() {
 	var (
-		fakePipeline *dbfakes.FakePipeline
-		scheduler    *schedulerfakes.FakeBuildScheduler
-		noop         bool
+		fakePipeline      *dbfakes.FakePipeline
+		scheduler         *schedulerfakes.FakeBuildScheduler
+		fakeNotifications *schedulerfakes.FakeNotifications
+		noop              bool
+		interval          time.Duration
+		jobPaused         chan bool
 
 		lock *lockfakes.FakeLock
 
@@ -40,6 +43,7 @@ var _ = Describe("Runner", func() {
 
 	BeforeEach(func() {
 		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.IDReturns(42)
 		fakePipeline.NameReturns("some-pipeline")
 
 		versionedResourceTypes = atc.VersionedResourceTypes{
@@ -77,6 +81,11 @@ var _ = Describe("Runner", func() {
 
 		scheduler = new(schedulerfakes.FakeBuildScheduler)
 		noop = false
+		interval = 100 * time.Millisecond
+
+		jobPaused = make(chan bool, 1)
+		fakeNotifications = new(schedulerfakes.FakeNotifications)
+		fakeNotifications.ListenReturns(jobPaused, nil)
 
 		someVersions = &algorithm.VersionsDB{
 			BuildOutputs: []algorithm.BuildOutput{
@@ -125,11 +134,12 @@ var _ = Describe("Runner", func() {
 
 	JustBeforeEach(func() {
 		process = ginkgomon.Invoke(&Runner{
-			Logger:    lagertest.NewTestLogger("test"),
-			Pipeline:  fakePipeline,
-			Scheduler: scheduler,
-			Noop:      noop,
-			Interval:  100 * time.Millisecond,
+			Logger:        lagertest.NewTestLogger("test"),
+			Pipeline:      fakePipeline,
+			Scheduler:     scheduler,
+			Notifications: fakeNotifications,
+			Noop:          noop,
+			Interval:      interval,
 		})
 	})
 
@@ -137,6 +147,36 @@ var _ = Describe("Runner", func() {
 		ginkgomon.Interrupt(process)
 	})
 
+	It("listens for jobs in the pipeline being paused or unpaused", func() {
+		Eventually(fakeNotifications.ListenCallCount).Should(Equal(1))
+		Expect(fakeNotifications.ListenArgsForCall(0)).To(Equal("job_paused_42"))
+	})
+
+	It("stops listening once it exits", func() {
+		ginkgomon.Interrupt(process)
+
+		Expect(fakeNotifications.UnlistenCallCount()).To(Equal(1))
+
+		channel, notifier := fakeNotifications.UnlistenArgsForCall(0)
+		Expect(channel).To(Equal("job_paused_42"))
+		Expect(notifier).To(Equal(jobPaused))
+	})
+
+	Context("when a job in the pipeline is paused or unpaused", func() {
+		BeforeEach(func() {
+			interval = time.Hour
+		})
+
+		It("schedules without waiting for the interval", func() {
+			Eventually(scheduler.ScheduleCallCount).Should(Equal(1))
+			Consistently(scheduler.ScheduleCallCount).Should(Equal(1))
+
+			jobPaused <- true
+
+			Eventually(scheduler.ScheduleCallCount).Should(Equal(2))
+		})
+	})
+
 	It("signs the scheduling lock for the pipeline", func() {
 		Eventually(fakePipeline.AcquireSchedulingLockCallCount).Should(BeNumerically(">=", 1))
 
@@ -78,7 +78,7 @@ func (s *Scheduler) ensurePendingBuildExists(
 		if ok && inputVersion.FirstOccurrence {
 			hasNewInputs = true
 			if inputConfig.Trigger {
-				err := job.EnsurePendingBuildExists()
+				err := job.EnsurePendingBuildExists(db.BuildTriggerResource)
 				if err != nil {
 					logger.Error("failed-to-ensure-pending-build-exists", err)
 					return err
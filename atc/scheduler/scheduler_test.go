@@ -277,8 +277,9 @@ var _ = Describe("Scheduler", func() {
 						Expect(scheduleErr).To(Equal(disaster))
 					})
 
-					It("created a pending build for the right job", func() {
+					It("created a resource-triggered pending build for the right job", func() {
 						Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(Equal(1))
+						Expect(fakeJob.EnsurePendingBuildExistsArgsForCall(0)).To(Equal(db.BuildTriggerResource))
 					})
 				})
 
@@ -176,7 +176,7 @@ func (i *imageResourceFetcher) Fetch(
 
 	// The random placement strategy is not really used because the image
 	// resource will always find the same worker as the container that owns it
-	versionedSource, err := i.resourceFetcher.Fetch(
+	versionedSource, _, err := i.resourceFetcher.Fetch(
 		ctx,
 		logger.Session("init-image"),
 		getSess,
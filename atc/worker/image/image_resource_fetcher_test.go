@@ -226,7 +226,7 @@ var _ = Describe("Image", func() {
 
 					Context("when fetching resource fails", func() {
 						BeforeEach(func() {
-							fakeResourceFetcher.FetchReturns(nil, resource.ErrInterrupted)
+							fakeResourceFetcher.FetchReturns(nil, false, resource.ErrInterrupted)
 						})
 
 						It("returns error", func() {
@@ -242,7 +242,7 @@ var _ = Describe("Image", func() {
 
 						BeforeEach(func() {
 							fakeVersionedSource = new(resourcefakes.FakeVersionedSource)
-							fakeResourceFetcher.FetchReturns(fakeVersionedSource, nil)
+							fakeResourceFetcher.FetchReturns(fakeVersionedSource, false, nil)
 
 							fakeVersionedSource.StreamOutReturns(tgzStreamWith("some-tar-contents"), nil)
 							fakeVolume := new(workerfakes.FakeVolume)
@@ -492,7 +492,7 @@ var _ = Describe("Image", func() {
 
 			Context("when fetching resource fails", func() {
 				BeforeEach(func() {
-					fakeResourceFetcher.FetchReturns(nil, resource.ErrInterrupted)
+					fakeResourceFetcher.FetchReturns(nil, false, resource.ErrInterrupted)
 				})
 
 				It("returns error", func() {
@@ -508,7 +508,7 @@ var _ = Describe("Image", func() {
 
 				BeforeEach(func() {
 					fakeVersionedSource = new(resourcefakes.FakeVersionedSource)
-					fakeResourceFetcher.FetchReturns(fakeVersionedSource, nil)
+					fakeResourceFetcher.FetchReturns(fakeVersionedSource, false, nil)
 
 					fakeVersionedSource.StreamOutReturns(tgzStreamWith("some-tar-contents"), nil)
 					fakeVolume := new(workerfakes.FakeVolume)
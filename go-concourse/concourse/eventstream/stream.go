@@ -31,6 +31,14 @@ func (s *SSEEventStream) NextEvent() (atc.Event, error) {
 		return nil, err
 	}
 
+	// heartbeats only keep the connection alive
+	for se.Name == "heartbeat" {
+		se, err = s.sseReader.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	switch se.Name {
 	case "event":
 		var message event.Message